@@ -3,6 +3,8 @@ package di
 import (
 	"DobrikaDev/user-service/internal/delivery"
 	"DobrikaDev/user-service/internal/service/balance"
+	"DobrikaDev/user-service/internal/service/idempotency"
+	"DobrikaDev/user-service/internal/service/reputation"
 	reputationgroup "DobrikaDev/user-service/internal/service/reputation_group"
 	"DobrikaDev/user-service/internal/service/user"
 	"DobrikaDev/user-service/internal/storage/sql"
@@ -24,7 +26,9 @@ type Container struct {
 	logger                 *zap.Logger
 	userService            *user.UserService
 	reputationGroupService *reputationgroup.ReputationGroupService
+	reputationService      *reputation.ReputationService
 	balanceService         *balance.BalanceService
+	idempotencyService     *idempotency.IdempotencyService
 	httpClient             *http.Client
 	server                 *delivery.Server
 	transactionFactory     *sqlxtrm.SqlxTransactionFactory
@@ -51,12 +55,24 @@ func (c *Container) GetReputationGroupService() *reputationgroup.ReputationGroup
 	})
 }
 
+func (c *Container) GetReputationService() *reputation.ReputationService {
+	return get(&c.reputationService, func() *reputation.ReputationService {
+		return reputation.NewReputationService(c.GetStorage(), c.cfg, c.logger)
+	})
+}
+
 func (c *Container) GetBalanceService() *balance.BalanceService {
 	return get(&c.balanceService, func() *balance.BalanceService {
 		return balance.NewBalanceService(c.GetStorage(), c.cfg, c.logger)
 	})
 }
 
+func (c *Container) GetIdempotencyService() *idempotency.IdempotencyService {
+	return get(&c.idempotencyService, func() *idempotency.IdempotencyService {
+		return idempotency.NewIdempotencyService(c.GetStorage(), c.cfg, c.logger)
+	})
+}
+
 func (c *Container) GetTransactionFactory() *sqlxtrm.SqlxTransactionFactory {
 	return get(&c.transactionFactory, func() *sqlxtrm.SqlxTransactionFactory {
 		return sqlxtrm.NewSqlxTransactionFactory(c.GetDB())
@@ -82,7 +98,7 @@ func (c *Container) GetDB() *sqlx.DB {
 
 func (c *Container) GetStorage() *sql.SqlStorage {
 	return get(&c.storage, func() *sql.SqlStorage {
-		return sql.NewStorage(c.GetTransactionFactory(), c.GetTransactionManager(), c.logger)
+		return sql.NewStorage(c.GetTransactionFactory(), c.GetTransactionManager(), c.cfg, c.logger)
 	})
 }
 
@@ -112,7 +128,7 @@ func (c *Container) GetGRPCServer() *grpc.Server {
 }
 func (c *Container) GetRpcServer() *delivery.Server {
 	return get(&c.server, func() *delivery.Server {
-		return delivery.NewServer(c.ctx, c.GetUserService(), c.GetReputationGroupService(), c.GetBalanceService(), c.cfg, c.logger)
+		return delivery.NewServer(c.ctx, c.GetUserService(), c.GetReputationGroupService(), c.GetReputationService(), c.GetBalanceService(), c.GetIdempotencyService(), c.cfg, c.logger)
 	})
 }
 
@@ -3,14 +3,23 @@ package delivery
 import (
 	"DobrikaDev/user-service/internal/domain"
 	userpb "DobrikaDev/user-service/internal/generated/proto/user"
+	"DobrikaDev/user-service/internal/service/balance"
 	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dr3dnought/gospadi"
 	"go.uber.org/zap"
 )
 
+var errInvalidCursor = errors.New("invalid cursor")
+
 func (s *Server) CreateOperation(ctx context.Context, req *userpb.CreateOperationRequest) (*userpb.CreateOperationResponse, error) {
-	if req.Amount <= 0 {
+	adjustment := req.Type == userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_ADJUSTMENT
+	if req.Amount <= 0 && !(adjustment && req.Amount < 0) {
 		return &userpb.CreateOperationResponse{
 			Error: &userpb.Error{
 				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
@@ -18,6 +27,22 @@ func (s *Server) CreateOperation(ctx context.Context, req *userpb.CreateOperatio
 			},
 		}, nil
 	}
+	if adjustment && (req.ActorMaxId == "" || strings.TrimSpace(req.Justification) == "") {
+		return &userpb.CreateOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "actor_max_id and justification are required for adjustments",
+			},
+		}, nil
+	}
+	if !adjustment && (req.ActorMaxId != "" || req.Justification != "" || req.CountsTowardsReputation) {
+		return &userpb.CreateOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "actor_max_id, justification and counts_towards_reputation are only supported for adjustments",
+			},
+		}, nil
+	}
 	if req.Type == userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED {
 		return &userpb.CreateOperationResponse{
 			Error: &userpb.Error{
@@ -26,11 +51,40 @@ func (s *Server) CreateOperation(ctx context.Context, req *userpb.CreateOperatio
 			},
 		}, nil
 	}
+	if req.ApplyCoefficient && req.Type != userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT {
+		return &userpb.CreateOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "apply_coefficient is only supported for deposits",
+			},
+		}, nil
+	}
 
-	operation, err := s.balanceService.CreateOperation(ctx, req.MaxId, &domain.BalanceOperation{
-		Amount:      int(req.Amount),
-		Type:        convertBalanceOperationTypeToDomain(req.Type),
-		Description: req.Description,
+	resp := &userpb.CreateOperationResponse{}
+	err := s.withIdempotency(ctx, "CreateOperation", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		operation := &domain.BalanceOperation{
+			Amount:      int(req.Amount),
+			Type:        convertBalanceOperationTypeToDomain(req.Type),
+			Description: req.Description,
+			ReasonCode:  convertBalanceOperationReasonToDomain(req.ReasonCode),
+			Metadata:    convertBalanceOperationMetadataToDomain(req.Metadata),
+			WalletType:  convertWalletTypeToDomain(req.WalletType),
+
+			ActorMaxID:              req.ActorMaxId,
+			Justification:           req.Justification,
+			CountsTowardsReputation: req.CountsTowardsReputation,
+		}
+		if req.ApplyCoefficient {
+			operation.BaseAmount = int(req.Amount)
+		}
+
+		operation, err := s.balanceService.CreateOperation(ctx, req.MaxId, operation)
+		if err != nil {
+			return err
+		}
+
+		resp.Operation = convertBalanceOperationToProto(operation)
+		return nil
 	})
 	if err != nil {
 		s.logger.Error("failed to create balance operation", zap.Error(err), zap.String("max_id", req.MaxId))
@@ -39,16 +93,95 @@ func (s *Server) CreateOperation(ctx context.Context, req *userpb.CreateOperatio
 		}, nil
 	}
 
-	return &userpb.CreateOperationResponse{
-		Operation: &userpb.BalanceOperation{
-			Id:          operation.ID,
-			BalanceId:   operation.BalanceID,
-			Amount:      int32(operation.Amount),
-			Type:        convertBalanceOperationTypeToProto(operation.Type),
-			Description: operation.Description,
-			CreatedAt:   int32(operation.CreatedAt.Unix()),
-		},
-	}, nil
+	return resp, nil
+}
+
+func (s *Server) TransferPoints(ctx context.Context, req *userpb.TransferPointsRequest) (*userpb.TransferPointsResponse, error) {
+	if req.FromMaxId == "" || req.ToMaxId == "" {
+		return &userpb.TransferPointsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "from_max_id and to_max_id are required",
+			},
+		}, nil
+	}
+	if req.FromMaxId == req.ToMaxId {
+		return &userpb.TransferPointsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "from_max_id and to_max_id must differ",
+			},
+		}, nil
+	}
+	if req.Amount <= 0 {
+		return &userpb.TransferPointsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "amount is required",
+			},
+		}, nil
+	}
+
+	resp := &userpb.TransferPointsResponse{}
+	err := s.withIdempotency(ctx, "TransferPoints", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		transfer, err := s.balanceService.TransferPoints(ctx, req.FromMaxId, req.ToMaxId, int(req.Amount), req.Description)
+		if err != nil {
+			return err
+		}
+
+		resp.TransferId = transfer.ID
+		resp.FromOperation = convertBalanceOperationToProto(transfer.FromOperation)
+		resp.ToOperation = convertBalanceOperationToProto(transfer.ToOperation)
+		resp.FromBalance = int32(transfer.FromBalance.Balance)
+		resp.ToBalance = int32(transfer.ToBalance.Balance)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to transfer points", zap.Error(err), zap.String("from_max_id", req.FromMaxId), zap.String("to_max_id", req.ToMaxId))
+		return &userpb.TransferPointsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ReverseOperation(ctx context.Context, req *userpb.ReverseOperationRequest) (*userpb.ReverseOperationResponse, error) {
+	if req.OperationId == "" {
+		return &userpb.ReverseOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "operation_id is required",
+			},
+		}, nil
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return &userpb.ReverseOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "reason is required",
+			},
+		}, nil
+	}
+
+	resp := &userpb.ReverseOperationResponse{}
+	err := s.withIdempotency(ctx, "ReverseOperation", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		operation, err := s.balanceService.ReverseOperation(ctx, req.OperationId, strings.TrimSpace(req.Reason))
+		if err != nil {
+			return err
+		}
+
+		resp.Operation = convertBalanceOperationToProto(operation)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to reverse balance operation", zap.Error(err), zap.String("operation_id", req.OperationId))
+		return &userpb.ReverseOperationResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
 }
 
 func (s *Server) GetBalance(ctx context.Context, req *userpb.GetBalanceRequest) (*userpb.GetBalanceResponse, error) {
@@ -60,7 +193,7 @@ func (s *Server) GetBalance(ctx context.Context, req *userpb.GetBalanceRequest)
 			},
 		}, nil
 	}
-	balance, err := s.balanceService.GetBalance(ctx, req.MaxId)
+	balance, err := s.balanceService.GetBalance(ctx, req.MaxId, convertWalletTypeToDomain(req.WalletType))
 	if err != nil {
 		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", req.MaxId))
 		return &userpb.GetBalanceResponse{
@@ -69,10 +202,20 @@ func (s *Server) GetBalance(ctx context.Context, req *userpb.GetBalanceRequest)
 	}
 
 	return &userpb.GetBalanceResponse{
-		Balance: int32(balance.Balance),
+		Balance:      int32(balance.Balance),
+		Available:    int32(balance.Available()),
+		ExpiringSoon: gospadi.Map(balance.ExpiringSoon, convertExpiringPointsToProto),
+		WalletType:   convertWalletTypeToProto(balance.WalletType),
 	}, nil
 }
 
+func convertExpiringPointsToProto(expiring *domain.ExpiringPoints) *userpb.ExpiringPoints {
+	return &userpb.ExpiringPoints{
+		Amount:    int32(expiring.Amount),
+		ExpiresAt: expiring.ExpiresAt.Unix(),
+	}
+}
+
 func (s *Server) GetBalanceOperations(ctx context.Context, req *userpb.GetBalanceOperationsRequest) (*userpb.GetBalanceOperationsResponse, error) {
 	if req.MaxId == "" {
 		return &userpb.GetBalanceOperationsResponse{
@@ -82,7 +225,78 @@ func (s *Server) GetBalanceOperations(ctx context.Context, req *userpb.GetBalanc
 			},
 		}, nil
 	}
-	operations, total, err := s.balanceService.GetBalanceOperations(ctx, req.MaxId, int(req.Limit), int(req.Offset))
+	if req.Cursor != "" && req.Offset > 0 {
+		return &userpb.GetBalanceOperationsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "cursor and offset cannot be combined",
+			},
+		}, nil
+	}
+	if req.AmountMin != nil && req.AmountMax != nil && *req.AmountMin > *req.AmountMax {
+		return &userpb.GetBalanceOperationsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "amount_min must not exceed amount_max",
+			},
+		}, nil
+	}
+	if req.CreatedFrom > 0 && req.CreatedTo > 0 && req.CreatedFrom >= req.CreatedTo {
+		return &userpb.GetBalanceOperationsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "created_from must be before created_to",
+			},
+		}, nil
+	}
+
+	filter := balance.GetBalanceOperationsFilter{
+		WalletType:  convertWalletTypeToDomain(req.WalletType),
+		Description: strings.TrimSpace(req.DescriptionContains),
+		Limit:       int(req.Limit),
+		Offset:      int(req.Offset),
+		SkipTotal:   req.SkipTotal,
+	}
+
+	for _, t := range req.Types {
+		filter.Types = append(filter.Types, convertBalanceOperationTypeToDomain(t))
+	}
+	for _, reason := range req.ReasonCodes {
+		if reason == userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED {
+			continue
+		}
+		filter.ReasonCodes = append(filter.ReasonCodes, convertBalanceOperationReasonToDomain(reason))
+	}
+	if req.CreatedFrom > 0 {
+		from := time.Unix(req.CreatedFrom, 0).UTC()
+		filter.CreatedFrom = &from
+	}
+	if req.CreatedTo > 0 {
+		to := time.Unix(req.CreatedTo, 0).UTC()
+		filter.CreatedTo = &to
+	}
+	if req.AmountMin != nil {
+		amountMin := int(*req.AmountMin)
+		filter.AmountMin = &amountMin
+	}
+	if req.AmountMax != nil {
+		amountMax := int(*req.AmountMax)
+		filter.AmountMax = &amountMax
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeBalanceOperationCursor(req.Cursor)
+		if err != nil {
+			return &userpb.GetBalanceOperationsResponse{
+				Error: &userpb.Error{
+					Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+					Message: "cursor is invalid",
+				},
+			}, nil
+		}
+		filter.Cursor = cursor
+	}
+
+	response, err := s.balanceService.GetBalanceOperations(ctx, req.MaxId, filter)
 	if err != nil {
 		s.logger.Error("failed to get balance operations", zap.Error(err), zap.String("max_id", req.MaxId), zap.Int("limit", int(req.Limit)), zap.Int("offset", int(req.Offset)))
 		return &userpb.GetBalanceOperationsResponse{
@@ -91,33 +305,513 @@ func (s *Server) GetBalanceOperations(ctx context.Context, req *userpb.GetBalanc
 	}
 
 	return &userpb.GetBalanceOperationsResponse{
-		Operations: convertBalanceOperationsToProto(operations),
-		Total:      total,
+		Operations: convertBalanceOperationsToProto(response.Operations),
+		Total:      response.Total,
+		NextCursor: encodeBalanceOperationCursor(response.NextCursor),
 	}, nil
 }
 
+func (s *Server) WatchBalance(req *userpb.WatchBalanceRequest, stream userpb.UserService_WatchBalanceServer) error {
+	ctx := stream.Context()
+
+	if req.MaxId == "" {
+		return stream.Send(&userpb.WatchBalanceResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		})
+	}
+
+	// Subscribe before reading the current balance so nothing committed in
+	// between is missed.
+	walletType := convertWalletTypeToDomain(req.WalletType)
+	changes, unsubscribe, err := s.balanceService.WatchBalance(ctx, req.MaxId, walletType)
+	if err != nil {
+		s.logger.Error("failed to watch balance", zap.Error(err), zap.String("max_id", req.MaxId))
+		return stream.Send(&userpb.WatchBalanceResponse{
+			Error: convertErrorToProto(err),
+		})
+	}
+	defer unsubscribe()
+
+	balance, err := s.balanceService.GetBalance(ctx, req.MaxId, walletType)
+	if err != nil {
+		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", req.MaxId))
+		return stream.Send(&userpb.WatchBalanceResponse{
+			Error: convertErrorToProto(err),
+		})
+	}
+
+	err = stream.Send(&userpb.WatchBalanceResponse{
+		Balance:   int32(balance.Balance),
+		Available: int32(balance.Available()),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change := <-changes:
+			err := stream.Send(&userpb.WatchBalanceResponse{
+				Balance:   int32(change.Balance),
+				Available: int32(change.Available()),
+				Operation: convertBalanceOperationToProto(change.Operation),
+				HoldId:    change.HoldID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) GetBalanceAt(ctx context.Context, req *userpb.GetBalanceAtRequest) (*userpb.GetBalanceAtResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.GetBalanceAtResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.Timestamp <= 0 {
+		return &userpb.GetBalanceAtResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "timestamp is required",
+			},
+		}, nil
+	}
+
+	balance, err := s.balanceService.GetBalanceAt(ctx, req.MaxId, convertWalletTypeToDomain(req.WalletType), time.Unix(req.Timestamp, 0).UTC())
+	if err != nil {
+		s.logger.Error("failed to get balance at", zap.Error(err), zap.String("max_id", req.MaxId), zap.Int64("timestamp", req.Timestamp))
+		return &userpb.GetBalanceAtResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetBalanceAtResponse{
+		Balance: int32(balance),
+	}, nil
+}
+
+func (s *Server) GetBalanceHistory(ctx context.Context, req *userpb.GetBalanceHistoryRequest) (*userpb.GetBalanceHistoryResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.GetBalanceHistoryResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.From <= 0 || req.To <= 0 || req.From >= req.To {
+		return &userpb.GetBalanceHistoryResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "from must be before to",
+			},
+		}, nil
+	}
+	if req.Granularity == userpb.BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_UNSPECIFIED {
+		return &userpb.GetBalanceHistoryResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "granularity is required",
+			},
+		}, nil
+	}
+
+	points, err := s.balanceService.GetBalanceHistory(ctx, req.MaxId, convertWalletTypeToDomain(req.WalletType),
+		time.Unix(req.From, 0).UTC(),
+		time.Unix(req.To, 0).UTC(),
+		convertBalanceHistoryGranularityToDomain(req.Granularity),
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance history", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.GetBalanceHistoryResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetBalanceHistoryResponse{
+		Points: gospadi.Map(points, convertBalanceHistoryPointToProto),
+	}, nil
+}
+
+func (s *Server) GetBalanceStats(ctx context.Context, req *userpb.GetBalanceStatsRequest) (*userpb.GetBalanceStatsResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.GetBalanceStatsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.From <= 0 || req.To <= 0 || req.From >= req.To {
+		return &userpb.GetBalanceStatsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "from must be before to",
+			},
+		}, nil
+	}
+
+	stats, err := s.balanceService.GetBalanceStats(ctx, req.MaxId, convertWalletTypeToDomain(req.WalletType),
+		time.Unix(req.From, 0).UTC(),
+		time.Unix(req.To, 0).UTC(),
+		convertBalanceStatsGroupByToDomain(req.GroupBy),
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance stats", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.GetBalanceStatsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetBalanceStatsResponse{
+		Earned:         stats.Earned,
+		Spent:          stats.Spent,
+		LifetimeEarned: stats.LifetimeEarned,
+		LifetimeSpent:  stats.LifetimeSpent,
+		Months:         gospadi.Map(stats.Months, convertBalanceStatsMonthToProto),
+		Groups:         gospadi.Map(stats.Groups, convertBalanceStatsGroupToProto),
+	}, nil
+}
+
+func convertBalanceStatsMonthToProto(month *domain.BalanceStatsMonth) *userpb.BalanceStatsMonth {
+	return &userpb.BalanceStatsMonth{
+		MonthStart: month.MonthStart.Unix(),
+		Earned:     month.Earned,
+		Spent:      month.Spent,
+	}
+}
+
+func convertBalanceStatsGroupToProto(group *domain.BalanceStatsGroup) *userpb.BalanceStatsGroup {
+	return &userpb.BalanceStatsGroup{
+		Key:    group.Key,
+		Count:  group.Count,
+		Earned: group.Earned,
+		Spent:  group.Spent,
+	}
+}
+
+func convertBalanceStatsGroupByToDomain(groupBy userpb.BalanceStatsGroupBy) domain.BalanceStatsGroupBy {
+	switch groupBy {
+	case userpb.BalanceStatsGroupBy_BALANCE_STATS_GROUP_BY_REASON:
+		return domain.BalanceStatsGroupByReason
+	case userpb.BalanceStatsGroupBy_BALANCE_STATS_GROUP_BY_DESCRIPTION:
+		return domain.BalanceStatsGroupByDescription
+	default:
+		return ""
+	}
+}
+
+func convertBalanceHistoryPointToProto(point *domain.BalanceHistoryPoint) *userpb.BalanceHistoryPoint {
+	return &userpb.BalanceHistoryPoint{
+		PeriodStart:    point.PeriodStart.Unix(),
+		PeriodEnd:      point.PeriodEnd.Unix(),
+		ClosingBalance: int32(point.Balance),
+	}
+}
+
+func convertBalanceHistoryGranularityToDomain(granularity userpb.BalanceHistoryGranularity) domain.BalanceHistoryGranularity {
+	switch granularity {
+	case userpb.BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_DAY:
+		return domain.BalanceHistoryGranularityDay
+	case userpb.BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_WEEK:
+		return domain.BalanceHistoryGranularityWeek
+	case userpb.BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_MONTH:
+		return domain.BalanceHistoryGranularityMonth
+	default:
+		return ""
+	}
+}
+
+func (s *Server) GetBalanceOperationTotals(ctx context.Context, req *userpb.GetBalanceOperationTotalsRequest) (*userpb.GetBalanceOperationTotalsResponse, error) {
+	if req.CreatedFrom > 0 && req.CreatedTo > 0 && req.CreatedFrom >= req.CreatedTo {
+		return &userpb.GetBalanceOperationTotalsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "created_from must be before created_to",
+			},
+		}, nil
+	}
+
+	var createdFrom, createdTo *time.Time
+	if req.CreatedFrom > 0 {
+		from := time.Unix(req.CreatedFrom, 0).UTC()
+		createdFrom = &from
+	}
+	if req.CreatedTo > 0 {
+		to := time.Unix(req.CreatedTo, 0).UTC()
+		createdTo = &to
+	}
+
+	totals, err := s.balanceService.GetBalanceOperationTotals(ctx, req.MaxId, convertWalletTypeToDomain(req.WalletType), createdFrom, createdTo)
+	if err != nil {
+		s.logger.Error("failed to get balance operation totals", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.GetBalanceOperationTotalsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetBalanceOperationTotalsResponse{
+		Totals: gospadi.Map(totals, convertBalanceOperationReasonTotalsToProto),
+	}, nil
+}
+
+func encodeBalanceOperationCursor(cursor *domain.BalanceOperationCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + ":" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeBalanceOperationCursor(encoded string) (*domain.BalanceOperationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BalanceOperationCursor{CreatedAt: time.Unix(0, unixNano).UTC(), ID: id}, nil
+}
+
+func (s *Server) GetTrialBalance(ctx context.Context, req *userpb.GetTrialBalanceRequest) (*userpb.GetTrialBalanceResponse, error) {
+	trialBalance, err := s.balanceService.GetTrialBalance(ctx)
+	if err != nil {
+		s.logger.Error("failed to get trial balance", zap.Error(err))
+		return &userpb.GetTrialBalanceResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetTrialBalanceResponse{
+		Lines:       gospadi.Map(trialBalance.Lines, convertTrialBalanceLineToProto),
+		TotalDebit:  trialBalance.TotalDebit,
+		TotalCredit: trialBalance.TotalCredit,
+		Balanced:    trialBalance.Balanced(),
+
+		WalletMismatches: gospadi.Map(trialBalance.WalletMismatches, convertLedgerWalletMismatchToProto),
+	}, nil
+}
+
+func (s *Server) ReconcileBalances(ctx context.Context, req *userpb.ReconcileBalancesRequest) (*userpb.ReconcileBalancesResponse, error) {
+	report, err := s.balanceService.Reconcile(ctx, req.Fix)
+	if err != nil {
+		s.logger.Error("failed to reconcile balances", zap.Error(err), zap.Bool("fix", req.Fix))
+		return &userpb.ReconcileBalancesResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.ReconcileBalancesResponse{
+		Scanned:    int32(report.Scanned),
+		Adjusted:   int32(report.Adjusted),
+		Mismatches: gospadi.Map(report.Mismatches, convertBalanceMismatchToProto),
+
+		NegativeBalanceIds: report.NegativeBalances,
+	}, nil
+}
+
+func convertBalanceMismatchToProto(mismatch *domain.BalanceMismatch) *userpb.BalanceMismatch {
+	return &userpb.BalanceMismatch{
+		BalanceId:             mismatch.BalanceID,
+		MaxId:                 mismatch.UserID,
+		Expected:              int32(mismatch.Expected),
+		Actual:                int32(mismatch.Actual),
+		Difference:            int32(mismatch.Difference()),
+		AdjustmentOperationId: mismatch.AdjustmentOperationID,
+	}
+}
+
+func convertLedgerWalletMismatchToProto(mismatch *domain.LedgerWalletMismatch) *userpb.LedgerWalletMismatch {
+	return &userpb.LedgerWalletMismatch{
+		Account:       mismatch.Account,
+		BalanceId:     mismatch.BalanceID,
+		LedgerBalance: mismatch.LedgerBalance,
+		Balance:       mismatch.Balance,
+	}
+}
+
+func convertTrialBalanceLineToProto(line *domain.TrialBalanceLine) *userpb.TrialBalanceLine {
+	return &userpb.TrialBalanceLine{
+		Account: line.Account,
+		Kind:    convertLedgerAccountKindToProto(line.Kind),
+		Debit:   line.Debit,
+		Credit:  line.Credit,
+	}
+}
+
+func convertLedgerAccountKindToProto(kind domain.LedgerAccountKind) userpb.LedgerAccountKind {
+	switch kind {
+	case domain.LedgerAccountKindWallet:
+		return userpb.LedgerAccountKind_LEDGER_ACCOUNT_KIND_WALLET
+	case domain.LedgerAccountKindSystem:
+		return userpb.LedgerAccountKind_LEDGER_ACCOUNT_KIND_SYSTEM
+	default:
+		return userpb.LedgerAccountKind_LEDGER_ACCOUNT_KIND_UNSPECIFIED
+	}
+}
+
 func convertBalanceOperationTypeToDomain(t userpb.BalanceOperationType) domain.BalanceOperationType {
 	switch t {
 	case userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT:
 		return domain.BalanceOperationTypeDeposit
 	case userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_WITHDRAW:
 		return domain.BalanceOperationTypeWithdraw
+	case userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_ADJUSTMENT:
+		return domain.BalanceOperationTypeAdjustment
+	case userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_EXPIRE:
+		return domain.BalanceOperationTypeExpire
 	default:
-		return domain.BalanceOperationTypeWithdraw
+		return ""
+	}
+}
+
+func convertBalanceOperationToProto(operation *domain.BalanceOperation) *userpb.BalanceOperation {
+	if operation == nil {
+		return nil
+	}
+	return &userpb.BalanceOperation{
+		Id:          operation.ID,
+		BalanceId:   operation.BalanceID,
+		Amount:      int32(operation.Amount),
+		Type:        convertBalanceOperationTypeToProto(operation.Type),
+		Description: operation.Description,
+		CreatedAt:   int32(operation.CreatedAt.Unix()),
+		TransferId:  operation.TransferID,
+
+		ReversesOperationId:   operation.ReversesOperationID,
+		ReversedByOperationId: operation.ReversedByOperationID,
+		ReasonCode:            convertBalanceOperationReasonToProto(operation.ReasonCode),
+		Metadata:              convertBalanceOperationMetadataToProto(operation.Metadata),
+		BaseAmount:            int32(operation.BaseAmount),
+		Coefficient:           operation.Coefficient,
+		WalletType:            convertWalletTypeToProto(operation.WalletType),
+		ActorMaxId:            operation.ActorMaxID,
+		Justification:         operation.Justification,
+	}
+}
+
+func convertBalanceOperationReasonTotalsToProto(totals *domain.BalanceOperationReasonTotals) *userpb.BalanceOperationReasonTotals {
+	return &userpb.BalanceOperationReasonTotals{
+		ReasonCode: convertBalanceOperationReasonToProto(totals.ReasonCode),
+		Count:      totals.Count,
+		Credited:   totals.Credited,
+		Debited:    totals.Debited,
+	}
+}
+
+func convertBalanceOperationMetadataToDomain(metadata *userpb.BalanceOperationMetadata) domain.BalanceOperationMetadata {
+	if metadata == nil {
+		return domain.BalanceOperationMetadata{}
+	}
+	return domain.BalanceOperationMetadata{
+		SourceService: metadata.SourceService,
+		TaskID:        metadata.TaskId,
+		EventID:       metadata.EventId,
+		Extra:         metadata.Extra,
+	}
+}
+
+func convertBalanceOperationMetadataToProto(metadata domain.BalanceOperationMetadata) *userpb.BalanceOperationMetadata {
+	return &userpb.BalanceOperationMetadata{
+		SourceService: metadata.SourceService,
+		TaskId:        metadata.TaskID,
+		EventId:       metadata.EventID,
+		Extra:         metadata.Extra,
+	}
+}
+
+func convertBalanceOperationReasonToDomain(reason userpb.BalanceOperationReason) domain.BalanceOperationReason {
+	switch reason {
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_TASK_REWARD:
+		return domain.BalanceOperationReasonTaskReward
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_EVENT_BONUS:
+		return domain.BalanceOperationReasonEventBonus
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_SHOP_PURCHASE:
+		return domain.BalanceOperationReasonShopPurchase
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_TRANSFER:
+		return domain.BalanceOperationReasonTransfer
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_REVERSAL:
+		return domain.BalanceOperationReasonReversal
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_ADJUSTMENT:
+		return domain.BalanceOperationReasonAdjustment
+	case userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_EXPIRY:
+		return domain.BalanceOperationReasonExpiry
+	default:
+		return domain.BalanceOperationReasonOther
+	}
+}
+
+func convertBalanceOperationReasonToProto(reason domain.BalanceOperationReason) userpb.BalanceOperationReason {
+	switch reason {
+	case domain.BalanceOperationReasonTaskReward:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_TASK_REWARD
+	case domain.BalanceOperationReasonEventBonus:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_EVENT_BONUS
+	case domain.BalanceOperationReasonShopPurchase:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_SHOP_PURCHASE
+	case domain.BalanceOperationReasonTransfer:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_TRANSFER
+	case domain.BalanceOperationReasonReversal:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_REVERSAL
+	case domain.BalanceOperationReasonAdjustment:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_ADJUSTMENT
+	case domain.BalanceOperationReasonOther:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_OTHER
+	case domain.BalanceOperationReasonExpiry:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_EXPIRY
+	default:
+		return userpb.BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
 	}
 }
 
 func convertBalanceOperationsToProto(operations []*domain.BalanceOperation) []*userpb.BalanceOperation {
-	return gospadi.Map(operations, func(operation *domain.BalanceOperation) *userpb.BalanceOperation {
-		return &userpb.BalanceOperation{
-			Id:          operation.ID,
-			BalanceId:   operation.BalanceID,
-			Amount:      int32(operation.Amount),
-			Type:        convertBalanceOperationTypeToProto(operation.Type),
-			Description: operation.Description,
-			CreatedAt:   int32(operation.CreatedAt.Unix()),
-		}
-	})
+	return gospadi.Map(operations, convertBalanceOperationToProto)
+}
+
+// An unspecified wallet is left empty for the service to resolve.
+func convertWalletTypeToDomain(walletType userpb.WalletType) domain.WalletType {
+	switch walletType {
+	case userpb.WalletType_WALLET_TYPE_POINTS:
+		return domain.WalletTypePoints
+	case userpb.WalletType_WALLET_TYPE_KARMA:
+		return domain.WalletTypeKarma
+	case userpb.WalletType_WALLET_TYPE_EVENT_TOKENS:
+		return domain.WalletTypeEventTokens
+	default:
+		return ""
+	}
+}
+
+func convertWalletTypeToProto(walletType domain.WalletType) userpb.WalletType {
+	switch walletType {
+	case domain.WalletTypePoints:
+		return userpb.WalletType_WALLET_TYPE_POINTS
+	case domain.WalletTypeKarma:
+		return userpb.WalletType_WALLET_TYPE_KARMA
+	case domain.WalletTypeEventTokens:
+		return userpb.WalletType_WALLET_TYPE_EVENT_TOKENS
+	default:
+		return userpb.WalletType_WALLET_TYPE_UNSPECIFIED
+	}
 }
 
 func convertBalanceOperationTypeToProto(t domain.BalanceOperationType) userpb.BalanceOperationType {
@@ -126,6 +820,10 @@ func convertBalanceOperationTypeToProto(t domain.BalanceOperationType) userpb.Ba
 		return userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT
 	case domain.BalanceOperationTypeWithdraw:
 		return userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_WITHDRAW
+	case domain.BalanceOperationTypeAdjustment:
+		return userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_ADJUSTMENT
+	case domain.BalanceOperationTypeExpire:
+		return userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_EXPIRE
 	default:
 		return userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
 	}
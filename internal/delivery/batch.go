@@ -0,0 +1,105 @@
+package delivery
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	userpb "DobrikaDev/user-service/internal/generated/proto/user"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+var errBatchRejected = errors.New("batch rejected")
+
+func (s *Server) CreateOperationsBatch(ctx context.Context, req *userpb.CreateOperationsBatchRequest) (*userpb.CreateOperationsBatchResponse, error) {
+	if len(req.Items) == 0 {
+		return &userpb.CreateOperationsBatchResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "items are required",
+			},
+		}, nil
+	}
+	if req.Mode == userpb.BatchMode_BATCH_MODE_UNSPECIFIED {
+		return &userpb.CreateOperationsBatchResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "mode is required",
+			},
+		}, nil
+	}
+
+	items := make([]*domain.BalanceOperationBatchItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, &domain.BalanceOperationBatchItem{
+			MaxID: item.MaxId,
+			Operation: &domain.BalanceOperation{
+				Amount:      int(item.Amount),
+				Type:        convertBatchOperationTypeToDomain(item.Type),
+				Description: item.Description,
+				ReasonCode:  convertBalanceOperationReasonToDomain(item.ReasonCode),
+				Metadata:    convertBalanceOperationMetadataToDomain(item.Metadata),
+				WalletType:  convertWalletTypeToDomain(item.WalletType),
+			},
+		})
+	}
+
+	resp := &userpb.CreateOperationsBatchResponse{}
+	err := s.withIdempotency(ctx, "CreateOperationsBatch", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		results, applied, err := s.balanceService.CreateOperationsBatch(ctx, items, req.Mode == userpb.BatchMode_BATCH_MODE_ALL_OR_NOTHING)
+		if err != nil {
+			return err
+		}
+
+		resp.Results = make([]*userpb.BatchOperationResult, 0, len(results))
+		for i, result := range results {
+			protoResult := &userpb.BatchOperationResult{
+				Index:     int32(i),
+				Operation: convertBalanceOperationToProto(result.Operation),
+			}
+			if result.Err != nil {
+				protoResult.Error = convertErrorToProto(result.Err)
+				resp.Failed++
+			} else if result.Operation != nil {
+				resp.Applied++
+			}
+			resp.Results = append(resp.Results, protoResult)
+		}
+
+		// A rejected batch is not stored under the idempotency key, so the
+		// caller can fix the failed items and retry with the same key.
+		if !applied && resp.Failed > 0 {
+			return errBatchRejected
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRejected) {
+		resp.Error = &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+			Message: fmt.Sprintf("batch rejected: %d of %d items failed", resp.Failed, len(req.Items)),
+		}
+		return resp, nil
+	}
+	if err != nil {
+		s.logger.Error("failed to create operations batch", zap.Error(err), zap.Int("items", len(req.Items)))
+		return &userpb.CreateOperationsBatchResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+// Anything but deposits and withdrawals is left empty so the item fails
+// validation.
+func convertBatchOperationTypeToDomain(t userpb.BalanceOperationType) domain.BalanceOperationType {
+	switch t {
+	case userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT:
+		return domain.BalanceOperationTypeDeposit
+	case userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_WITHDRAW:
+		return domain.BalanceOperationTypeWithdraw
+	default:
+		return ""
+	}
+}
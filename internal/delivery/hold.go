@@ -0,0 +1,150 @@
+package delivery
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	userpb "DobrikaDev/user-service/internal/generated/proto/user"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) CreateHold(ctx context.Context, req *userpb.CreateHoldRequest) (*userpb.CreateHoldResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.CreateHoldResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.Amount <= 0 {
+		return &userpb.CreateHoldResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "amount is required",
+			},
+		}, nil
+	}
+	if req.TtlSeconds < 0 {
+		return &userpb.CreateHoldResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "ttl_seconds must not be negative",
+			},
+		}, nil
+	}
+
+	resp := &userpb.CreateHoldResponse{}
+	err := s.withIdempotency(ctx, "CreateHold", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		hold, err := s.balanceService.CreateHold(ctx, req.MaxId, int(req.Amount), req.Description, time.Duration(req.TtlSeconds)*time.Second)
+		if err != nil {
+			return err
+		}
+
+		resp.Hold = convertHoldToProto(hold)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to create hold", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.CreateHoldResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+func (s *Server) CaptureHold(ctx context.Context, req *userpb.CaptureHoldRequest) (*userpb.CaptureHoldResponse, error) {
+	if req.HoldId == "" {
+		return &userpb.CaptureHoldResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "hold_id is required",
+			},
+		}, nil
+	}
+	if req.Amount < 0 {
+		return &userpb.CaptureHoldResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "amount must not be negative",
+			},
+		}, nil
+	}
+
+	resp := &userpb.CaptureHoldResponse{}
+	err := s.withIdempotency(ctx, "CaptureHold", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		hold, operation, err := s.balanceService.CaptureHold(ctx, req.HoldId, int(req.Amount))
+		if err != nil {
+			return err
+		}
+
+		resp.Hold = convertHoldToProto(hold)
+		resp.Operation = convertBalanceOperationToProto(operation)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to capture hold", zap.Error(err), zap.String("hold_id", req.HoldId))
+		return &userpb.CaptureHoldResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ReleaseHold(ctx context.Context, req *userpb.ReleaseHoldRequest) (*userpb.ReleaseHoldResponse, error) {
+	if req.HoldId == "" {
+		return &userpb.ReleaseHoldResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "hold_id is required",
+			},
+		}, nil
+	}
+
+	hold, err := s.balanceService.ReleaseHold(ctx, req.HoldId)
+	if err != nil {
+		s.logger.Error("failed to release hold", zap.Error(err), zap.String("hold_id", req.HoldId))
+		return &userpb.ReleaseHoldResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.ReleaseHoldResponse{
+		Hold: convertHoldToProto(hold),
+	}, nil
+}
+
+func convertHoldToProto(hold *domain.Hold) *userpb.Hold {
+	if hold == nil {
+		return nil
+	}
+	return &userpb.Hold{
+		Id:             hold.ID,
+		BalanceId:      hold.BalanceID,
+		Amount:         int32(hold.Amount),
+		CapturedAmount: int32(hold.CapturedAmount),
+		Status:         convertHoldStatusToProto(hold.Status),
+		Description:    hold.Description,
+		OperationId:    hold.OperationID,
+		ExpiresAt:      hold.ExpiresAt.Unix(),
+		CreatedAt:      hold.CreatedAt.Unix(),
+	}
+}
+
+func convertHoldStatusToProto(status domain.HoldStatus) userpb.HoldStatus {
+	switch status {
+	case domain.HoldStatusActive:
+		return userpb.HoldStatus_HOLD_STATUS_ACTIVE
+	case domain.HoldStatusCaptured:
+		return userpb.HoldStatus_HOLD_STATUS_CAPTURED
+	case domain.HoldStatusReleased:
+		return userpb.HoldStatus_HOLD_STATUS_RELEASED
+	case domain.HoldStatusExpired:
+		return userpb.HoldStatus_HOLD_STATUS_EXPIRED
+	default:
+		return userpb.HoldStatus_HOLD_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"DobrikaDev/user-service/internal/service/idempotency"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const idempotencyKeyField = "idempotency_key"
+
+// withIdempotency runs fn, which fills resp, through the idempotency service
+// when the caller sent a key. On a replay resp is overwritten with the stored
+// response of the first call.
+func (s *Server) withIdempotency(ctx context.Context, scope string, key string, req proto.Message, resp proto.Message, fn func(ctx context.Context) error) error {
+	if key == "" {
+		return fn(ctx)
+	}
+
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		s.logger.Error("failed to hash request", zap.Error(err), zap.String("scope", scope))
+		return idempotency.ErrIdempotencyKeyInternal
+	}
+
+	payload, err := s.idempotencyService.Do(ctx, scope, key, requestHash, func(txCtx context.Context) ([]byte, error) {
+		if err := fn(txCtx); err != nil {
+			return nil, err
+		}
+		return proto.Marshal(resp)
+	})
+	if err != nil {
+		return err
+	}
+
+	proto.Reset(resp)
+	if err := proto.Unmarshal(payload, resp); err != nil {
+		s.logger.Error("failed to decode stored response", zap.Error(err), zap.String("scope", scope), zap.String("key", key))
+		return idempotency.ErrIdempotencyKeyInternal
+	}
+
+	return nil
+}
+
+func hashRequest(req proto.Message) (string, error) {
+	clone := proto.Clone(req)
+	message := clone.ProtoReflect()
+	if field := message.Descriptor().Fields().ByName(idempotencyKeyField); field != nil {
+		message.Clear(field)
+	}
+
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
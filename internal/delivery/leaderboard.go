@@ -0,0 +1,90 @@
+package delivery
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	userpb "DobrikaDev/user-service/internal/generated/proto/user"
+	"context"
+	"strings"
+
+	"github.com/dr3dnought/gospadi"
+	"go.uber.org/zap"
+)
+
+func (s *Server) GetLeaderboard(ctx context.Context, req *userpb.GetLeaderboardRequest) (*userpb.GetLeaderboardResponse, error) {
+	if req.Metric == userpb.LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED {
+		return &userpb.GetLeaderboardResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "metric is required",
+			},
+		}, nil
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return &userpb.GetLeaderboardResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "limit and offset must not be negative",
+			},
+		}, nil
+	}
+
+	leaderboard, err := s.balanceService.GetLeaderboard(ctx, domain.LeaderboardQuery{
+		Period:            convertLeaderboardPeriodToDomain(req.Period),
+		Metric:            convertLeaderboardMetricToDomain(req.Metric),
+		Geolocation:       strings.TrimSpace(req.Geolocation),
+		ReputationGroupID: int(req.ReputationGroupId),
+		CallerMaxID:       req.CallerMaxId,
+		Limit:             int(req.Limit),
+		Offset:            int(req.Offset),
+	})
+	if err != nil {
+		s.logger.Error("failed to get leaderboard", zap.Error(err))
+		return &userpb.GetLeaderboardResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	resp := &userpb.GetLeaderboardResponse{
+		Entries: gospadi.Map(leaderboard.Entries, convertLeaderboardEntryToProto),
+		Total:   int32(leaderboard.Total),
+	}
+	if leaderboard.Caller != nil {
+		resp.Caller = convertLeaderboardEntryToProto(leaderboard.Caller)
+	}
+	if !leaderboard.RefreshedAt.IsZero() {
+		resp.RefreshedAt = leaderboard.RefreshedAt.Unix()
+	}
+
+	return resp, nil
+}
+
+func convertLeaderboardEntryToProto(entry *domain.LeaderboardEntry) *userpb.LeaderboardEntry {
+	return &userpb.LeaderboardEntry{
+		Rank:  int32(entry.Rank),
+		MaxId: entry.MaxID,
+		Name:  entry.Name,
+		Score: int32(entry.Score),
+	}
+}
+
+func convertLeaderboardPeriodToDomain(period userpb.LeaderboardPeriod) domain.LeaderboardPeriod {
+	switch period {
+	case userpb.LeaderboardPeriod_LEADERBOARD_PERIOD_MONTH:
+		return domain.LeaderboardPeriodMonth
+	case userpb.LeaderboardPeriod_LEADERBOARD_PERIOD_WEEK:
+		return domain.LeaderboardPeriodWeek
+	default:
+		return domain.LeaderboardPeriodAllTime
+	}
+}
+
+func convertLeaderboardMetricToDomain(metric userpb.LeaderboardMetric) domain.LeaderboardMetric {
+	switch metric {
+	case userpb.LeaderboardMetric_LEADERBOARD_METRIC_BALANCE:
+		return domain.LeaderboardMetricBalance
+	case userpb.LeaderboardMetric_LEADERBOARD_METRIC_EARNED:
+		return domain.LeaderboardMetricEarned
+	default:
+		return ""
+	}
+}
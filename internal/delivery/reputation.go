@@ -0,0 +1,275 @@
+package delivery
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	userpb "DobrikaDev/user-service/internal/generated/proto/user"
+	"context"
+
+	"github.com/dr3dnought/gospadi"
+	"go.uber.org/zap"
+)
+
+func (s *Server) AddReputationEvent(ctx context.Context, req *userpb.AddReputationEventRequest) (*userpb.AddReputationEventResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.AddReputationEventResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.Amount == 0 {
+		return &userpb.AddReputationEventResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "amount is required",
+			},
+		}, nil
+	}
+	switch req.Reason {
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_UNSPECIFIED,
+		userpb.ReputationEventReason_REPUTATION_EVENT_REASON_MIGRATION,
+		userpb.ReputationEventReason_REPUTATION_EVENT_REASON_DECAY:
+		return &userpb.AddReputationEventResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "reason is required",
+			},
+		}, nil
+	}
+
+	resp := &userpb.AddReputationEventResponse{}
+	err := s.withIdempotency(ctx, "AddReputationEvent", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		event, reputation, err := s.reputationService.AddReputationEvent(ctx, &domain.ReputationEvent{
+			MaxID:       req.MaxId,
+			Amount:      int(req.Amount),
+			Reason:      convertReputationEventReasonToDomain(req.Reason),
+			Description: req.Description,
+			ReferenceID: req.ReferenceId,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp.Event = convertReputationEventToProto(event)
+		resp.Reputation = convertReputationToProto(reputation)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to add reputation event", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.AddReputationEventResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+func (s *Server) GetReputation(ctx context.Context, req *userpb.GetReputationRequest) (*userpb.GetReputationResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.GetReputationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return &userpb.GetReputationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "limit and offset must not be negative",
+			},
+		}, nil
+	}
+
+	reputation, events, total, err := s.reputationService.GetReputation(ctx, req.MaxId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		s.logger.Error("failed to get reputation", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.GetReputationResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetReputationResponse{
+		Reputation:  convertReputationToProto(reputation),
+		Events:      gospadi.Map(events, convertReputationEventToProto),
+		TotalEvents: total,
+	}, nil
+}
+
+func (s *Server) GetReputationHistory(ctx context.Context, req *userpb.GetReputationHistoryRequest) (*userpb.GetReputationHistoryResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.GetReputationHistoryResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return &userpb.GetReputationHistoryResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "limit and offset must not be negative",
+			},
+		}, nil
+	}
+
+	changes, total, err := s.reputationService.GetReputationHistory(ctx, req.MaxId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		s.logger.Error("failed to get reputation history", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.GetReputationHistoryResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.GetReputationHistoryResponse{
+		Changes: gospadi.Map(changes, convertReputationGroupChangeToProto),
+		Total:   total,
+	}, nil
+}
+
+func (s *Server) RecalculateReputationGroups(ctx context.Context, req *userpb.RecalculateReputationGroupsRequest) (*userpb.RecalculateReputationGroupsResponse, error) {
+	if req.Limit < 0 {
+		return &userpb.RecalculateReputationGroupsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "limit must not be negative",
+			},
+		}, nil
+	}
+
+	report, err := s.reputationService.RecalculateReputationGroups(ctx, req.DryRun, req.AfterMaxId, int(req.Limit))
+	if err != nil {
+		s.logger.Error("failed to recalculate reputation groups", zap.Error(err), zap.String("after_max_id", req.AfterMaxId))
+		return &userpb.RecalculateReputationGroupsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.RecalculateReputationGroupsResponse{
+		Scanned:   int32(report.Scanned),
+		Moved:     int32(report.Moved),
+		Moves:     gospadi.Map(report.Moves, convertReputationGroupMoveToProto),
+		NextMaxId: report.NextMaxID,
+	}, nil
+}
+
+func convertReputationToProto(reputation *domain.Reputation) *userpb.Reputation {
+	if reputation == nil {
+		return nil
+	}
+	return &userpb.Reputation{
+		MaxId:               reputation.MaxID,
+		Score:               int32(reputation.Score),
+		ReputationGroup:     convertReputationGroupToProto(reputation.Group),
+		NextReputationGroup: convertReputationGroupToProto(reputation.NextGroup),
+	}
+}
+
+func convertReputationEventToProto(event *domain.ReputationEvent) *userpb.ReputationEvent {
+	if event == nil {
+		return nil
+	}
+	return &userpb.ReputationEvent{
+		Id:          event.ID,
+		MaxId:       event.MaxID,
+		Amount:      int32(event.Amount),
+		Reason:      convertReputationEventReasonToProto(event.Reason),
+		Description: event.Description,
+		ReferenceId: event.ReferenceID,
+		CreatedAt:   event.CreatedAt.Unix(),
+	}
+}
+
+func convertReputationEventReasonToProto(reason domain.ReputationEventReason) userpb.ReputationEventReason {
+	switch reason {
+	case domain.ReputationEventReasonTaskCompleted:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_TASK_COMPLETED
+	case domain.ReputationEventReasonEventParticipation:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_EVENT_PARTICIPATION
+	case domain.ReputationEventReasonModerationPenalty:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_MODERATION_PENALTY
+	case domain.ReputationEventReasonAdjustment:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_ADJUSTMENT
+	case domain.ReputationEventReasonMigration:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_MIGRATION
+	case domain.ReputationEventReasonDecay:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_DECAY
+	case domain.ReputationEventReasonOther:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_OTHER
+	default:
+		return userpb.ReputationEventReason_REPUTATION_EVENT_REASON_UNSPECIFIED
+	}
+}
+
+func convertReputationEventReasonToDomain(reason userpb.ReputationEventReason) domain.ReputationEventReason {
+	switch reason {
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_TASK_COMPLETED:
+		return domain.ReputationEventReasonTaskCompleted
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_EVENT_PARTICIPATION:
+		return domain.ReputationEventReasonEventParticipation
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_MODERATION_PENALTY:
+		return domain.ReputationEventReasonModerationPenalty
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_ADJUSTMENT:
+		return domain.ReputationEventReasonAdjustment
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_MIGRATION:
+		return domain.ReputationEventReasonMigration
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_DECAY:
+		return domain.ReputationEventReasonDecay
+	case userpb.ReputationEventReason_REPUTATION_EVENT_REASON_OTHER:
+		return domain.ReputationEventReasonOther
+	default:
+		return ""
+	}
+}
+
+func convertReputationGroupChangeToProto(change *domain.ReputationGroupChange) *userpb.ReputationGroupChange {
+	if change == nil {
+		return nil
+	}
+	return &userpb.ReputationGroupChange{
+		Id:                change.ID,
+		MaxId:             change.MaxID,
+		FromGroupId:       int32(change.FromGroupID),
+		FromGroupName:     change.FromGroupName,
+		ToGroupId:         int32(change.ToGroupID),
+		ToGroupName:       change.ToGroupName,
+		Promoted:          change.Promoted,
+		Score:             int32(change.Score),
+		Cause:             convertReputationChangeCauseToProto(change.Cause),
+		ReputationEventId: change.ReputationEventID,
+		ReferenceId:       change.ReferenceID,
+		CreatedAt:         change.CreatedAt.Unix(),
+	}
+}
+
+func convertReputationChangeCauseToProto(cause domain.ReputationChangeCause) userpb.ReputationChangeCause {
+	switch cause {
+	case domain.ReputationChangeCauseReputationEvent:
+		return userpb.ReputationChangeCause_REPUTATION_CHANGE_CAUSE_REPUTATION_EVENT
+	case domain.ReputationChangeCauseAdmin:
+		return userpb.ReputationChangeCause_REPUTATION_CHANGE_CAUSE_ADMIN
+	case domain.ReputationChangeCauseDecay:
+		return userpb.ReputationChangeCause_REPUTATION_CHANGE_CAUSE_DECAY
+	case domain.ReputationChangeCauseRecalculation:
+		return userpb.ReputationChangeCause_REPUTATION_CHANGE_CAUSE_RECALCULATION
+	default:
+		return userpb.ReputationChangeCause_REPUTATION_CHANGE_CAUSE_UNSPECIFIED
+	}
+}
+
+func convertReputationGroupMoveToProto(move *domain.ReputationGroupMove) *userpb.ReputationGroupMove {
+	if move == nil {
+		return nil
+	}
+	return &userpb.ReputationGroupMove{
+		FromGroupId:   int32(move.FromGroupID),
+		FromGroupName: move.FromGroupName,
+		ToGroupId:     int32(move.ToGroupID),
+		ToGroupName:   move.ToGroupName,
+		Promoted:      move.Promoted,
+		Count:         int32(move.Count),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"DobrikaDev/user-service/internal/domain"
 	"DobrikaDev/user-service/internal/generated/proto/user"
 	"context"
+	"strings"
 
 	"github.com/dr3dnought/gospadi"
 	"go.uber.org/zap"
@@ -45,6 +46,220 @@ func (s *Server) GetReputationGroupByID(ctx context.Context, req *user.GetReputa
 	}, nil
 }
 
+func (s *Server) GetReputationGroupLimits(ctx context.Context, req *user.GetReputationGroupLimitsRequest) (*user.GetReputationGroupLimitsResponse, error) {
+	if req.ReputationGroupId == 0 {
+		return &user.GetReputationGroupLimitsResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "reputation_group_id is required",
+			},
+		}, nil
+	}
+
+	limits, err := s.reputationGroupService.GetReputationGroupLimits(ctx, int(req.ReputationGroupId))
+	if err != nil {
+		s.logger.Error("failed to get reputation group limits", zap.Error(err), zap.Int("id", int(req.ReputationGroupId)))
+		return &user.GetReputationGroupLimitsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &user.GetReputationGroupLimitsResponse{
+		Limits: gospadi.Map(limits, convertReputationGroupLimitToProto),
+	}, nil
+}
+
+func (s *Server) SetReputationGroupLimits(ctx context.Context, req *user.SetReputationGroupLimitsRequest) (*user.SetReputationGroupLimitsResponse, error) {
+	if req.ReputationGroupId == 0 {
+		return &user.SetReputationGroupLimitsResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "reputation_group_id is required",
+			},
+		}, nil
+	}
+	for _, limit := range req.Limits {
+		if limit.Direction == user.LimitDirection_LIMIT_DIRECTION_UNSPECIFIED {
+			return &user.SetReputationGroupLimitsResponse{
+				Error: &user.Error{
+					Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+					Message: "limit direction is required",
+				},
+			}, nil
+		}
+		if limit.WindowSeconds <= 0 {
+			return &user.SetReputationGroupLimitsResponse{
+				Error: &user.Error{
+					Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+					Message: "limit window_seconds must be positive",
+				},
+			}, nil
+		}
+	}
+
+	limits, err := s.reputationGroupService.SetReputationGroupLimits(ctx, int(req.ReputationGroupId), gospadi.Map(req.Limits, convertReputationGroupLimitToDomain))
+	if err != nil {
+		s.logger.Error("failed to set reputation group limits", zap.Error(err), zap.Int("id", int(req.ReputationGroupId)))
+		return &user.SetReputationGroupLimitsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &user.SetReputationGroupLimitsResponse{
+		Limits: gospadi.Map(limits, convertReputationGroupLimitToProto),
+	}, nil
+}
+
+func (s *Server) CreateReputationGroup(ctx context.Context, req *user.CreateReputationGroupRequest) (*user.CreateReputationGroupResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return &user.CreateReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "name is required",
+			},
+		}, nil
+	}
+	if req.Coefficient <= 0 {
+		return &user.CreateReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "coefficient must be positive",
+			},
+		}, nil
+	}
+
+	reputationGroup, err := s.reputationGroupService.CreateReputationGroup(ctx, &domain.ReputationGroup{
+		Name:           req.Name,
+		Description:    req.Description,
+		Coefficient:    req.Coefficient,
+		ReputationNeed: int(req.ReputationNeed),
+	})
+	if err != nil {
+		s.logger.Error("failed to create reputation group", zap.Error(err), zap.String("name", req.Name))
+		return &user.CreateReputationGroupResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &user.CreateReputationGroupResponse{
+		ReputationGroup: convertReputationGroupToProto(reputationGroup),
+	}, nil
+}
+
+func (s *Server) UpdateReputationGroup(ctx context.Context, req *user.UpdateReputationGroupRequest) (*user.UpdateReputationGroupResponse, error) {
+	if req.Id == 0 {
+		return &user.UpdateReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "id is required",
+			},
+		}, nil
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return &user.UpdateReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "name is required",
+			},
+		}, nil
+	}
+	if req.Coefficient <= 0 {
+		return &user.UpdateReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "coefficient must be positive",
+			},
+		}, nil
+	}
+
+	reputationGroup, err := s.reputationGroupService.UpdateReputationGroup(ctx, &domain.ReputationGroup{
+		ID:             int(req.Id),
+		Name:           req.Name,
+		Description:    req.Description,
+		Coefficient:    req.Coefficient,
+		ReputationNeed: int(req.ReputationNeed),
+	})
+	if err != nil {
+		s.logger.Error("failed to update reputation group", zap.Error(err), zap.Int("id", int(req.Id)))
+		return &user.UpdateReputationGroupResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &user.UpdateReputationGroupResponse{
+		ReputationGroup: convertReputationGroupToProto(reputationGroup),
+	}, nil
+}
+
+func (s *Server) DeleteReputationGroup(ctx context.Context, req *user.DeleteReputationGroupRequest) (*user.DeleteReputationGroupResponse, error) {
+	if req.Id == 0 {
+		return &user.DeleteReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "id is required",
+			},
+		}, nil
+	}
+	if req.MoveMembersToId == req.Id {
+		return &user.DeleteReputationGroupResponse{
+			Error: &user.Error{
+				Code:    user.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "move_members_to_id must differ from id",
+			},
+		}, nil
+	}
+
+	moved, err := s.reputationGroupService.DeleteReputationGroup(ctx, int(req.Id), int(req.MoveMembersToId))
+	if err != nil {
+		s.logger.Error("failed to delete reputation group", zap.Error(err), zap.Int("id", int(req.Id)))
+		return &user.DeleteReputationGroupResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &user.DeleteReputationGroupResponse{
+		MovedMembers: int32(moved),
+	}, nil
+}
+
+func convertReputationGroupLimitToProto(limit *domain.ReputationGroupLimit) *user.ReputationGroupLimit {
+	return &user.ReputationGroupLimit{
+		Direction:     convertLimitDirectionToProto(limit.Direction),
+		WindowSeconds: int32(limit.WindowSeconds),
+		MaxAmount:     int32(limit.MaxAmount),
+	}
+}
+
+func convertReputationGroupLimitToDomain(limit *user.ReputationGroupLimit) *domain.ReputationGroupLimit {
+	return &domain.ReputationGroupLimit{
+		Direction:     convertLimitDirectionToDomain(limit.Direction),
+		WindowSeconds: int(limit.WindowSeconds),
+		MaxAmount:     int(limit.MaxAmount),
+	}
+}
+
+func convertLimitDirectionToProto(direction domain.LimitDirection) user.LimitDirection {
+	switch direction {
+	case domain.LimitDirectionEarn:
+		return user.LimitDirection_LIMIT_DIRECTION_EARN
+	case domain.LimitDirectionSpend:
+		return user.LimitDirection_LIMIT_DIRECTION_SPEND
+	default:
+		return user.LimitDirection_LIMIT_DIRECTION_UNSPECIFIED
+	}
+}
+
+func convertLimitDirectionToDomain(direction user.LimitDirection) domain.LimitDirection {
+	switch direction {
+	case user.LimitDirection_LIMIT_DIRECTION_EARN:
+		return domain.LimitDirectionEarn
+	case user.LimitDirection_LIMIT_DIRECTION_SPEND:
+		return domain.LimitDirectionSpend
+	default:
+		return ""
+	}
+}
+
 func convertReputationGroupToProto(reputationGroup *domain.ReputationGroup) *user.ReputationGroup {
 	if reputationGroup == nil {
 		return nil
@@ -0,0 +1,243 @@
+package delivery
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	userpb "DobrikaDev/user-service/internal/generated/proto/user"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dr3dnought/gospadi"
+	"go.uber.org/zap"
+)
+
+func (s *Server) ScheduleOperation(ctx context.Context, req *userpb.ScheduleOperationRequest) (*userpb.ScheduleOperationResponse, error) {
+	if req.MaxId == "" {
+		return &userpb.ScheduleOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "max_id is required",
+			},
+		}, nil
+	}
+	if req.Amount <= 0 {
+		return &userpb.ScheduleOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "amount is required",
+			},
+		}, nil
+	}
+	if req.Type != userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT && req.Type != userpb.BalanceOperationType_BALANCE_OPERATION_TYPE_WITHDRAW {
+		return &userpb.ScheduleOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "type must be deposit or withdraw",
+			},
+		}, nil
+	}
+	cronExpression := strings.TrimSpace(req.CronExpression)
+	if (req.RunAt > 0) == (cronExpression != "") {
+		return &userpb.ScheduleOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "exactly one of run_at and cron_expression is required",
+			},
+		}, nil
+	}
+
+	resp := &userpb.ScheduleOperationResponse{}
+	err := s.withIdempotency(ctx, "ScheduleOperation", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		operation := &domain.ScheduledOperation{
+			MaxID:          req.MaxId,
+			WalletType:     convertWalletTypeToDomain(req.WalletType),
+			Amount:         int(req.Amount),
+			Type:           convertBatchOperationTypeToDomain(req.Type),
+			Description:    req.Description,
+			ReasonCode:     convertBalanceOperationReasonToDomain(req.ReasonCode),
+			Metadata:       convertBalanceOperationMetadataToDomain(req.Metadata),
+			CronExpression: cronExpression,
+		}
+		if req.RunAt > 0 {
+			runAt := time.Unix(req.RunAt, 0).UTC()
+			operation.NextRunAt = &runAt
+		}
+
+		operation, err := s.balanceService.ScheduleOperation(ctx, operation)
+		if err != nil {
+			return err
+		}
+
+		resp.ScheduledOperation = convertScheduledOperationToProto(operation)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to schedule operation", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.ScheduleOperationResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ListScheduledOperations(ctx context.Context, req *userpb.ListScheduledOperationsRequest) (*userpb.ListScheduledOperationsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return &userpb.ListScheduledOperationsResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "limit and offset must not be negative",
+			},
+		}, nil
+	}
+
+	statuses := make([]domain.ScheduledOperationStatus, 0, len(req.Statuses))
+	for _, status := range req.Statuses {
+		if status == userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_UNSPECIFIED {
+			continue
+		}
+		statuses = append(statuses, convertScheduledOperationStatusToDomain(status))
+	}
+
+	operations, total, err := s.balanceService.ListScheduledOperations(ctx, req.MaxId, statuses, int(req.Limit), int(req.Offset))
+	if err != nil {
+		s.logger.Error("failed to list scheduled operations", zap.Error(err), zap.String("max_id", req.MaxId))
+		return &userpb.ListScheduledOperationsResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.ListScheduledOperationsResponse{
+		ScheduledOperations: gospadi.Map(operations, convertScheduledOperationToProto),
+		Total:               total,
+	}, nil
+}
+
+func (s *Server) PauseScheduledOperation(ctx context.Context, req *userpb.PauseScheduledOperationRequest) (*userpb.PauseScheduledOperationResponse, error) {
+	if req.Id == "" {
+		return &userpb.PauseScheduledOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "id is required",
+			},
+		}, nil
+	}
+
+	operation, err := s.balanceService.PauseScheduledOperation(ctx, req.Id)
+	if err != nil {
+		s.logger.Error("failed to pause scheduled operation", zap.Error(err), zap.String("id", req.Id))
+		return &userpb.PauseScheduledOperationResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.PauseScheduledOperationResponse{
+		ScheduledOperation: convertScheduledOperationToProto(operation),
+	}, nil
+}
+
+func (s *Server) ResumeScheduledOperation(ctx context.Context, req *userpb.ResumeScheduledOperationRequest) (*userpb.ResumeScheduledOperationResponse, error) {
+	if req.Id == "" {
+		return &userpb.ResumeScheduledOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "id is required",
+			},
+		}, nil
+	}
+
+	operation, err := s.balanceService.ResumeScheduledOperation(ctx, req.Id)
+	if err != nil {
+		s.logger.Error("failed to resume scheduled operation", zap.Error(err), zap.String("id", req.Id))
+		return &userpb.ResumeScheduledOperationResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.ResumeScheduledOperationResponse{
+		ScheduledOperation: convertScheduledOperationToProto(operation),
+	}, nil
+}
+
+func (s *Server) CancelScheduledOperation(ctx context.Context, req *userpb.CancelScheduledOperationRequest) (*userpb.CancelScheduledOperationResponse, error) {
+	if req.Id == "" {
+		return &userpb.CancelScheduledOperationResponse{
+			Error: &userpb.Error{
+				Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+				Message: "id is required",
+			},
+		}, nil
+	}
+
+	operation, err := s.balanceService.CancelScheduledOperation(ctx, req.Id)
+	if err != nil {
+		s.logger.Error("failed to cancel scheduled operation", zap.Error(err), zap.String("id", req.Id))
+		return &userpb.CancelScheduledOperationResponse{
+			Error: convertErrorToProto(err),
+		}, nil
+	}
+
+	return &userpb.CancelScheduledOperationResponse{
+		ScheduledOperation: convertScheduledOperationToProto(operation),
+	}, nil
+}
+
+func convertScheduledOperationToProto(operation *domain.ScheduledOperation) *userpb.ScheduledOperation {
+	if operation == nil {
+		return nil
+	}
+
+	scheduled := &userpb.ScheduledOperation{
+		Id:              operation.ID,
+		MaxId:           operation.MaxID,
+		WalletType:      convertWalletTypeToProto(operation.WalletType),
+		Amount:          int32(operation.Amount),
+		Type:            convertBalanceOperationTypeToProto(operation.Type),
+		Description:     operation.Description,
+		ReasonCode:      convertBalanceOperationReasonToProto(operation.ReasonCode),
+		Metadata:        convertBalanceOperationMetadataToProto(operation.Metadata),
+		CronExpression:  operation.CronExpression,
+		Status:          convertScheduledOperationStatusToProto(operation.Status),
+		LastOperationId: operation.LastOperationID,
+		LastError:       operation.LastError,
+		CreatedAt:       operation.CreatedAt.Unix(),
+	}
+	if operation.NextRunAt != nil {
+		scheduled.NextRunAt = operation.NextRunAt.Unix()
+	}
+	if operation.LastRunAt != nil {
+		scheduled.LastRunAt = operation.LastRunAt.Unix()
+	}
+
+	return scheduled
+}
+
+func convertScheduledOperationStatusToProto(status domain.ScheduledOperationStatus) userpb.ScheduledOperationStatus {
+	switch status {
+	case domain.ScheduledOperationStatusActive:
+		return userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_ACTIVE
+	case domain.ScheduledOperationStatusPaused:
+		return userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_PAUSED
+	case domain.ScheduledOperationStatusCancelled:
+		return userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_CANCELLED
+	case domain.ScheduledOperationStatusCompleted:
+		return userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_COMPLETED
+	default:
+		return userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_UNSPECIFIED
+	}
+}
+
+func convertScheduledOperationStatusToDomain(status userpb.ScheduledOperationStatus) domain.ScheduledOperationStatus {
+	switch status {
+	case userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_ACTIVE:
+		return domain.ScheduledOperationStatusActive
+	case userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_PAUSED:
+		return domain.ScheduledOperationStatusPaused
+	case userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_CANCELLED:
+		return domain.ScheduledOperationStatusCancelled
+	case userpb.ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_COMPLETED:
+		return domain.ScheduledOperationStatusCompleted
+	default:
+		return ""
+	}
+}
@@ -3,6 +3,8 @@ package delivery
 import (
 	userpb "DobrikaDev/user-service/internal/generated/proto/user"
 	"DobrikaDev/user-service/internal/service/balance"
+	"DobrikaDev/user-service/internal/service/idempotency"
+	"DobrikaDev/user-service/internal/service/reputation"
 	reputationgroup "DobrikaDev/user-service/internal/service/reputation_group"
 	"DobrikaDev/user-service/internal/service/user"
 	"DobrikaDev/user-service/utils/config"
@@ -15,15 +17,17 @@ import (
 type Server struct {
 	userService            *user.UserService
 	reputationGroupService *reputationgroup.ReputationGroupService
+	reputationService      *reputation.ReputationService
 	balanceService         *balance.BalanceService
+	idempotencyService     *idempotency.IdempotencyService
 	userpb.UnimplementedUserServiceServer
 
 	cfg    *config.Config
 	logger *zap.Logger
 }
 
-func NewServer(ctx context.Context, userService *user.UserService, reputationGroupService *reputationgroup.ReputationGroupService, balanceService *balance.BalanceService, cfg *config.Config, logger *zap.Logger) *Server {
-	server := &Server{userService: userService, reputationGroupService: reputationGroupService, balanceService: balanceService, cfg: cfg, logger: logger}
+func NewServer(ctx context.Context, userService *user.UserService, reputationGroupService *reputationgroup.ReputationGroupService, reputationService *reputation.ReputationService, balanceService *balance.BalanceService, idempotencyService *idempotency.IdempotencyService, cfg *config.Config, logger *zap.Logger) *Server {
+	server := &Server{userService: userService, reputationGroupService: reputationGroupService, reputationService: reputationService, balanceService: balanceService, idempotencyService: idempotencyService, cfg: cfg, logger: logger}
 	return server
 }
 
@@ -2,11 +2,14 @@ package delivery
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"DobrikaDev/user-service/internal/domain"
 	userpb "DobrikaDev/user-service/internal/generated/proto/user"
 	balance "DobrikaDev/user-service/internal/service/balance"
+	"DobrikaDev/user-service/internal/service/idempotency"
+	"DobrikaDev/user-service/internal/service/reputation"
 	reputationgroup "DobrikaDev/user-service/internal/service/reputation_group"
 	"DobrikaDev/user-service/internal/service/user"
 
@@ -15,7 +18,18 @@ import (
 )
 
 func (s *Server) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.CreateUserResponse, error) {
-	user, err := s.userService.CreateUser(ctx, convertUserToDomain(req.User))
+	resp := &userpb.CreateUserResponse{}
+	err := s.withIdempotency(ctx, "CreateUser", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		user, err := s.userService.CreateUser(ctx, convertUserToDomain(req.User))
+		if err != nil {
+			return err
+		}
+
+		s.logger.Debug("user created", zap.Any("user", user))
+
+		resp.User = convertUserToProto(user)
+		return nil
+	})
 	if err != nil {
 		s.logger.Error("failed to create user", zap.Error(err), zap.Any("user", req.User))
 		return &userpb.CreateUserResponse{
@@ -23,11 +37,7 @@ func (s *Server) CreateUser(ctx context.Context, req *userpb.CreateUserRequest)
 		}, nil
 	}
 
-	s.logger.Debug("user created", zap.Any("user", user))
-
-	return &userpb.CreateUserResponse{
-		User: convertUserToProto(user),
-	}, nil
+	return resp, nil
 }
 
 func (s *Server) GetUsers(ctx context.Context, req *userpb.GetUsersRequest) (*userpb.GetUsersResponse, error) {
@@ -87,17 +97,25 @@ func (s *Server) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest)
 		}, nil
 	}
 
-	existing, err := s.userService.GetUserByMaxID(ctx, req.GetUser().GetMaxId())
-	if err != nil {
-		s.logger.Error("failed to fetch user before update", zap.Error(err), zap.String("max_id", req.GetUser().GetMaxId()))
-		return &userpb.UpdateUserResponse{
-			Error: convertErrorToProto(err),
-		}, nil
-	}
+	resp := &userpb.UpdateUserResponse{}
+	err := s.withIdempotency(ctx, "UpdateUser", req.IdempotencyKey, req, resp, func(ctx context.Context) error {
+		existing, err := s.userService.GetUserByMaxID(ctx, req.GetUser().GetMaxId())
+		if err != nil {
+			s.logger.Error("failed to fetch user before update", zap.Error(err), zap.String("max_id", req.GetUser().GetMaxId()))
+			return err
+		}
+
+		merged := mergeUser(existing, req.GetUser())
+
+		if err := s.userService.UpdateUser(ctx, merged); err != nil {
+			return err
+		}
 
-	merged := mergeUser(existing, req.GetUser())
+		s.logger.Debug("user updated", zap.Any("user", merged))
 
-	err = s.userService.UpdateUser(ctx, merged)
+		resp.User = convertUserToProto(merged)
+		return nil
+	})
 	if err != nil {
 		s.logger.Error("failed to update user", zap.Error(err), zap.Any("user", req.User))
 		return &userpb.UpdateUserResponse{
@@ -105,11 +123,7 @@ func (s *Server) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest)
 		}, nil
 	}
 
-	s.logger.Debug("user updated", zap.Any("user", merged))
-
-	return &userpb.UpdateUserResponse{
-		User: convertUserToProto(merged),
-	}, nil
+	return resp, nil
 }
 
 func (s *Server) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*userpb.DeleteUserResponse, error) {
@@ -128,6 +142,14 @@ func (s *Server) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest)
 }
 
 func convertErrorToProto(err error) *userpb.Error {
+	var limitErr *domain.LimitExceededError
+	if errors.As(err, &limitErr) {
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_LIMIT_EXCEEDED,
+			Message: limitErr.Error(),
+		}
+	}
+
 	switch err {
 	case user.ErrUserNotFound:
 		return &userpb.Error{
@@ -169,6 +191,31 @@ func convertErrorToProto(err error) *userpb.Error {
 			Code:    userpb.ErrorCode_ERROR_CODE_INTERNAL,
 			Message: err.Error(),
 		}
+	case reputationgroup.ErrReputationGroupInUse:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_CONFLICT,
+			Message: err.Error(),
+		}
+	case reputation.ErrReputationNotFound:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_NOT_FOUND,
+			Message: err.Error(),
+		}
+	case reputation.ErrReputationInvalid:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+			Message: err.Error(),
+		}
+	case reputation.ErrReputationEventAlreadyExists:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_ALREADY_EXISTS,
+			Message: err.Error(),
+		}
+	case reputation.ErrReputationInternal:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_INTERNAL,
+			Message: err.Error(),
+		}
 	case balance.ErrBalanceNotFound:
 		return &userpb.Error{
 			Code:    userpb.ErrorCode_ERROR_CODE_NOT_FOUND,
@@ -184,6 +231,61 @@ func convertErrorToProto(err error) *userpb.Error {
 			Code:    userpb.ErrorCode_ERROR_CODE_NOT_ENOUGH,
 			Message: err.Error(),
 		}
+	case balance.ErrBalanceInvalid:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+			Message: err.Error(),
+		}
+	case balance.ErrBalanceForbidden:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_FORBIDDEN,
+			Message: err.Error(),
+		}
+	case balance.ErrBalanceOperationNotFound:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_NOT_FOUND,
+			Message: err.Error(),
+		}
+	case balance.ErrBalanceOperationAlreadyReversed:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_CONFLICT,
+			Message: err.Error(),
+		}
+	case balance.ErrBalanceOperationNotReversible:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_VALIDATION,
+			Message: err.Error(),
+		}
+	case balance.ErrHoldNotFound:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_NOT_FOUND,
+			Message: err.Error(),
+		}
+	case balance.ErrHoldNotActive:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_CONFLICT,
+			Message: err.Error(),
+		}
+	case balance.ErrScheduledOperationNotFound:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_NOT_FOUND,
+			Message: err.Error(),
+		}
+	case balance.ErrScheduledOperationStatusConflict:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_CONFLICT,
+			Message: err.Error(),
+		}
+	case idempotency.ErrIdempotencyKeyReused:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_IDEMPOTENCY_KEY_REUSED,
+			Message: err.Error(),
+		}
+	case idempotency.ErrIdempotencyKeyInternal:
+		return &userpb.Error{
+			Code:    userpb.ErrorCode_ERROR_CODE_INTERNAL,
+			Message: err.Error(),
+		}
 	default:
 		return &userpb.Error{
 			Code:    userpb.ErrorCode_ERROR_CODE_UNSPECIFIED,
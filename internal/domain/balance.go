@@ -1,18 +1,222 @@
 package domain
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
 
 type Balance struct {
-	ID      string `json:"id" db:"id"`
-	UserID  string `json:"user_id" db:"user_id"`
-	Balance int    `json:"balance" db:"balance"`
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	WalletType WalletType `json:"wallet_type" db:"wallet_type"`
+	Balance    int        `json:"balance" db:"balance"`
+	Held       int        `json:"held" db:"held"`
+
+	ExpiringSoon []*ExpiringPoints `json:"expiring_soon" db:"-"`
+}
+
+func (b *Balance) Available() int {
+	return b.Balance - b.Held
 }
 
 type BalanceOperation struct {
 	ID          string               `json:"id" db:"id"`
 	BalanceID   string               `json:"balance_id" db:"balance_id"`
+	WalletType  WalletType           `json:"wallet_type" db:"wallet_type"`
 	Amount      int                  `json:"amount" db:"amount"`
 	Type        BalanceOperationType `json:"type" db:"type"`
 	Description string               `json:"description" db:"description"`
+	TransferID  string               `json:"transfer_id" db:"transfer_id"`
 	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+
+	ReasonCode BalanceOperationReason   `json:"reason_code" db:"reason_code"`
+	Metadata   BalanceOperationMetadata `json:"metadata" db:"metadata"`
+
+	ReversesOperationID   string `json:"reverses_operation_id" db:"reverses_operation_id"`
+	ReversedByOperationID string `json:"reversed_by_operation_id" db:"reversed_by_operation_id"`
+
+	// ReputationAmount is what the operation adds to the owner's earned points
+	// on the leaderboard. The reputation score is kept by reputation events.
+	ReputationAmount int `json:"reputation_amount" db:"reputation_amount"`
+
+	// Set on coefficient-mode deposits only.
+	BaseAmount  int     `json:"base_amount" db:"base_amount"`
+	Coefficient float64 `json:"coefficient" db:"coefficient"`
+
+	// Set on manual adjustments only.
+	ActorMaxID    string `json:"actor_max_id" db:"actor_max_id"`
+	Justification string `json:"justification" db:"justification"`
+
+	// Adjustments only record a reputation event when this is set.
+	CountsTowardsReputation bool `json:"counts_towards_reputation" db:"-"`
+}
+
+// Coefficients have two decimal places; the product is rounded half up.
+func ApplyCoefficient(base int, coefficient float64) int {
+	hundredths := int64(math.Round(coefficient * 100))
+	return int((int64(base)*hundredths + 50) / 100)
+}
+
+type BalanceOperationMetadata struct {
+	SourceService string            `json:"source_service,omitempty"`
+	TaskID        string            `json:"task_id,omitempty"`
+	EventID       string            `json:"event_id,omitempty"`
+	Extra         map[string]string `json:"extra,omitempty"`
+}
+
+func (m BalanceOperationMetadata) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+func (m *BalanceOperationMetadata) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = BalanceOperationMetadata{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	default:
+		return errors.New("unsupported balance operation metadata type")
+	}
+}
+
+type ExpiringPoints struct {
+	Amount    int       `json:"amount" db:"amount"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
+
+type BalanceOperationReasonTotals struct {
+	ReasonCode BalanceOperationReason `json:"reason_code" db:"reason_code"`
+	Count      int64                  `json:"count" db:"count"`
+	Credited   int64                  `json:"credited" db:"credited"`
+	Debited    int64                  `json:"debited" db:"debited"`
+}
+
+type BalanceOperationCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+type Transfer struct {
+	ID            string            `json:"id"`
+	Amount        int               `json:"amount"`
+	Description   string            `json:"description"`
+	FromBalance   *Balance          `json:"from_balance"`
+	ToBalance     *Balance          `json:"to_balance"`
+	FromOperation *BalanceOperation `json:"from_operation"`
+	ToOperation   *BalanceOperation `json:"to_operation"`
+}
+
+type Hold struct {
+	ID             string     `json:"id" db:"id"`
+	BalanceID      string     `json:"balance_id" db:"balance_id"`
+	Amount         int        `json:"amount" db:"amount"`
+	CapturedAmount int        `json:"captured_amount" db:"captured_amount"`
+	Status         HoldStatus `json:"status" db:"status"`
+	Description    string     `json:"description" db:"description"`
+	OperationID    string     `json:"operation_id" db:"operation_id"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type BalanceMismatch struct {
+	BalanceID             string `json:"balance_id" db:"balance_id"`
+	UserID                string `json:"user_id" db:"user_id"`
+	Actual                int    `json:"actual" db:"actual"`
+	Expected              int    `json:"expected" db:"expected"`
+	AdjustmentOperationID string `json:"adjustment_operation_id" db:"-"`
+}
+
+func (m *BalanceMismatch) Difference() int {
+	return m.Actual - m.Expected
+}
+
+// ReconciliationReport sums up a reconciliation run. NegativeBalances lists
+// the balances still below zero after a fixing run; the non-negative
+// constraint is validated only once there are none.
+type ReconciliationReport struct {
+	Scanned          int                `json:"scanned"`
+	Adjusted         int                `json:"adjusted"`
+	Mismatches       []*BalanceMismatch `json:"mismatches"`
+	NegativeBalances []string           `json:"negative_balances"`
+}
+
+type BalanceHistoryGranularity string
+
+const (
+	BalanceHistoryGranularityDay   BalanceHistoryGranularity = "day"
+	BalanceHistoryGranularityWeek  BalanceHistoryGranularity = "week"
+	BalanceHistoryGranularityMonth BalanceHistoryGranularity = "month"
+)
+
+// PeriodEnd is exclusive and cut short at the end of the queried range.
+type BalanceHistoryPoint struct {
+	PeriodStart time.Time `json:"period_start" db:"period_start"`
+	PeriodEnd   time.Time `json:"period_end" db:"period_end"`
+	Balance     int       `json:"balance" db:"balance"`
+}
+
+// HoldID is set instead of OperationID when a hold was placed or released.
+type BalanceChange struct {
+	MaxID       string     `json:"max_id"`
+	BalanceID   string     `json:"balance_id"`
+	WalletType  WalletType `json:"wallet_type"`
+	Balance     int        `json:"balance"`
+	Held        int        `json:"held"`
+	OperationID string     `json:"operation_id,omitempty"`
+	HoldID      string     `json:"hold_id,omitempty"`
+
+	Operation *BalanceOperation `json:"-"`
+}
+
+func (c *BalanceChange) Available() int {
+	return c.Balance - c.Held
+}
+
+type BalanceOperationBatchItem struct {
+	MaxID     string
+	Operation *BalanceOperation
+}
+
+type BalanceOperationBatchResult struct {
+	Operation *BalanceOperation
+	Err       error
+}
+
+type BalanceStatsGroupBy string
+
+const (
+	BalanceStatsGroupByReason      BalanceStatsGroupBy = "reason"
+	BalanceStatsGroupByDescription BalanceStatsGroupBy = "description"
+)
+
+// Reversed operations and their reversals cancel out and are left out.
+type BalanceStats struct {
+	Earned         int64 `json:"earned" db:"earned"`
+	Spent          int64 `json:"spent" db:"spent"`
+	LifetimeEarned int64 `json:"lifetime_earned" db:"lifetime_earned"`
+	LifetimeSpent  int64 `json:"lifetime_spent" db:"lifetime_spent"`
+
+	Months []*BalanceStatsMonth `json:"months" db:"-"`
+	Groups []*BalanceStatsGroup `json:"groups" db:"-"`
+}
+
+// Months are aligned to UTC; the first and last are cut to the queried range.
+type BalanceStatsMonth struct {
+	MonthStart time.Time `json:"month_start" db:"month_start"`
+	Earned     int64     `json:"earned" db:"earned"`
+	Spent      int64     `json:"spent" db:"spent"`
+}
+
+type BalanceStatsGroup struct {
+	Key    string `json:"key" db:"key"`
+	Count  int64  `json:"count" db:"count"`
+	Earned int64  `json:"earned" db:"earned"`
+	Spent  int64  `json:"spent" db:"spent"`
 }
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestApplyCoefficient(t *testing.T) {
+	tests := []struct {
+		base        int
+		coefficient float64
+		want        int
+	}{
+		{base: 10, coefficient: 1, want: 10},
+		{base: 7, coefficient: 0.8, want: 6},
+		{base: 5, coefficient: 1.3, want: 7},
+		{base: 10, coefficient: 1.15, want: 12},
+		{base: 3, coefficient: 1.15, want: 3},
+		{base: 1, coefficient: 0.49, want: 0},
+		{base: 1, coefficient: 0.5, want: 1},
+		{base: 1000000, coefficient: 1.2, want: 1200000},
+	}
+	for _, tt := range tests {
+		if got := ApplyCoefficient(tt.base, tt.coefficient); got != tt.want {
+			t.Errorf("ApplyCoefficient(%d, %v) = %d, want %d", tt.base, tt.coefficient, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type IdempotencyKey struct {
+	Scope       string    `json:"scope" db:"scope"`
+	Key         string    `json:"key" db:"key"`
+	RequestHash string    `json:"request_hash" db:"request_hash"`
+	Response    []byte    `json:"response" db:"response"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`
+}
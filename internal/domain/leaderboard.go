@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+type LeaderboardPeriod string
+
+const (
+	LeaderboardPeriodAllTime LeaderboardPeriod = "all_time"
+	LeaderboardPeriodMonth   LeaderboardPeriod = "month"
+	LeaderboardPeriodWeek    LeaderboardPeriod = "week"
+)
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardMetricBalance LeaderboardMetric = "balance"
+	LeaderboardMetricEarned  LeaderboardMetric = "earned"
+)
+
+type LeaderboardQuery struct {
+	Period            LeaderboardPeriod
+	Metric            LeaderboardMetric
+	Geolocation       string
+	ReputationGroupID int
+	CallerMaxID       string
+	Limit             int
+	Offset            int
+}
+
+type LeaderboardEntry struct {
+	Rank  int    `json:"rank" db:"rank"`
+	MaxID string `json:"max_id" db:"max_id"`
+	Name  string `json:"name" db:"name"`
+	Score int    `json:"score" db:"score"`
+}
+
+type Leaderboard struct {
+	Entries []*LeaderboardEntry `json:"entries"`
+	Total   int                 `json:"total"`
+	// Nil when the caller is not ranked under the query's filters.
+	Caller      *LeaderboardEntry `json:"caller"`
+	RefreshedAt time.Time         `json:"refreshed_at"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+type LedgerAccountKind string
+
+const (
+	LedgerAccountKindWallet LedgerAccountKind = "wallet"
+	LedgerAccountKindSystem LedgerAccountKind = "system"
+)
+
+const (
+	LedgerAccountRewardsIssued = "system:rewards_issued"
+	LedgerAccountRedemptions   = "system:redemptions"
+	LedgerAccountAdjustments   = "system:adjustments"
+	LedgerAccountTransfers     = "system:transfers"
+	LedgerAccountExpired       = "system:expired"
+)
+
+func WalletLedgerAccountID(balanceID string) string {
+	return "wallet:" + balanceID
+}
+
+type LedgerEntryDirection string
+
+const (
+	LedgerEntryDirectionDebit  LedgerEntryDirection = "debit"
+	LedgerEntryDirectionCredit LedgerEntryDirection = "credit"
+)
+
+type LedgerEntry struct {
+	ID          string               `json:"id" db:"id"`
+	OperationID string               `json:"operation_id" db:"operation_id"`
+	AccountID   string               `json:"account_id" db:"account_id"`
+	Direction   LedgerEntryDirection `json:"direction" db:"direction"`
+	Amount      int                  `json:"amount" db:"amount"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+}
+
+type TrialBalanceLine struct {
+	Account string            `json:"account" db:"account"`
+	Kind    LedgerAccountKind `json:"kind" db:"kind"`
+	Debit   int64             `json:"debit" db:"debit"`
+	Credit  int64             `json:"credit" db:"credit"`
+}
+
+// LedgerBalance of a wallet account is its credits minus its debits.
+type LedgerWalletMismatch struct {
+	Account       string `json:"account" db:"account"`
+	BalanceID     string `json:"balance_id" db:"balance_id"`
+	LedgerBalance int64  `json:"ledger_balance" db:"ledger_balance"`
+	Balance       int64  `json:"balance" db:"balance"`
+}
+
+type TrialBalance struct {
+	Lines       []*TrialBalanceLine `json:"lines"`
+	TotalDebit  int64               `json:"total_debit"`
+	TotalCredit int64               `json:"total_credit"`
+
+	WalletMismatches []*LedgerWalletMismatch `json:"wallet_mismatches"`
+}
+
+func (t *TrialBalance) Balanced() bool {
+	return t.TotalDebit == t.TotalCredit && len(t.WalletMismatches) == 0
+}
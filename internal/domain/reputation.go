@@ -1,5 +1,13 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
+// The default group keeps a zero threshold and cannot be deleted.
+const DefaultReputationGroupID = 1
+
 type ReputationGroup struct {
 	ID             int     `json:"id" db:"id"`
 	Name           string  `json:"name" db:"name"`
@@ -7,3 +15,124 @@ type ReputationGroup struct {
 	Coefficient    float64 `json:"coefficient" db:"coefficient"`
 	ReputationNeed int     `json:"reputation_need" db:"reputation_need"`
 }
+
+type LimitDirection string
+
+const (
+	LimitDirectionEarn  LimitDirection = "earn"
+	LimitDirectionSpend LimitDirection = "spend"
+)
+
+func (d LimitDirection) String() string {
+	return string(d)
+}
+
+type ReputationGroupLimit struct {
+	ReputationGroupID int            `json:"reputation_group_id" db:"reputation_group_id"`
+	Direction         LimitDirection `json:"direction" db:"direction"`
+	WindowSeconds     int            `json:"window_seconds" db:"window_seconds"`
+	MaxAmount         int            `json:"max_amount" db:"max_amount"`
+}
+
+func (l *ReputationGroupLimit) Window() time.Duration {
+	return time.Duration(l.WindowSeconds) * time.Second
+}
+
+// ResetsAt is zero when the amount is larger than the limit itself.
+type LimitExceededError struct {
+	Limit    ReputationGroupLimit
+	Used     int
+	ResetsAt time.Time
+}
+
+func (e *LimitExceededError) Error() string {
+	message := fmt.Sprintf("%s limit of %d points per %s exceeded: %d already used",
+		e.Limit.Direction, e.Limit.MaxAmount, e.Limit.Window(), e.Used)
+	if e.ResetsAt.IsZero() {
+		return message + ", amount is larger than the limit"
+	}
+	return message + ", window resets at " + e.ResetsAt.UTC().Format(time.RFC3339)
+}
+
+type ReputationEventReason string
+
+const (
+	ReputationEventReasonTaskCompleted      ReputationEventReason = "task_completed"
+	ReputationEventReasonEventParticipation ReputationEventReason = "event_participation"
+	ReputationEventReasonModerationPenalty  ReputationEventReason = "moderation_penalty"
+	ReputationEventReasonAdjustment         ReputationEventReason = "adjustment"
+	ReputationEventReasonMigration          ReputationEventReason = "migration"
+	ReputationEventReasonDecay              ReputationEventReason = "decay"
+	ReputationEventReasonOther              ReputationEventReason = "other"
+)
+
+func (r ReputationEventReason) String() string {
+	return string(r)
+}
+
+// The score is the sum of every event's Amount; penalties are negative.
+type ReputationEvent struct {
+	ID          string                `json:"id" db:"id"`
+	MaxID       string                `json:"max_id" db:"user_id"`
+	Amount      int                   `json:"amount" db:"amount"`
+	Reason      ReputationEventReason `json:"reason" db:"reason"`
+	Description string                `json:"description" db:"description"`
+	// A user gets at most one event per reason and reference.
+	ReferenceID string    `json:"reference_id" db:"reference_id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// NextGroup is nil in the top group.
+type Reputation struct {
+	MaxID     string           `json:"max_id"`
+	Score     int              `json:"score"`
+	Group     *ReputationGroup `json:"group"`
+	NextGroup *ReputationGroup `json:"next_group"`
+}
+
+type ReputationChangeCause string
+
+const (
+	ReputationChangeCauseReputationEvent ReputationChangeCause = "reputation_event"
+	ReputationChangeCauseAdmin           ReputationChangeCause = "admin"
+	ReputationChangeCauseDecay           ReputationChangeCause = "decay"
+	ReputationChangeCauseRecalculation   ReputationChangeCause = "recalculation"
+)
+
+func (c ReputationChangeCause) String() string {
+	return string(c)
+}
+
+// Group names are the ones at the time of the change.
+type ReputationGroupChange struct {
+	ID                string                `json:"id" db:"id"`
+	MaxID             string                `json:"max_id" db:"user_id"`
+	FromGroupID       int                   `json:"from_group_id" db:"from_group_id"`
+	FromGroupName     string                `json:"from_group_name" db:"from_group_name"`
+	ToGroupID         int                   `json:"to_group_id" db:"to_group_id"`
+	ToGroupName       string                `json:"to_group_name" db:"to_group_name"`
+	Promoted          bool                  `json:"promoted" db:"promoted"`
+	Score             int                   `json:"score" db:"score"`
+	Cause             ReputationChangeCause `json:"cause" db:"cause"`
+	ReputationEventID string                `json:"reputation_event_id" db:"reputation_event_id"`
+	ReferenceID       string                `json:"reference_id" db:"reference_id"`
+	CreatedAt         time.Time             `json:"created_at" db:"created_at"`
+}
+
+type ReputationGroupMove struct {
+	FromGroupID   int    `json:"from_group_id"`
+	FromGroupName string `json:"from_group_name"`
+	ToGroupID     int    `json:"to_group_id"`
+	ToGroupName   string `json:"to_group_name"`
+	Promoted      bool   `json:"promoted"`
+	Count         int    `json:"count"`
+}
+
+// NextMaxID is empty once every user was checked.
+type ReputationRecalculationReport struct {
+	DryRun    bool                   `json:"dry_run"`
+	Scanned   int                    `json:"scanned"`
+	Moved     int                    `json:"moved"`
+	Moves     []*ReputationGroupMove `json:"moves"`
+	NextMaxID string                 `json:"next_max_id"`
+}
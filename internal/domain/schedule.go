@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+type ScheduledOperationStatus string
+
+const (
+	ScheduledOperationStatusActive    ScheduledOperationStatus = "active"
+	ScheduledOperationStatusPaused    ScheduledOperationStatus = "paused"
+	ScheduledOperationStatusCancelled ScheduledOperationStatus = "cancelled"
+	ScheduledOperationStatusCompleted ScheduledOperationStatus = "completed"
+)
+
+func (s ScheduledOperationStatus) String() string {
+	return string(s)
+}
+
+// A one-off operation has no cron expression and completes after its run.
+type ScheduledOperation struct {
+	ID             string                   `json:"id" db:"id"`
+	MaxID          string                   `json:"max_id" db:"user_id"`
+	WalletType     WalletType               `json:"wallet_type" db:"wallet_type"`
+	Amount         int                      `json:"amount" db:"amount"`
+	Type           BalanceOperationType     `json:"type" db:"type"`
+	Description    string                   `json:"description" db:"description"`
+	ReasonCode     BalanceOperationReason   `json:"reason_code" db:"reason_code"`
+	Metadata       BalanceOperationMetadata `json:"metadata" db:"metadata"`
+	CronExpression string                   `json:"cron_expression" db:"cron_expression"`
+	Status         ScheduledOperationStatus `json:"status" db:"status"`
+	NextRunAt      *time.Time               `json:"next_run_at" db:"next_run_at"`
+	LastRunAt      *time.Time               `json:"last_run_at" db:"last_run_at"`
+	CreatedAt      time.Time                `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at" db:"updated_at"`
+
+	// Outcome of the most recent run.
+	LastOperationID string `json:"last_operation_id" db:"last_operation_id"`
+	LastError       string `json:"last_error" db:"last_error"`
+}
+
+func (o *ScheduledOperation) Recurring() bool {
+	return o.CronExpression != ""
+}
@@ -28,8 +28,89 @@ type BalanceOperationType string
 const (
 	BalanceOperationTypeDeposit  BalanceOperationType = "deposit"
 	BalanceOperationTypeWithdraw BalanceOperationType = "withdraw"
+
+	// Adjustments carry a signed amount.
+
+	BalanceOperationTypeAdjustment BalanceOperationType = "adjustment"
+
+	// Only the expiry job writes expire operations.
+	BalanceOperationTypeExpire BalanceOperationType = "expire"
 )
 
 func (t BalanceOperationType) String() string {
 	return string(t)
 }
+
+// Each user has one balance per wallet type.
+type WalletType string
+
+const (
+	// WalletTypePoints is used whenever a caller does not name a wallet.
+	WalletTypePoints WalletType = "points"
+
+	// WalletTypeKarma only feeds reputation and cannot be spent.
+	WalletTypeKarma WalletType = "karma"
+
+	WalletTypeEventTokens WalletType = "event_tokens"
+)
+
+func (t WalletType) String() string {
+	return string(t)
+}
+
+func (t WalletType) Valid() bool {
+	switch t {
+	case WalletTypePoints, WalletTypeKarma, WalletTypeEventTokens:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t WalletType) Spendable() bool {
+	return t != WalletTypeKarma
+}
+
+// CountsTowardsReputation is about earned points on the leaderboard.
+func (t WalletType) CountsTowardsReputation() bool {
+	return t != WalletTypeEventTokens
+}
+
+// FeedsReputationScore is about the reputation score and with it the group.
+func (t WalletType) FeedsReputationScore() bool {
+	return t == WalletTypeKarma
+}
+
+func (t WalletType) Expires() bool {
+	return t != WalletTypeKarma
+}
+
+type BalanceOperationReason string
+
+const (
+	BalanceOperationReasonTaskReward   BalanceOperationReason = "task_reward"
+	BalanceOperationReasonEventBonus   BalanceOperationReason = "event_bonus"
+	BalanceOperationReasonShopPurchase BalanceOperationReason = "shop_purchase"
+	BalanceOperationReasonTransfer     BalanceOperationReason = "transfer"
+	BalanceOperationReasonReversal     BalanceOperationReason = "reversal"
+	BalanceOperationReasonAdjustment   BalanceOperationReason = "adjustment"
+	BalanceOperationReasonExpiry       BalanceOperationReason = "expiry"
+	BalanceOperationReasonOther        BalanceOperationReason = "other"
+)
+
+func (r BalanceOperationReason) String() string {
+	return string(r)
+}
+
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusReleased HoldStatus = "released"
+	HoldStatusExpired  HoldStatus = "expired"
+)
+
+func (s HoldStatus) String() string {
+	return string(s)
+}
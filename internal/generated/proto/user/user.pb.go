@@ -21,380 +21,6411 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type BalanceOperationType int32
+// Periods are aligned to UTC; weeks start on Monday.
+type BalanceHistoryGranularity int32
 
 const (
-	BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED BalanceOperationType = 0
-	BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT     BalanceOperationType = 1
-	BalanceOperationType_BALANCE_OPERATION_TYPE_WITHDRAW    BalanceOperationType = 2
+	BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_UNSPECIFIED BalanceHistoryGranularity = 0
+	BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_DAY         BalanceHistoryGranularity = 1
+	BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_WEEK        BalanceHistoryGranularity = 2
+	BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_MONTH       BalanceHistoryGranularity = 3
 )
 
-// Enum value maps for BalanceOperationType.
+// Enum value maps for BalanceHistoryGranularity.
 var (
-	BalanceOperationType_name = map[int32]string{
-		0: "BALANCE_OPERATION_TYPE_UNSPECIFIED",
-		1: "BALANCE_OPERATION_TYPE_DEPOSIT",
-		2: "BALANCE_OPERATION_TYPE_WITHDRAW",
+	BalanceHistoryGranularity_name = map[int32]string{
+		0: "BALANCE_HISTORY_GRANULARITY_UNSPECIFIED",
+		1: "BALANCE_HISTORY_GRANULARITY_DAY",
+		2: "BALANCE_HISTORY_GRANULARITY_WEEK",
+		3: "BALANCE_HISTORY_GRANULARITY_MONTH",
 	}
-	BalanceOperationType_value = map[string]int32{
-		"BALANCE_OPERATION_TYPE_UNSPECIFIED": 0,
-		"BALANCE_OPERATION_TYPE_DEPOSIT":     1,
-		"BALANCE_OPERATION_TYPE_WITHDRAW":    2,
+	BalanceHistoryGranularity_value = map[string]int32{
+		"BALANCE_HISTORY_GRANULARITY_UNSPECIFIED": 0,
+		"BALANCE_HISTORY_GRANULARITY_DAY":         1,
+		"BALANCE_HISTORY_GRANULARITY_WEEK":        2,
+		"BALANCE_HISTORY_GRANULARITY_MONTH":       3,
 	}
 )
 
-func (x BalanceOperationType) Enum() *BalanceOperationType {
-	p := new(BalanceOperationType)
+func (x BalanceHistoryGranularity) Enum() *BalanceHistoryGranularity {
+	p := new(BalanceHistoryGranularity)
 	*p = x
 	return p
 }
 
-func (x BalanceOperationType) String() string {
+func (x BalanceHistoryGranularity) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (BalanceOperationType) Descriptor() protoreflect.EnumDescriptor {
+func (BalanceHistoryGranularity) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_user_user_proto_enumTypes[0].Descriptor()
 }
 
-func (BalanceOperationType) Type() protoreflect.EnumType {
+func (BalanceHistoryGranularity) Type() protoreflect.EnumType {
 	return &file_proto_user_user_proto_enumTypes[0]
 }
 
-func (x BalanceOperationType) Number() protoreflect.EnumNumber {
+func (x BalanceHistoryGranularity) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use BalanceOperationType.Descriptor instead.
-func (BalanceOperationType) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use BalanceHistoryGranularity.Descriptor instead.
+func (BalanceHistoryGranularity) EnumDescriptor() ([]byte, []int) {
 	return file_proto_user_user_proto_rawDescGZIP(), []int{0}
 }
 
-type Sex int32
+type BalanceStatsGroupBy int32
 
 const (
-	Sex_SEX_UNSPECIFIED Sex = 0
-	Sex_SEX_MALE        Sex = 1
-	Sex_SEX_FEMALE      Sex = 2
+	BalanceStatsGroupBy_BALANCE_STATS_GROUP_BY_UNSPECIFIED BalanceStatsGroupBy = 0
+	BalanceStatsGroupBy_BALANCE_STATS_GROUP_BY_REASON      BalanceStatsGroupBy = 1
+	BalanceStatsGroupBy_BALANCE_STATS_GROUP_BY_DESCRIPTION BalanceStatsGroupBy = 2
 )
 
-// Enum value maps for Sex.
+// Enum value maps for BalanceStatsGroupBy.
 var (
-	Sex_name = map[int32]string{
-		0: "SEX_UNSPECIFIED",
-		1: "SEX_MALE",
-		2: "SEX_FEMALE",
+	BalanceStatsGroupBy_name = map[int32]string{
+		0: "BALANCE_STATS_GROUP_BY_UNSPECIFIED",
+		1: "BALANCE_STATS_GROUP_BY_REASON",
+		2: "BALANCE_STATS_GROUP_BY_DESCRIPTION",
 	}
-	Sex_value = map[string]int32{
-		"SEX_UNSPECIFIED": 0,
-		"SEX_MALE":        1,
-		"SEX_FEMALE":      2,
+	BalanceStatsGroupBy_value = map[string]int32{
+		"BALANCE_STATS_GROUP_BY_UNSPECIFIED": 0,
+		"BALANCE_STATS_GROUP_BY_REASON":      1,
+		"BALANCE_STATS_GROUP_BY_DESCRIPTION": 2,
 	}
 )
 
-func (x Sex) Enum() *Sex {
-	p := new(Sex)
+func (x BalanceStatsGroupBy) Enum() *BalanceStatsGroupBy {
+	p := new(BalanceStatsGroupBy)
 	*p = x
 	return p
 }
 
-func (x Sex) String() string {
+func (x BalanceStatsGroupBy) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Sex) Descriptor() protoreflect.EnumDescriptor {
+func (BalanceStatsGroupBy) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_user_user_proto_enumTypes[1].Descriptor()
 }
 
-func (Sex) Type() protoreflect.EnumType {
+func (BalanceStatsGroupBy) Type() protoreflect.EnumType {
 	return &file_proto_user_user_proto_enumTypes[1]
 }
 
-func (x Sex) Number() protoreflect.EnumNumber {
+func (x BalanceStatsGroupBy) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use Sex.Descriptor instead.
-func (Sex) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use BalanceStatsGroupBy.Descriptor instead.
+func (BalanceStatsGroupBy) EnumDescriptor() ([]byte, []int) {
 	return file_proto_user_user_proto_rawDescGZIP(), []int{1}
 }
 
-type Role int32
+type BatchMode int32
 
 const (
-	Role_ROLE_UNSPECIFIED Role = 0
-	Role_ROLE_USER        Role = 1
-	Role_ROLE_ADMIN       Role = 2
+	BatchMode_BATCH_MODE_UNSPECIFIED    BatchMode = 0
+	BatchMode_BATCH_MODE_ALL_OR_NOTHING BatchMode = 1
+	BatchMode_BATCH_MODE_BEST_EFFORT    BatchMode = 2
 )
 
-// Enum value maps for Role.
+// Enum value maps for BatchMode.
 var (
-	Role_name = map[int32]string{
-		0: "ROLE_UNSPECIFIED",
-		1: "ROLE_USER",
-		2: "ROLE_ADMIN",
+	BatchMode_name = map[int32]string{
+		0: "BATCH_MODE_UNSPECIFIED",
+		1: "BATCH_MODE_ALL_OR_NOTHING",
+		2: "BATCH_MODE_BEST_EFFORT",
 	}
-	Role_value = map[string]int32{
-		"ROLE_UNSPECIFIED": 0,
-		"ROLE_USER":        1,
-		"ROLE_ADMIN":       2,
+	BatchMode_value = map[string]int32{
+		"BATCH_MODE_UNSPECIFIED":    0,
+		"BATCH_MODE_ALL_OR_NOTHING": 1,
+		"BATCH_MODE_BEST_EFFORT":    2,
 	}
 )
 
-func (x Role) Enum() *Role {
-	p := new(Role)
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
 	*p = x
 	return p
 }
 
-func (x Role) String() string {
+func (x BatchMode) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Role) Descriptor() protoreflect.EnumDescriptor {
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_user_user_proto_enumTypes[2].Descriptor()
 }
 
-func (Role) Type() protoreflect.EnumType {
+func (BatchMode) Type() protoreflect.EnumType {
 	return &file_proto_user_user_proto_enumTypes[2]
 }
 
-func (x Role) Number() protoreflect.EnumNumber {
+func (x BatchMode) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use Role.Descriptor instead.
-func (Role) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
 	return file_proto_user_user_proto_rawDescGZIP(), []int{2}
 }
 
-type Status int32
+type LeaderboardPeriod int32
 
 const (
-	Status_STATUS_UNSPECIFIED Status = 0
-	Status_STATUS_ACTIVE      Status = 1
-	Status_STATUS_INACTIVE    Status = 2
+	LeaderboardPeriod_LEADERBOARD_PERIOD_UNSPECIFIED LeaderboardPeriod = 0
+	LeaderboardPeriod_LEADERBOARD_PERIOD_ALL_TIME    LeaderboardPeriod = 1
+	LeaderboardPeriod_LEADERBOARD_PERIOD_MONTH       LeaderboardPeriod = 2
+	LeaderboardPeriod_LEADERBOARD_PERIOD_WEEK        LeaderboardPeriod = 3
 )
 
-// Enum value maps for Status.
+// Enum value maps for LeaderboardPeriod.
 var (
-	Status_name = map[int32]string{
-		0: "STATUS_UNSPECIFIED",
-		1: "STATUS_ACTIVE",
-		2: "STATUS_INACTIVE",
+	LeaderboardPeriod_name = map[int32]string{
+		0: "LEADERBOARD_PERIOD_UNSPECIFIED",
+		1: "LEADERBOARD_PERIOD_ALL_TIME",
+		2: "LEADERBOARD_PERIOD_MONTH",
+		3: "LEADERBOARD_PERIOD_WEEK",
 	}
-	Status_value = map[string]int32{
-		"STATUS_UNSPECIFIED": 0,
-		"STATUS_ACTIVE":      1,
-		"STATUS_INACTIVE":    2,
+	LeaderboardPeriod_value = map[string]int32{
+		"LEADERBOARD_PERIOD_UNSPECIFIED": 0,
+		"LEADERBOARD_PERIOD_ALL_TIME":    1,
+		"LEADERBOARD_PERIOD_MONTH":       2,
+		"LEADERBOARD_PERIOD_WEEK":        3,
 	}
 )
 
-func (x Status) Enum() *Status {
-	p := new(Status)
+func (x LeaderboardPeriod) Enum() *LeaderboardPeriod {
+	p := new(LeaderboardPeriod)
 	*p = x
 	return p
 }
 
-func (x Status) String() string {
+func (x LeaderboardPeriod) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Status) Descriptor() protoreflect.EnumDescriptor {
+func (LeaderboardPeriod) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_user_user_proto_enumTypes[3].Descriptor()
 }
 
-func (Status) Type() protoreflect.EnumType {
+func (LeaderboardPeriod) Type() protoreflect.EnumType {
 	return &file_proto_user_user_proto_enumTypes[3]
 }
 
-func (x Status) Number() protoreflect.EnumNumber {
+func (x LeaderboardPeriod) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use Status.Descriptor instead.
-func (Status) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use LeaderboardPeriod.Descriptor instead.
+func (LeaderboardPeriod) EnumDescriptor() ([]byte, []int) {
 	return file_proto_user_user_proto_rawDescGZIP(), []int{3}
 }
 
-type ErrorCode int32
+type LeaderboardMetric int32
 
 const (
-	ErrorCode_ERROR_CODE_UNSPECIFIED    ErrorCode = 0
-	ErrorCode_ERROR_CODE_VALIDATION     ErrorCode = 1
-	ErrorCode_ERROR_CODE_NOT_FOUND      ErrorCode = 2
-	ErrorCode_ERROR_CODE_INTERNAL       ErrorCode = 3
-	ErrorCode_ERROR_CODE_ALREADY_EXISTS ErrorCode = 4
-	ErrorCode_ERROR_CODE_NOT_ENOUGH     ErrorCode = 5
+	LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED LeaderboardMetric = 0
+	LeaderboardMetric_LEADERBOARD_METRIC_BALANCE     LeaderboardMetric = 1
+	// Points counted towards reputation during the period.
+	LeaderboardMetric_LEADERBOARD_METRIC_EARNED LeaderboardMetric = 2
 )
 
-// Enum value maps for ErrorCode.
+// Enum value maps for LeaderboardMetric.
 var (
-	ErrorCode_name = map[int32]string{
-		0: "ERROR_CODE_UNSPECIFIED",
-		1: "ERROR_CODE_VALIDATION",
-		2: "ERROR_CODE_NOT_FOUND",
-		3: "ERROR_CODE_INTERNAL",
-		4: "ERROR_CODE_ALREADY_EXISTS",
-		5: "ERROR_CODE_NOT_ENOUGH",
+	LeaderboardMetric_name = map[int32]string{
+		0: "LEADERBOARD_METRIC_UNSPECIFIED",
+		1: "LEADERBOARD_METRIC_BALANCE",
+		2: "LEADERBOARD_METRIC_EARNED",
 	}
-	ErrorCode_value = map[string]int32{
-		"ERROR_CODE_UNSPECIFIED":    0,
-		"ERROR_CODE_VALIDATION":     1,
-		"ERROR_CODE_NOT_FOUND":      2,
-		"ERROR_CODE_INTERNAL":       3,
-		"ERROR_CODE_ALREADY_EXISTS": 4,
-		"ERROR_CODE_NOT_ENOUGH":     5,
+	LeaderboardMetric_value = map[string]int32{
+		"LEADERBOARD_METRIC_UNSPECIFIED": 0,
+		"LEADERBOARD_METRIC_BALANCE":     1,
+		"LEADERBOARD_METRIC_EARNED":      2,
 	}
 )
 
-func (x ErrorCode) Enum() *ErrorCode {
-	p := new(ErrorCode)
+func (x LeaderboardMetric) Enum() *LeaderboardMetric {
+	p := new(LeaderboardMetric)
 	*p = x
 	return p
 }
 
-func (x ErrorCode) String() string {
+func (x LeaderboardMetric) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+func (LeaderboardMetric) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_user_user_proto_enumTypes[4].Descriptor()
 }
 
-func (ErrorCode) Type() protoreflect.EnumType {
+func (LeaderboardMetric) Type() protoreflect.EnumType {
 	return &file_proto_user_user_proto_enumTypes[4]
 }
 
-func (x ErrorCode) Number() protoreflect.EnumNumber {
+func (x LeaderboardMetric) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use ErrorCode.Descriptor instead.
-func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use LeaderboardMetric.Descriptor instead.
+func (LeaderboardMetric) EnumDescriptor() ([]byte, []int) {
 	return file_proto_user_user_proto_rawDescGZIP(), []int{4}
 }
 
-type GetBalanceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxId         string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ScheduledOperationStatus int32
+
+const (
+	ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_UNSPECIFIED ScheduledOperationStatus = 0
+	ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_ACTIVE      ScheduledOperationStatus = 1
+	ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_PAUSED      ScheduledOperationStatus = 2
+	ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_CANCELLED   ScheduledOperationStatus = 3
+	ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_COMPLETED   ScheduledOperationStatus = 4
+)
+
+// Enum value maps for ScheduledOperationStatus.
+var (
+	ScheduledOperationStatus_name = map[int32]string{
+		0: "SCHEDULED_OPERATION_STATUS_UNSPECIFIED",
+		1: "SCHEDULED_OPERATION_STATUS_ACTIVE",
+		2: "SCHEDULED_OPERATION_STATUS_PAUSED",
+		3: "SCHEDULED_OPERATION_STATUS_CANCELLED",
+		4: "SCHEDULED_OPERATION_STATUS_COMPLETED",
+	}
+	ScheduledOperationStatus_value = map[string]int32{
+		"SCHEDULED_OPERATION_STATUS_UNSPECIFIED": 0,
+		"SCHEDULED_OPERATION_STATUS_ACTIVE":      1,
+		"SCHEDULED_OPERATION_STATUS_PAUSED":      2,
+		"SCHEDULED_OPERATION_STATUS_CANCELLED":   3,
+		"SCHEDULED_OPERATION_STATUS_COMPLETED":   4,
+	}
+)
+
+func (x ScheduledOperationStatus) Enum() *ScheduledOperationStatus {
+	p := new(ScheduledOperationStatus)
+	*p = x
+	return p
 }
 
-func (x *GetBalanceRequest) Reset() {
-	*x = GetBalanceRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x ScheduledOperationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *GetBalanceRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (ScheduledOperationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[5].Descriptor()
 }
 
-func (*GetBalanceRequest) ProtoMessage() {}
+func (ScheduledOperationStatus) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[5]
+}
 
-func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x ScheduledOperationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduledOperationStatus.Descriptor instead.
+func (ScheduledOperationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{5}
+}
+
+type HoldStatus int32
+
+const (
+	HoldStatus_HOLD_STATUS_UNSPECIFIED HoldStatus = 0
+	HoldStatus_HOLD_STATUS_ACTIVE      HoldStatus = 1
+	HoldStatus_HOLD_STATUS_CAPTURED    HoldStatus = 2
+	HoldStatus_HOLD_STATUS_RELEASED    HoldStatus = 3
+	HoldStatus_HOLD_STATUS_EXPIRED     HoldStatus = 4
+)
+
+// Enum value maps for HoldStatus.
+var (
+	HoldStatus_name = map[int32]string{
+		0: "HOLD_STATUS_UNSPECIFIED",
+		1: "HOLD_STATUS_ACTIVE",
+		2: "HOLD_STATUS_CAPTURED",
+		3: "HOLD_STATUS_RELEASED",
+		4: "HOLD_STATUS_EXPIRED",
 	}
-	return mi.MessageOf(x)
+	HoldStatus_value = map[string]int32{
+		"HOLD_STATUS_UNSPECIFIED": 0,
+		"HOLD_STATUS_ACTIVE":      1,
+		"HOLD_STATUS_CAPTURED":    2,
+		"HOLD_STATUS_RELEASED":    3,
+		"HOLD_STATUS_EXPIRED":     4,
+	}
+)
+
+func (x HoldStatus) Enum() *HoldStatus {
+	p := new(HoldStatus)
+	*p = x
+	return p
 }
 
-// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
-func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{0}
+func (x HoldStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *GetBalanceRequest) GetMaxId() string {
-	if x != nil {
-		return x.MaxId
+func (HoldStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[6].Descriptor()
+}
+
+func (HoldStatus) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[6]
+}
+
+func (x HoldStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HoldStatus.Descriptor instead.
+func (HoldStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{6}
+}
+
+type LedgerAccountKind int32
+
+const (
+	LedgerAccountKind_LEDGER_ACCOUNT_KIND_UNSPECIFIED LedgerAccountKind = 0
+	LedgerAccountKind_LEDGER_ACCOUNT_KIND_WALLET      LedgerAccountKind = 1
+	LedgerAccountKind_LEDGER_ACCOUNT_KIND_SYSTEM      LedgerAccountKind = 2
+)
+
+// Enum value maps for LedgerAccountKind.
+var (
+	LedgerAccountKind_name = map[int32]string{
+		0: "LEDGER_ACCOUNT_KIND_UNSPECIFIED",
+		1: "LEDGER_ACCOUNT_KIND_WALLET",
+		2: "LEDGER_ACCOUNT_KIND_SYSTEM",
 	}
-	return ""
+	LedgerAccountKind_value = map[string]int32{
+		"LEDGER_ACCOUNT_KIND_UNSPECIFIED": 0,
+		"LEDGER_ACCOUNT_KIND_WALLET":      1,
+		"LEDGER_ACCOUNT_KIND_SYSTEM":      2,
+	}
+)
+
+func (x LedgerAccountKind) Enum() *LedgerAccountKind {
+	p := new(LedgerAccountKind)
+	*p = x
+	return p
 }
 
-type GetBalanceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Balance       int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
-	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x LedgerAccountKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *GetBalanceResponse) Reset() {
-	*x = GetBalanceResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (LedgerAccountKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[7].Descriptor()
 }
 
-func (x *GetBalanceResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (LedgerAccountKind) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[7]
 }
 
-func (*GetBalanceResponse) ProtoMessage() {}
+func (x LedgerAccountKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
 
-func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+// Deprecated: Use LedgerAccountKind.Descriptor instead.
+func (LedgerAccountKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{7}
+}
+
+type BalanceOperationReason int32
+
+const (
+	BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED   BalanceOperationReason = 0
+	BalanceOperationReason_BALANCE_OPERATION_REASON_TASK_REWARD   BalanceOperationReason = 1
+	BalanceOperationReason_BALANCE_OPERATION_REASON_EVENT_BONUS   BalanceOperationReason = 2
+	BalanceOperationReason_BALANCE_OPERATION_REASON_SHOP_PURCHASE BalanceOperationReason = 3
+	BalanceOperationReason_BALANCE_OPERATION_REASON_TRANSFER      BalanceOperationReason = 4
+	BalanceOperationReason_BALANCE_OPERATION_REASON_REVERSAL      BalanceOperationReason = 5
+	BalanceOperationReason_BALANCE_OPERATION_REASON_ADJUSTMENT    BalanceOperationReason = 6
+	BalanceOperationReason_BALANCE_OPERATION_REASON_OTHER         BalanceOperationReason = 7
+	BalanceOperationReason_BALANCE_OPERATION_REASON_EXPIRY        BalanceOperationReason = 8
+)
+
+// Enum value maps for BalanceOperationReason.
+var (
+	BalanceOperationReason_name = map[int32]string{
+		0: "BALANCE_OPERATION_REASON_UNSPECIFIED",
+		1: "BALANCE_OPERATION_REASON_TASK_REWARD",
+		2: "BALANCE_OPERATION_REASON_EVENT_BONUS",
+		3: "BALANCE_OPERATION_REASON_SHOP_PURCHASE",
+		4: "BALANCE_OPERATION_REASON_TRANSFER",
+		5: "BALANCE_OPERATION_REASON_REVERSAL",
+		6: "BALANCE_OPERATION_REASON_ADJUSTMENT",
+		7: "BALANCE_OPERATION_REASON_OTHER",
+		8: "BALANCE_OPERATION_REASON_EXPIRY",
 	}
-	return mi.MessageOf(x)
+	BalanceOperationReason_value = map[string]int32{
+		"BALANCE_OPERATION_REASON_UNSPECIFIED":   0,
+		"BALANCE_OPERATION_REASON_TASK_REWARD":   1,
+		"BALANCE_OPERATION_REASON_EVENT_BONUS":   2,
+		"BALANCE_OPERATION_REASON_SHOP_PURCHASE": 3,
+		"BALANCE_OPERATION_REASON_TRANSFER":      4,
+		"BALANCE_OPERATION_REASON_REVERSAL":      5,
+		"BALANCE_OPERATION_REASON_ADJUSTMENT":    6,
+		"BALANCE_OPERATION_REASON_OTHER":         7,
+		"BALANCE_OPERATION_REASON_EXPIRY":        8,
+	}
+)
+
+func (x BalanceOperationReason) Enum() *BalanceOperationReason {
+	p := new(BalanceOperationReason)
+	*p = x
+	return p
 }
 
-// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
-func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{1}
+func (x BalanceOperationReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *GetBalanceResponse) GetBalance() int32 {
-	if x != nil {
-		return x.Balance
+func (BalanceOperationReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[8].Descriptor()
+}
+
+func (BalanceOperationReason) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[8]
+}
+
+func (x BalanceOperationReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceOperationReason.Descriptor instead.
+func (BalanceOperationReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{8}
+}
+
+// Unspecified means the points wallet. Karma only feeds reputation and cannot
+// be spent; event tokens are spendable, expire sooner than points and do not
+// count towards reputation.
+type WalletType int32
+
+const (
+	WalletType_WALLET_TYPE_UNSPECIFIED  WalletType = 0
+	WalletType_WALLET_TYPE_POINTS       WalletType = 1
+	WalletType_WALLET_TYPE_KARMA        WalletType = 2
+	WalletType_WALLET_TYPE_EVENT_TOKENS WalletType = 3
+)
+
+// Enum value maps for WalletType.
+var (
+	WalletType_name = map[int32]string{
+		0: "WALLET_TYPE_UNSPECIFIED",
+		1: "WALLET_TYPE_POINTS",
+		2: "WALLET_TYPE_KARMA",
+		3: "WALLET_TYPE_EVENT_TOKENS",
 	}
-	return 0
+	WalletType_value = map[string]int32{
+		"WALLET_TYPE_UNSPECIFIED":  0,
+		"WALLET_TYPE_POINTS":       1,
+		"WALLET_TYPE_KARMA":        2,
+		"WALLET_TYPE_EVENT_TOKENS": 3,
+	}
+)
+
+func (x WalletType) Enum() *WalletType {
+	p := new(WalletType)
+	*p = x
+	return p
 }
 
-func (x *GetBalanceResponse) GetError() *Error {
+func (x WalletType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WalletType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[9].Descriptor()
+}
+
+func (WalletType) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[9]
+}
+
+func (x WalletType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WalletType.Descriptor instead.
+func (WalletType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{9}
+}
+
+type BalanceOperationType int32
+
+const (
+	BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED BalanceOperationType = 0
+	BalanceOperationType_BALANCE_OPERATION_TYPE_DEPOSIT     BalanceOperationType = 1
+	BalanceOperationType_BALANCE_OPERATION_TYPE_WITHDRAW    BalanceOperationType = 2
+	BalanceOperationType_BALANCE_OPERATION_TYPE_ADJUSTMENT  BalanceOperationType = 3
+	BalanceOperationType_BALANCE_OPERATION_TYPE_EXPIRE      BalanceOperationType = 4
+)
+
+// Enum value maps for BalanceOperationType.
+var (
+	BalanceOperationType_name = map[int32]string{
+		0: "BALANCE_OPERATION_TYPE_UNSPECIFIED",
+		1: "BALANCE_OPERATION_TYPE_DEPOSIT",
+		2: "BALANCE_OPERATION_TYPE_WITHDRAW",
+		3: "BALANCE_OPERATION_TYPE_ADJUSTMENT",
+		4: "BALANCE_OPERATION_TYPE_EXPIRE",
+	}
+	BalanceOperationType_value = map[string]int32{
+		"BALANCE_OPERATION_TYPE_UNSPECIFIED": 0,
+		"BALANCE_OPERATION_TYPE_DEPOSIT":     1,
+		"BALANCE_OPERATION_TYPE_WITHDRAW":    2,
+		"BALANCE_OPERATION_TYPE_ADJUSTMENT":  3,
+		"BALANCE_OPERATION_TYPE_EXPIRE":      4,
+	}
+)
+
+func (x BalanceOperationType) Enum() *BalanceOperationType {
+	p := new(BalanceOperationType)
+	*p = x
+	return p
+}
+
+func (x BalanceOperationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceOperationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[10].Descriptor()
+}
+
+func (BalanceOperationType) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[10]
+}
+
+func (x BalanceOperationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceOperationType.Descriptor instead.
+func (BalanceOperationType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{10}
+}
+
+type ReputationChangeCause int32
+
+const (
+	ReputationChangeCause_REPUTATION_CHANGE_CAUSE_UNSPECIFIED      ReputationChangeCause = 0
+	ReputationChangeCause_REPUTATION_CHANGE_CAUSE_REPUTATION_EVENT ReputationChangeCause = 1
+	// A manual adjustment or a group deleted with its members moved.
+	ReputationChangeCause_REPUTATION_CHANGE_CAUSE_ADMIN         ReputationChangeCause = 2
+	ReputationChangeCause_REPUTATION_CHANGE_CAUSE_DECAY         ReputationChangeCause = 3
+	ReputationChangeCause_REPUTATION_CHANGE_CAUSE_RECALCULATION ReputationChangeCause = 4
+)
+
+// Enum value maps for ReputationChangeCause.
+var (
+	ReputationChangeCause_name = map[int32]string{
+		0: "REPUTATION_CHANGE_CAUSE_UNSPECIFIED",
+		1: "REPUTATION_CHANGE_CAUSE_REPUTATION_EVENT",
+		2: "REPUTATION_CHANGE_CAUSE_ADMIN",
+		3: "REPUTATION_CHANGE_CAUSE_DECAY",
+		4: "REPUTATION_CHANGE_CAUSE_RECALCULATION",
+	}
+	ReputationChangeCause_value = map[string]int32{
+		"REPUTATION_CHANGE_CAUSE_UNSPECIFIED":      0,
+		"REPUTATION_CHANGE_CAUSE_REPUTATION_EVENT": 1,
+		"REPUTATION_CHANGE_CAUSE_ADMIN":            2,
+		"REPUTATION_CHANGE_CAUSE_DECAY":            3,
+		"REPUTATION_CHANGE_CAUSE_RECALCULATION":    4,
+	}
+)
+
+func (x ReputationChangeCause) Enum() *ReputationChangeCause {
+	p := new(ReputationChangeCause)
+	*p = x
+	return p
+}
+
+func (x ReputationChangeCause) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReputationChangeCause) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[11].Descriptor()
+}
+
+func (ReputationChangeCause) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[11]
+}
+
+func (x ReputationChangeCause) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReputationChangeCause.Descriptor instead.
+func (ReputationChangeCause) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{11}
+}
+
+type ReputationEventReason int32
+
+const (
+	ReputationEventReason_REPUTATION_EVENT_REASON_UNSPECIFIED         ReputationEventReason = 0
+	ReputationEventReason_REPUTATION_EVENT_REASON_TASK_COMPLETED      ReputationEventReason = 1
+	ReputationEventReason_REPUTATION_EVENT_REASON_EVENT_PARTICIPATION ReputationEventReason = 2
+	ReputationEventReason_REPUTATION_EVENT_REASON_MODERATION_PENALTY  ReputationEventReason = 3
+	ReputationEventReason_REPUTATION_EVENT_REASON_ADJUSTMENT          ReputationEventReason = 4
+	// Seeded from deposit totals when reputation events were introduced.
+	ReputationEventReason_REPUTATION_EVENT_REASON_MIGRATION ReputationEventReason = 5
+	ReputationEventReason_REPUTATION_EVENT_REASON_OTHER     ReputationEventReason = 6
+	// Taken by the decay job after a period without new reputation.
+	ReputationEventReason_REPUTATION_EVENT_REASON_DECAY ReputationEventReason = 7
+)
+
+// Enum value maps for ReputationEventReason.
+var (
+	ReputationEventReason_name = map[int32]string{
+		0: "REPUTATION_EVENT_REASON_UNSPECIFIED",
+		1: "REPUTATION_EVENT_REASON_TASK_COMPLETED",
+		2: "REPUTATION_EVENT_REASON_EVENT_PARTICIPATION",
+		3: "REPUTATION_EVENT_REASON_MODERATION_PENALTY",
+		4: "REPUTATION_EVENT_REASON_ADJUSTMENT",
+		5: "REPUTATION_EVENT_REASON_MIGRATION",
+		6: "REPUTATION_EVENT_REASON_OTHER",
+		7: "REPUTATION_EVENT_REASON_DECAY",
+	}
+	ReputationEventReason_value = map[string]int32{
+		"REPUTATION_EVENT_REASON_UNSPECIFIED":         0,
+		"REPUTATION_EVENT_REASON_TASK_COMPLETED":      1,
+		"REPUTATION_EVENT_REASON_EVENT_PARTICIPATION": 2,
+		"REPUTATION_EVENT_REASON_MODERATION_PENALTY":  3,
+		"REPUTATION_EVENT_REASON_ADJUSTMENT":          4,
+		"REPUTATION_EVENT_REASON_MIGRATION":           5,
+		"REPUTATION_EVENT_REASON_OTHER":               6,
+		"REPUTATION_EVENT_REASON_DECAY":               7,
+	}
+)
+
+func (x ReputationEventReason) Enum() *ReputationEventReason {
+	p := new(ReputationEventReason)
+	*p = x
+	return p
+}
+
+func (x ReputationEventReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReputationEventReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[12].Descriptor()
+}
+
+func (ReputationEventReason) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[12]
+}
+
+func (x ReputationEventReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReputationEventReason.Descriptor instead.
+func (ReputationEventReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{12}
+}
+
+type LimitDirection int32
+
+const (
+	LimitDirection_LIMIT_DIRECTION_UNSPECIFIED LimitDirection = 0
+	LimitDirection_LIMIT_DIRECTION_EARN        LimitDirection = 1
+	LimitDirection_LIMIT_DIRECTION_SPEND       LimitDirection = 2
+)
+
+// Enum value maps for LimitDirection.
+var (
+	LimitDirection_name = map[int32]string{
+		0: "LIMIT_DIRECTION_UNSPECIFIED",
+		1: "LIMIT_DIRECTION_EARN",
+		2: "LIMIT_DIRECTION_SPEND",
+	}
+	LimitDirection_value = map[string]int32{
+		"LIMIT_DIRECTION_UNSPECIFIED": 0,
+		"LIMIT_DIRECTION_EARN":        1,
+		"LIMIT_DIRECTION_SPEND":       2,
+	}
+)
+
+func (x LimitDirection) Enum() *LimitDirection {
+	p := new(LimitDirection)
+	*p = x
+	return p
+}
+
+func (x LimitDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LimitDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[13].Descriptor()
+}
+
+func (LimitDirection) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[13]
+}
+
+func (x LimitDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LimitDirection.Descriptor instead.
+func (LimitDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{13}
+}
+
+type Sex int32
+
+const (
+	Sex_SEX_UNSPECIFIED Sex = 0
+	Sex_SEX_MALE        Sex = 1
+	Sex_SEX_FEMALE      Sex = 2
+)
+
+// Enum value maps for Sex.
+var (
+	Sex_name = map[int32]string{
+		0: "SEX_UNSPECIFIED",
+		1: "SEX_MALE",
+		2: "SEX_FEMALE",
+	}
+	Sex_value = map[string]int32{
+		"SEX_UNSPECIFIED": 0,
+		"SEX_MALE":        1,
+		"SEX_FEMALE":      2,
+	}
+)
+
+func (x Sex) Enum() *Sex {
+	p := new(Sex)
+	*p = x
+	return p
+}
+
+func (x Sex) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Sex) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[14].Descriptor()
+}
+
+func (Sex) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[14]
+}
+
+func (x Sex) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Sex.Descriptor instead.
+func (Sex) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{14}
+}
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_USER        Role = 1
+	Role_ROLE_ADMIN       Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_USER",
+		2: "ROLE_ADMIN",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_USER":        1,
+		"ROLE_ADMIN":       2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[15].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[15]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{15}
+}
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+	Status_STATUS_INACTIVE    Status = 2
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+		2: "STATUS_INACTIVE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+		"STATUS_INACTIVE":    2,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[16].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[16]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{16}
+}
+
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED            ErrorCode = 0
+	ErrorCode_ERROR_CODE_VALIDATION             ErrorCode = 1
+	ErrorCode_ERROR_CODE_NOT_FOUND              ErrorCode = 2
+	ErrorCode_ERROR_CODE_INTERNAL               ErrorCode = 3
+	ErrorCode_ERROR_CODE_ALREADY_EXISTS         ErrorCode = 4
+	ErrorCode_ERROR_CODE_NOT_ENOUGH             ErrorCode = 5
+	ErrorCode_ERROR_CODE_IDEMPOTENCY_KEY_REUSED ErrorCode = 6
+	ErrorCode_ERROR_CODE_CONFLICT               ErrorCode = 7
+	ErrorCode_ERROR_CODE_LIMIT_EXCEEDED         ErrorCode = 8
+	ErrorCode_ERROR_CODE_FORBIDDEN              ErrorCode = 9
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_VALIDATION",
+		2: "ERROR_CODE_NOT_FOUND",
+		3: "ERROR_CODE_INTERNAL",
+		4: "ERROR_CODE_ALREADY_EXISTS",
+		5: "ERROR_CODE_NOT_ENOUGH",
+		6: "ERROR_CODE_IDEMPOTENCY_KEY_REUSED",
+		7: "ERROR_CODE_CONFLICT",
+		8: "ERROR_CODE_LIMIT_EXCEEDED",
+		9: "ERROR_CODE_FORBIDDEN",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":            0,
+		"ERROR_CODE_VALIDATION":             1,
+		"ERROR_CODE_NOT_FOUND":              2,
+		"ERROR_CODE_INTERNAL":               3,
+		"ERROR_CODE_ALREADY_EXISTS":         4,
+		"ERROR_CODE_NOT_ENOUGH":             5,
+		"ERROR_CODE_IDEMPOTENCY_KEY_REUSED": 6,
+		"ERROR_CODE_CONFLICT":               7,
+		"ERROR_CODE_LIMIT_EXCEEDED":         8,
+		"ERROR_CODE_FORBIDDEN":              9,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_user_proto_enumTypes[17].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_proto_user_user_proto_enumTypes[17]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{17}
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxId         string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	WalletType    WalletType             `protobuf:"varint,2,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetBalanceRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetBalanceRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Available     int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	ExpiringSoon  []*ExpiringPoints      `protobuf:"bytes,4,rep,name=expiring_soon,json=expiringSoon,proto3" json:"expiring_soon,omitempty"`
+	WalletType    WalletType             `protobuf:"varint,5,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBalanceResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *GetBalanceResponse) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetExpiringSoon() []*ExpiringPoints {
+	if x != nil {
+		return x.ExpiringSoon
+	}
+	return nil
+}
+
+func (x *GetBalanceResponse) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type ExpiringPoints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int32                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiringPoints) Reset() {
+	*x = ExpiringPoints{}
+	mi := &file_proto_user_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiringPoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiringPoints) ProtoMessage() {}
+
+func (x *ExpiringPoints) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiringPoints.ProtoReflect.Descriptor instead.
+func (*ExpiringPoints) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExpiringPoints) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ExpiringPoints) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type WatchBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxId         string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	WalletType    WalletType             `protobuf:"varint,2,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBalanceRequest) Reset() {
+	*x = WatchBalanceRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBalanceRequest) ProtoMessage() {}
+
+func (x *WatchBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBalanceRequest.ProtoReflect.Descriptor instead.
+func (*WatchBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchBalanceRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *WatchBalanceRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+// The first message carries the current balance and no operation. Every
+// following message is sent after an operation on the balance commits.
+type WatchBalanceResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Balance   int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Available int32                  `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	Operation *BalanceOperation      `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Error     *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Set instead of operation when a hold was placed or released.
+	HoldId        string `protobuf:"bytes,5,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBalanceResponse) Reset() {
+	*x = WatchBalanceResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBalanceResponse) ProtoMessage() {}
+
+func (x *WatchBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBalanceResponse.ProtoReflect.Descriptor instead.
+func (*WatchBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchBalanceResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *WatchBalanceResponse) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *WatchBalanceResponse) GetOperation() *BalanceOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *WatchBalanceResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *WatchBalanceResponse) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+type GetBalanceAtRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MaxId string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Unix seconds. Operations created at this instant are included.
+	Timestamp     int64      `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	WalletType    WalletType `protobuf:"varint,3,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceAtRequest) Reset() {
+	*x = GetBalanceAtRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceAtRequest) ProtoMessage() {}
+
+func (x *GetBalanceAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceAtRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceAtRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetBalanceAtRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetBalanceAtRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *GetBalanceAtRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type GetBalanceAtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceAtResponse) Reset() {
+	*x = GetBalanceAtResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceAtResponse) ProtoMessage() {}
+
+func (x *GetBalanceAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceAtResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceAtResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBalanceAtResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *GetBalanceAtResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GetBalanceHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MaxId string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Unix seconds, inclusive.
+	From int64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Unix seconds, exclusive.
+	To            int64                     `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Granularity   BalanceHistoryGranularity `protobuf:"varint,4,opt,name=granularity,proto3,enum=user.BalanceHistoryGranularity" json:"granularity,omitempty"`
+	WalletType    WalletType                `protobuf:"varint,5,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceHistoryRequest) Reset() {
+	*x = GetBalanceHistoryRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceHistoryRequest) ProtoMessage() {}
+
+func (x *GetBalanceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBalanceHistoryRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetBalanceHistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetBalanceHistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetBalanceHistoryRequest) GetGranularity() BalanceHistoryGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return BalanceHistoryGranularity_BALANCE_HISTORY_GRANULARITY_UNSPECIFIED
+}
+
+func (x *GetBalanceHistoryRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type GetBalanceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*BalanceHistoryPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceHistoryResponse) Reset() {
+	*x = GetBalanceHistoryResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceHistoryResponse) ProtoMessage() {}
+
+func (x *GetBalanceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetBalanceHistoryResponse) GetPoints() []*BalanceHistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *GetBalanceHistoryResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BalanceHistoryPoint struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart int64                  `protobuf:"varint,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	// Exclusive. Cut short at the requested to for the last period.
+	PeriodEnd      int64 `protobuf:"varint,2,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	ClosingBalance int32 `protobuf:"varint,3,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BalanceHistoryPoint) Reset() {
+	*x = BalanceHistoryPoint{}
+	mi := &file_proto_user_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceHistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceHistoryPoint) ProtoMessage() {}
+
+func (x *BalanceHistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceHistoryPoint.ProtoReflect.Descriptor instead.
+func (*BalanceHistoryPoint) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BalanceHistoryPoint) GetPeriodStart() int64 {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return 0
+}
+
+func (x *BalanceHistoryPoint) GetPeriodEnd() int64 {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return 0
+}
+
+func (x *BalanceHistoryPoint) GetClosingBalance() int32 {
+	if x != nil {
+		return x.ClosingBalance
+	}
+	return 0
+}
+
+type GetBalanceStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MaxId string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Unix seconds, inclusive.
+	From int64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Unix seconds, exclusive. At most about ten years after from.
+	To         int64      `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	WalletType WalletType `protobuf:"varint,4,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	// Unspecified returns no groups.
+	GroupBy       BalanceStatsGroupBy `protobuf:"varint,5,opt,name=group_by,json=groupBy,proto3,enum=user.BalanceStatsGroupBy" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceStatsRequest) Reset() {
+	*x = GetBalanceStatsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceStatsRequest) ProtoMessage() {}
+
+func (x *GetBalanceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetBalanceStatsRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetBalanceStatsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetBalanceStatsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetBalanceStatsRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+func (x *GetBalanceStatsRequest) GetGroupBy() BalanceStatsGroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return BalanceStatsGroupBy_BALANCE_STATS_GROUP_BY_UNSPECIFIED
+}
+
+// Reversed operations and their reversals cancel out and are left out.
+type GetBalanceStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Totals of the requested range.
+	Earned int64 `protobuf:"varint,1,opt,name=earned,proto3" json:"earned,omitempty"`
+	Spent  int64 `protobuf:"varint,2,opt,name=spent,proto3" json:"spent,omitempty"`
+	// Totals of every operation of the wallet.
+	LifetimeEarned int64                `protobuf:"varint,3,opt,name=lifetime_earned,json=lifetimeEarned,proto3" json:"lifetime_earned,omitempty"`
+	LifetimeSpent  int64                `protobuf:"varint,4,opt,name=lifetime_spent,json=lifetimeSpent,proto3" json:"lifetime_spent,omitempty"`
+	Months         []*BalanceStatsMonth `protobuf:"bytes,5,rep,name=months,proto3" json:"months,omitempty"`
+	// At most 50, the ones with the most operations first.
+	Groups        []*BalanceStatsGroup `protobuf:"bytes,6,rep,name=groups,proto3" json:"groups,omitempty"`
+	Error         *Error               `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceStatsResponse) Reset() {
+	*x = GetBalanceStatsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceStatsResponse) ProtoMessage() {}
+
+func (x *GetBalanceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetBalanceStatsResponse) GetEarned() int64 {
+	if x != nil {
+		return x.Earned
+	}
+	return 0
+}
+
+func (x *GetBalanceStatsResponse) GetSpent() int64 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+func (x *GetBalanceStatsResponse) GetLifetimeEarned() int64 {
+	if x != nil {
+		return x.LifetimeEarned
+	}
+	return 0
+}
+
+func (x *GetBalanceStatsResponse) GetLifetimeSpent() int64 {
+	if x != nil {
+		return x.LifetimeSpent
+	}
+	return 0
+}
+
+func (x *GetBalanceStatsResponse) GetMonths() []*BalanceStatsMonth {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+func (x *GetBalanceStatsResponse) GetGroups() []*BalanceStatsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *GetBalanceStatsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Months are aligned to UTC; the first and last are cut to the requested range.
+type BalanceStatsMonth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MonthStart    int64                  `protobuf:"varint,1,opt,name=month_start,json=monthStart,proto3" json:"month_start,omitempty"`
+	Earned        int64                  `protobuf:"varint,2,opt,name=earned,proto3" json:"earned,omitempty"`
+	Spent         int64                  `protobuf:"varint,3,opt,name=spent,proto3" json:"spent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceStatsMonth) Reset() {
+	*x = BalanceStatsMonth{}
+	mi := &file_proto_user_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceStatsMonth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceStatsMonth) ProtoMessage() {}
+
+func (x *BalanceStatsMonth) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceStatsMonth.ProtoReflect.Descriptor instead.
+func (*BalanceStatsMonth) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BalanceStatsMonth) GetMonthStart() int64 {
+	if x != nil {
+		return x.MonthStart
+	}
+	return 0
+}
+
+func (x *BalanceStatsMonth) GetEarned() int64 {
+	if x != nil {
+		return x.Earned
+	}
+	return 0
+}
+
+func (x *BalanceStatsMonth) GetSpent() int64 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+type BalanceStatsGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The reason code or the description, depending on group_by.
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count         int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Earned        int64  `protobuf:"varint,3,opt,name=earned,proto3" json:"earned,omitempty"`
+	Spent         int64  `protobuf:"varint,4,opt,name=spent,proto3" json:"spent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceStatsGroup) Reset() {
+	*x = BalanceStatsGroup{}
+	mi := &file_proto_user_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceStatsGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceStatsGroup) ProtoMessage() {}
+
+func (x *BalanceStatsGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceStatsGroup.ProtoReflect.Descriptor instead.
+func (*BalanceStatsGroup) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BalanceStatsGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BalanceStatsGroup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BalanceStatsGroup) GetEarned() int64 {
+	if x != nil {
+		return x.Earned
+	}
+	return 0
+}
+
+func (x *BalanceStatsGroup) GetSpent() int64 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+type GetBalanceOperationsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	MaxId  string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Unspecified or unknown types are rejected.
+	Types []BalanceOperationType `protobuf:"varint,4,rep,packed,name=types,proto3,enum=user.BalanceOperationType" json:"types,omitempty"`
+	// Unix seconds, inclusive.
+	CreatedFrom int64 `protobuf:"varint,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// Unix seconds, exclusive.
+	CreatedTo           int64  `protobuf:"varint,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	AmountMin           *int32 `protobuf:"varint,7,opt,name=amount_min,json=amountMin,proto3,oneof" json:"amount_min,omitempty"`
+	AmountMax           *int32 `protobuf:"varint,8,opt,name=amount_max,json=amountMax,proto3,oneof" json:"amount_max,omitempty"`
+	DescriptionContains string `protobuf:"bytes,9,opt,name=description_contains,json=descriptionContains,proto3" json:"description_contains,omitempty"`
+	// Opaque cursor from a previous next_cursor. Cannot be combined with offset.
+	Cursor        string                   `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	SkipTotal     bool                     `protobuf:"varint,11,opt,name=skip_total,json=skipTotal,proto3" json:"skip_total,omitempty"`
+	ReasonCodes   []BalanceOperationReason `protobuf:"varint,12,rep,packed,name=reason_codes,json=reasonCodes,proto3,enum=user.BalanceOperationReason" json:"reason_codes,omitempty"`
+	WalletType    WalletType               `protobuf:"varint,13,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceOperationsRequest) Reset() {
+	*x = GetBalanceOperationsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceOperationsRequest) ProtoMessage() {}
+
+func (x *GetBalanceOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceOperationsRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetBalanceOperationsRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetBalanceOperationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsRequest) GetTypes() []BalanceOperationType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *GetBalanceOperationsRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsRequest) GetAmountMin() int32 {
+	if x != nil && x.AmountMin != nil {
+		return *x.AmountMin
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsRequest) GetAmountMax() int32 {
+	if x != nil && x.AmountMax != nil {
+		return *x.AmountMax
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsRequest) GetDescriptionContains() string {
+	if x != nil {
+		return x.DescriptionContains
+	}
+	return ""
+}
+
+func (x *GetBalanceOperationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetBalanceOperationsRequest) GetSkipTotal() bool {
+	if x != nil {
+		return x.SkipTotal
+	}
+	return false
+}
+
+func (x *GetBalanceOperationsRequest) GetReasonCodes() []BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCodes
+	}
+	return nil
+}
+
+func (x *GetBalanceOperationsRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type GetBalanceOperationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*BalanceOperation    `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceOperationsResponse) Reset() {
+	*x = GetBalanceOperationsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceOperationsResponse) ProtoMessage() {}
+
+func (x *GetBalanceOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceOperationsResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBalanceOperationsResponse) GetOperations() []*BalanceOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *GetBalanceOperationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *GetBalanceOperationsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetBalanceOperationTotalsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty aggregates over every balance.
+	MaxId string `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Unix seconds, inclusive.
+	CreatedFrom int64 `protobuf:"varint,2,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// Unix seconds, exclusive.
+	CreatedTo     int64      `protobuf:"varint,3,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	WalletType    WalletType `protobuf:"varint,4,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceOperationTotalsRequest) Reset() {
+	*x = GetBalanceOperationTotalsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceOperationTotalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceOperationTotalsRequest) ProtoMessage() {}
+
+func (x *GetBalanceOperationTotalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceOperationTotalsRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceOperationTotalsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBalanceOperationTotalsRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetBalanceOperationTotalsRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationTotalsRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *GetBalanceOperationTotalsRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type GetBalanceOperationTotalsResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Totals        []*BalanceOperationReasonTotals `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty"`
+	Error         *Error                          `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceOperationTotalsResponse) Reset() {
+	*x = GetBalanceOperationTotalsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceOperationTotalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceOperationTotalsResponse) ProtoMessage() {}
+
+func (x *GetBalanceOperationTotalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceOperationTotalsResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceOperationTotalsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetBalanceOperationTotalsResponse) GetTotals() []*BalanceOperationReasonTotals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *GetBalanceOperationTotalsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BalanceOperationReasonTotals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReasonCode    BalanceOperationReason `protobuf:"varint,1,opt,name=reason_code,json=reasonCode,proto3,enum=user.BalanceOperationReason" json:"reason_code,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Credited      int64                  `protobuf:"varint,3,opt,name=credited,proto3" json:"credited,omitempty"`
+	Debited       int64                  `protobuf:"varint,4,opt,name=debited,proto3" json:"debited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceOperationReasonTotals) Reset() {
+	*x = BalanceOperationReasonTotals{}
+	mi := &file_proto_user_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceOperationReasonTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceOperationReasonTotals) ProtoMessage() {}
+
+func (x *BalanceOperationReasonTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceOperationReasonTotals.ProtoReflect.Descriptor instead.
+func (*BalanceOperationReasonTotals) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BalanceOperationReasonTotals) GetReasonCode() BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
+}
+
+func (x *BalanceOperationReasonTotals) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BalanceOperationReasonTotals) GetCredited() int64 {
+	if x != nil {
+		return x.Credited
+	}
+	return 0
+}
+
+func (x *BalanceOperationReasonTotals) GetDebited() int64 {
+	if x != nil {
+		return x.Debited
+	}
+	return 0
+}
+
+type CreateOperationRequest struct {
+	state          protoimpl.MessageState    `protogen:"open.v1"`
+	MaxId          string                    `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Amount         int32                     `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type           BalanceOperationType      `protobuf:"varint,3,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
+	Description    string                    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	IdempotencyKey string                    `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	ReasonCode     BalanceOperationReason    `protobuf:"varint,6,opt,name=reason_code,json=reasonCode,proto3,enum=user.BalanceOperationReason" json:"reason_code,omitempty"`
+	Metadata       *BalanceOperationMetadata `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Deposits only: amount is treated as the base amount and multiplied by
+	// the user's current reputation group coefficient, rounded half up.
+	ApplyCoefficient bool `protobuf:"varint,8,opt,name=apply_coefficient,json=applyCoefficient,proto3" json:"apply_coefficient,omitempty"`
+	// Karma cannot be withdrawn.
+	WalletType WalletType `protobuf:"varint,9,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	// Adjustments only: amount is signed, and the acting admin and a
+	// justification are required. Adjustments do not count towards
+	// reputation unless counts_towards_reputation is set, which also records
+	// an adjustment reputation event of the same amount.
+	ActorMaxId              string `protobuf:"bytes,10,opt,name=actor_max_id,json=actorMaxId,proto3" json:"actor_max_id,omitempty"`
+	Justification           string `protobuf:"bytes,11,opt,name=justification,proto3" json:"justification,omitempty"`
+	CountsTowardsReputation bool   `protobuf:"varint,12,opt,name=counts_towards_reputation,json=countsTowardsReputation,proto3" json:"counts_towards_reputation,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *CreateOperationRequest) Reset() {
+	*x = CreateOperationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOperationRequest) ProtoMessage() {}
+
+func (x *CreateOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOperationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateOperationRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *CreateOperationRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateOperationRequest) GetType() BalanceOperationType {
+	if x != nil {
+		return x.Type
+	}
+	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *CreateOperationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateOperationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOperationRequest) GetReasonCode() BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
+}
+
+func (x *CreateOperationRequest) GetMetadata() *BalanceOperationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateOperationRequest) GetApplyCoefficient() bool {
+	if x != nil {
+		return x.ApplyCoefficient
+	}
+	return false
+}
+
+func (x *CreateOperationRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+func (x *CreateOperationRequest) GetActorMaxId() string {
+	if x != nil {
+		return x.ActorMaxId
+	}
+	return ""
+}
+
+func (x *CreateOperationRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *CreateOperationRequest) GetCountsTowardsReputation() bool {
+	if x != nil {
+		return x.CountsTowardsReputation
+	}
+	return false
+}
+
+type CreateOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     *BalanceOperation      `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOperationResponse) Reset() {
+	*x = CreateOperationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOperationResponse) ProtoMessage() {}
+
+func (x *CreateOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOperationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateOperationResponse) GetOperation() *BalanceOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *CreateOperationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CreateOperationsBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 1000 items.
+	Items          []*BatchOperationItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Mode           BatchMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=user.BatchMode" json:"mode,omitempty"`
+	IdempotencyKey string                `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateOperationsBatchRequest) Reset() {
+	*x = CreateOperationsBatchRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOperationsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOperationsBatchRequest) ProtoMessage() {}
+
+func (x *CreateOperationsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOperationsBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateOperationsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateOperationsBatchRequest) GetItems() []*BatchOperationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOperationsBatchRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *CreateOperationsBatchRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateOperationsBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per item, in request order.
+	Results []*BatchOperationResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Applied int32                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	Failed  int32                   `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Set when the batch was rejected as a whole, e.g. an all-or-nothing
+	// batch with a failed item. Item errors are in results.
+	Error         *Error `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOperationsBatchResponse) Reset() {
+	*x = CreateOperationsBatchResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOperationsBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOperationsBatchResponse) ProtoMessage() {}
+
+func (x *CreateOperationsBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOperationsBatchResponse.ProtoReflect.Descriptor instead.
+func (*CreateOperationsBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateOperationsBatchResponse) GetResults() []*BatchOperationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *CreateOperationsBatchResponse) GetApplied() int32 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+func (x *CreateOperationsBatchResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *CreateOperationsBatchResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BatchOperationItem struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	MaxId  string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Amount int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Deposit or withdraw.
+	Type          BalanceOperationType      `protobuf:"varint,3,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
+	Description   string                    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ReasonCode    BalanceOperationReason    `protobuf:"varint,5,opt,name=reason_code,json=reasonCode,proto3,enum=user.BalanceOperationReason" json:"reason_code,omitempty"`
+	Metadata      *BalanceOperationMetadata `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	WalletType    WalletType                `protobuf:"varint,7,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOperationItem) Reset() {
+	*x = BatchOperationItem{}
+	mi := &file_proto_user_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOperationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOperationItem) ProtoMessage() {}
+
+func (x *BatchOperationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOperationItem.ProtoReflect.Descriptor instead.
+func (*BatchOperationItem) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BatchOperationItem) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *BatchOperationItem) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BatchOperationItem) GetType() BalanceOperationType {
+	if x != nil {
+		return x.Type
+	}
+	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *BatchOperationItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BatchOperationItem) GetReasonCode() BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
+}
+
+func (x *BatchOperationItem) GetMetadata() *BalanceOperationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BatchOperationItem) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type BatchOperationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Operation     *BalanceOperation      `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOperationResult) Reset() {
+	*x = BatchOperationResult{}
+	mi := &file_proto_user_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOperationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOperationResult) ProtoMessage() {}
+
+func (x *BatchOperationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOperationResult.ProtoReflect.Descriptor instead.
+func (*BatchOperationResult) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BatchOperationResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchOperationResult) GetOperation() *BalanceOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *BatchOperationResult) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type TransferPointsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromMaxId      string                 `protobuf:"bytes,1,opt,name=from_max_id,json=fromMaxId,proto3" json:"from_max_id,omitempty"`
+	ToMaxId        string                 `protobuf:"bytes,2,opt,name=to_max_id,json=toMaxId,proto3" json:"to_max_id,omitempty"`
+	Amount         int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferPointsRequest) Reset() {
+	*x = TransferPointsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferPointsRequest) ProtoMessage() {}
+
+func (x *TransferPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferPointsRequest.ProtoReflect.Descriptor instead.
+func (*TransferPointsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TransferPointsRequest) GetFromMaxId() string {
+	if x != nil {
+		return x.FromMaxId
+	}
+	return ""
+}
+
+func (x *TransferPointsRequest) GetToMaxId() string {
+	if x != nil {
+		return x.ToMaxId
+	}
+	return ""
+}
+
+func (x *TransferPointsRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferPointsRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TransferPointsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type TransferPointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	FromOperation *BalanceOperation      `protobuf:"bytes,2,opt,name=from_operation,json=fromOperation,proto3" json:"from_operation,omitempty"`
+	ToOperation   *BalanceOperation      `protobuf:"bytes,3,opt,name=to_operation,json=toOperation,proto3" json:"to_operation,omitempty"`
+	FromBalance   int32                  `protobuf:"varint,4,opt,name=from_balance,json=fromBalance,proto3" json:"from_balance,omitempty"`
+	ToBalance     int32                  `protobuf:"varint,5,opt,name=to_balance,json=toBalance,proto3" json:"to_balance,omitempty"`
+	Error         *Error                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferPointsResponse) Reset() {
+	*x = TransferPointsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferPointsResponse) ProtoMessage() {}
+
+func (x *TransferPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferPointsResponse.ProtoReflect.Descriptor instead.
+func (*TransferPointsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TransferPointsResponse) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *TransferPointsResponse) GetFromOperation() *BalanceOperation {
+	if x != nil {
+		return x.FromOperation
+	}
+	return nil
+}
+
+func (x *TransferPointsResponse) GetToOperation() *BalanceOperation {
+	if x != nil {
+		return x.ToOperation
+	}
+	return nil
+}
+
+func (x *TransferPointsResponse) GetFromBalance() int32 {
+	if x != nil {
+		return x.FromBalance
+	}
+	return 0
+}
+
+func (x *TransferPointsResponse) GetToBalance() int32 {
+	if x != nil {
+		return x.ToBalance
+	}
+	return 0
+}
+
+func (x *TransferPointsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GetLeaderboardRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Period            LeaderboardPeriod      `protobuf:"varint,1,opt,name=period,proto3,enum=user.LeaderboardPeriod" json:"period,omitempty"`
+	Metric            LeaderboardMetric      `protobuf:"varint,2,opt,name=metric,proto3,enum=user.LeaderboardMetric" json:"metric,omitempty"`
+	Geolocation       string                 `protobuf:"bytes,3,opt,name=geolocation,proto3" json:"geolocation,omitempty"`
+	ReputationGroupId int32                  `protobuf:"varint,4,opt,name=reputation_group_id,json=reputationGroupId,proto3" json:"reputation_group_id,omitempty"`
+	// Whose rank to return in caller, whether or not it is on the page.
+	CallerMaxId   string `protobuf:"bytes,5,opt,name=caller_max_id,json=callerMaxId,proto3" json:"caller_max_id,omitempty"`
+	Limit         int32  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetLeaderboardRequest) GetPeriod() LeaderboardPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return LeaderboardPeriod_LEADERBOARD_PERIOD_UNSPECIFIED
+}
+
+func (x *GetLeaderboardRequest) GetMetric() LeaderboardMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED
+}
+
+func (x *GetLeaderboardRequest) GetGeolocation() string {
+	if x != nil {
+		return x.Geolocation
+	}
+	return ""
+}
+
+func (x *GetLeaderboardRequest) GetReputationGroupId() int32 {
+	if x != nil {
+		return x.ReputationGroupId
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetCallerMaxId() string {
+	if x != nil {
+		return x.CallerMaxId
+	}
+	return ""
+}
+
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetLeaderboardResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total   int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Caller  *LeaderboardEntry      `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	// Unix seconds of the last refresh of the underlying aggregates.
+	RefreshedAt   int64  `protobuf:"varint,4,opt,name=refreshed_at,json=refreshedAt,proto3" json:"refreshed_at,omitempty"`
+	Error         *Error `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetLeaderboardResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetLeaderboardResponse) GetCaller() *LeaderboardEntry {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+func (x *GetLeaderboardResponse) GetRefreshedAt() int64 {
+	if x != nil {
+		return x.RefreshedAt
+	}
+	return 0
+}
+
+func (x *GetLeaderboardResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	MaxId         string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Score         int32                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_proto_user_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ReconcileBalancesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When set, every mismatch gets an adjustment operation that brings the
+	// operation log in line with the stored balance, and the non-negative
+	// balance constraint is validated once no balance is below zero.
+	Fix           bool `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileBalancesRequest) Reset() {
+	*x = ReconcileBalancesRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBalancesRequest) ProtoMessage() {}
+
+func (x *ReconcileBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileBalancesRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReconcileBalancesRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+type ReconcileBalancesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Scanned    int32                  `protobuf:"varint,1,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Adjusted   int32                  `protobuf:"varint,2,opt,name=adjusted,proto3" json:"adjusted,omitempty"`
+	Mismatches []*BalanceMismatch     `protobuf:"bytes,3,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	Error      *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Balances still below zero after a fixing run.
+	NegativeBalanceIds []string `protobuf:"bytes,5,rep,name=negative_balance_ids,json=negativeBalanceIds,proto3" json:"negative_balance_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReconcileBalancesResponse) Reset() {
+	*x = ReconcileBalancesResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBalancesResponse) ProtoMessage() {}
+
+func (x *ReconcileBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileBalancesResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReconcileBalancesResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *ReconcileBalancesResponse) GetAdjusted() int32 {
+	if x != nil {
+		return x.Adjusted
+	}
+	return 0
+}
+
+func (x *ReconcileBalancesResponse) GetMismatches() []*BalanceMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+func (x *ReconcileBalancesResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *ReconcileBalancesResponse) GetNegativeBalanceIds() []string {
+	if x != nil {
+		return x.NegativeBalanceIds
+	}
+	return nil
+}
+
+type BalanceMismatch struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId             string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	MaxId                 string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Expected              int32                  `protobuf:"varint,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual                int32                  `protobuf:"varint,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	Difference            int32                  `protobuf:"varint,5,opt,name=difference,proto3" json:"difference,omitempty"`
+	AdjustmentOperationId string                 `protobuf:"bytes,6,opt,name=adjustment_operation_id,json=adjustmentOperationId,proto3" json:"adjustment_operation_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *BalanceMismatch) Reset() {
+	*x = BalanceMismatch{}
+	mi := &file_proto_user_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceMismatch) ProtoMessage() {}
+
+func (x *BalanceMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceMismatch.ProtoReflect.Descriptor instead.
+func (*BalanceMismatch) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BalanceMismatch) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *BalanceMismatch) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *BalanceMismatch) GetExpected() int32 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *BalanceMismatch) GetActual() int32 {
+	if x != nil {
+		return x.Actual
+	}
+	return 0
+}
+
+func (x *BalanceMismatch) GetDifference() int32 {
+	if x != nil {
+		return x.Difference
+	}
+	return 0
+}
+
+func (x *BalanceMismatch) GetAdjustmentOperationId() string {
+	if x != nil {
+		return x.AdjustmentOperationId
+	}
+	return ""
+}
+
+type CreateHoldRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxId          string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Amount         int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	TtlSeconds     int32                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateHoldRequest) Reset() {
+	*x = CreateHoldRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHoldRequest) ProtoMessage() {}
+
+func (x *CreateHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHoldRequest.ProtoReflect.Descriptor instead.
+func (*CreateHoldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateHoldRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *CreateHoldRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateHoldRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateHoldRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *CreateHoldRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHoldResponse) Reset() {
+	*x = CreateHoldResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHoldResponse) ProtoMessage() {}
+
+func (x *CreateHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHoldResponse.ProtoReflect.Descriptor instead.
+func (*CreateHoldResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *CreateHoldResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CaptureHoldRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	HoldId string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	// Zero captures the whole hold.
+	Amount         int32  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CaptureHoldRequest) Reset() {
+	*x = CaptureHoldRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldRequest) ProtoMessage() {}
+
+func (x *CaptureHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldRequest.ProtoReflect.Descriptor instead.
+func (*CaptureHoldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CaptureHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *CaptureHoldRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CaptureHoldRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CaptureHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	Operation     *BalanceOperation      `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldResponse) Reset() {
+	*x = CaptureHoldResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldResponse) ProtoMessage() {}
+
+func (x *CaptureHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldResponse.ProtoReflect.Descriptor instead.
+func (*CaptureHoldResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CaptureHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *CaptureHoldResponse) GetOperation() *BalanceOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *CaptureHoldResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ReleaseHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldRequest) Reset() {
+	*x = ReleaseHoldRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReleaseHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+type ReleaseHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldResponse) Reset() {
+	*x = ReleaseHoldResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldResponse) ProtoMessage() {}
+
+func (x *ReleaseHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReleaseHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *ReleaseHoldResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Exactly one of run_at and cron_expression must be set.
+type ScheduleOperationRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	MaxId  string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Amount int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Deposit or withdraw.
+	Type        BalanceOperationType      `protobuf:"varint,3,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
+	Description string                    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ReasonCode  BalanceOperationReason    `protobuf:"varint,5,opt,name=reason_code,json=reasonCode,proto3,enum=user.BalanceOperationReason" json:"reason_code,omitempty"`
+	Metadata    *BalanceOperationMetadata `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	WalletType  WalletType                `protobuf:"varint,7,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	// Unix seconds, in the future. Runs once.
+	RunAt int64 `protobuf:"varint,8,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	// Standard five-field cron expression or a descriptor such as @monthly,
+	// evaluated in UTC. Runs until paused or cancelled.
+	CronExpression string `protobuf:"bytes,9,opt,name=cron_expression,json=cronExpression,proto3" json:"cron_expression,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScheduleOperationRequest) Reset() {
+	*x = ScheduleOperationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleOperationRequest) ProtoMessage() {}
+
+func (x *ScheduleOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleOperationRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ScheduleOperationRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *ScheduleOperationRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ScheduleOperationRequest) GetType() BalanceOperationType {
+	if x != nil {
+		return x.Type
+	}
+	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduleOperationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScheduleOperationRequest) GetReasonCode() BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
+}
+
+func (x *ScheduleOperationRequest) GetMetadata() *BalanceOperationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ScheduleOperationRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduleOperationRequest) GetRunAt() int64 {
+	if x != nil {
+		return x.RunAt
+	}
+	return 0
+}
+
+func (x *ScheduleOperationRequest) GetCronExpression() string {
+	if x != nil {
+		return x.CronExpression
+	}
+	return ""
+}
+
+func (x *ScheduleOperationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ScheduleOperationResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledOperation *ScheduledOperation    `protobuf:"bytes,1,opt,name=scheduled_operation,json=scheduledOperation,proto3" json:"scheduled_operation,omitempty"`
+	Error              *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ScheduleOperationResponse) Reset() {
+	*x = ScheduleOperationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleOperationResponse) ProtoMessage() {}
+
+func (x *ScheduleOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleOperationResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ScheduleOperationResponse) GetScheduledOperation() *ScheduledOperation {
+	if x != nil {
+		return x.ScheduledOperation
+	}
+	return nil
+}
+
+func (x *ScheduleOperationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ListScheduledOperationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty lists scheduled operations of every user.
+	MaxId         string                     `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Statuses      []ScheduledOperationStatus `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=user.ScheduledOperationStatus" json:"statuses,omitempty"`
+	Limit         int32                      `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                      `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledOperationsRequest) Reset() {
+	*x = ListScheduledOperationsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledOperationsRequest) ProtoMessage() {}
+
+func (x *ListScheduledOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListScheduledOperationsRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *ListScheduledOperationsRequest) GetStatuses() []ScheduledOperationStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListScheduledOperationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListScheduledOperationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListScheduledOperationsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledOperations []*ScheduledOperation  `protobuf:"bytes,1,rep,name=scheduled_operations,json=scheduledOperations,proto3" json:"scheduled_operations,omitempty"`
+	Total               int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error               *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListScheduledOperationsResponse) Reset() {
+	*x = ListScheduledOperationsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledOperationsResponse) ProtoMessage() {}
+
+func (x *ListScheduledOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListScheduledOperationsResponse) GetScheduledOperations() []*ScheduledOperation {
+	if x != nil {
+		return x.ScheduledOperations
+	}
+	return nil
+}
+
+func (x *ListScheduledOperationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListScheduledOperationsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type PauseScheduledOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduledOperationRequest) Reset() {
+	*x = PauseScheduledOperationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduledOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduledOperationRequest) ProtoMessage() {}
+
+func (x *PauseScheduledOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduledOperationRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduledOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PauseScheduledOperationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PauseScheduledOperationResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledOperation *ScheduledOperation    `protobuf:"bytes,1,opt,name=scheduled_operation,json=scheduledOperation,proto3" json:"scheduled_operation,omitempty"`
+	Error              *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PauseScheduledOperationResponse) Reset() {
+	*x = PauseScheduledOperationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduledOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduledOperationResponse) ProtoMessage() {}
+
+func (x *PauseScheduledOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduledOperationResponse.ProtoReflect.Descriptor instead.
+func (*PauseScheduledOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PauseScheduledOperationResponse) GetScheduledOperation() *ScheduledOperation {
+	if x != nil {
+		return x.ScheduledOperation
+	}
+	return nil
+}
+
+func (x *PauseScheduledOperationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Recurring operations skip the occurrences that fell into the pause.
+type ResumeScheduledOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeScheduledOperationRequest) Reset() {
+	*x = ResumeScheduledOperationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduledOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduledOperationRequest) ProtoMessage() {}
+
+func (x *ResumeScheduledOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduledOperationRequest.ProtoReflect.Descriptor instead.
+func (*ResumeScheduledOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ResumeScheduledOperationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResumeScheduledOperationResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledOperation *ScheduledOperation    `protobuf:"bytes,1,opt,name=scheduled_operation,json=scheduledOperation,proto3" json:"scheduled_operation,omitempty"`
+	Error              *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ResumeScheduledOperationResponse) Reset() {
+	*x = ResumeScheduledOperationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduledOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduledOperationResponse) ProtoMessage() {}
+
+func (x *ResumeScheduledOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduledOperationResponse.ProtoReflect.Descriptor instead.
+func (*ResumeScheduledOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ResumeScheduledOperationResponse) GetScheduledOperation() *ScheduledOperation {
+	if x != nil {
+		return x.ScheduledOperation
+	}
+	return nil
+}
+
+func (x *ResumeScheduledOperationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CancelScheduledOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledOperationRequest) Reset() {
+	*x = CancelScheduledOperationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledOperationRequest) ProtoMessage() {}
+
+func (x *CancelScheduledOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledOperationRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CancelScheduledOperationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelScheduledOperationResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledOperation *ScheduledOperation    `protobuf:"bytes,1,opt,name=scheduled_operation,json=scheduledOperation,proto3" json:"scheduled_operation,omitempty"`
+	Error              *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CancelScheduledOperationResponse) Reset() {
+	*x = CancelScheduledOperationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledOperationResponse) ProtoMessage() {}
+
+func (x *CancelScheduledOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledOperationResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CancelScheduledOperationResponse) GetScheduledOperation() *ScheduledOperation {
+	if x != nil {
+		return x.ScheduledOperation
+	}
+	return nil
+}
+
+func (x *CancelScheduledOperationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ScheduledOperation struct {
+	state          protoimpl.MessageState    `protogen:"open.v1"`
+	Id             string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaxId          string                    `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	WalletType     WalletType                `protobuf:"varint,3,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	Amount         int32                     `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type           BalanceOperationType      `protobuf:"varint,5,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
+	Description    string                    `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	ReasonCode     BalanceOperationReason    `protobuf:"varint,7,opt,name=reason_code,json=reasonCode,proto3,enum=user.BalanceOperationReason" json:"reason_code,omitempty"`
+	Metadata       *BalanceOperationMetadata `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CronExpression string                    `protobuf:"bytes,9,opt,name=cron_expression,json=cronExpression,proto3" json:"cron_expression,omitempty"`
+	Status         ScheduledOperationStatus  `protobuf:"varint,10,opt,name=status,proto3,enum=user.ScheduledOperationStatus" json:"status,omitempty"`
+	// Unix seconds, zero when nothing is left to run.
+	NextRunAt int64 `protobuf:"varint,11,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt int64 `protobuf:"varint,12,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	// Outcome of the latest run: the operation it created, or why it was
+	// rejected.
+	LastOperationId string `protobuf:"bytes,13,opt,name=last_operation_id,json=lastOperationId,proto3" json:"last_operation_id,omitempty"`
+	LastError       string `protobuf:"bytes,14,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt       int64  `protobuf:"varint,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScheduledOperation) Reset() {
+	*x = ScheduledOperation{}
+	mi := &file_proto_user_user_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledOperation) ProtoMessage() {}
+
+func (x *ScheduledOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledOperation.ProtoReflect.Descriptor instead.
+func (*ScheduledOperation) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ScheduledOperation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduledOperation) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ScheduledOperation) GetType() BalanceOperationType {
+	if x != nil {
+		return x.Type
+	}
+	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduledOperation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetReasonCode() BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
+}
+
+func (x *ScheduledOperation) GetMetadata() *BalanceOperationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ScheduledOperation) GetCronExpression() string {
+	if x != nil {
+		return x.CronExpression
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetStatus() ScheduledOperationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ScheduledOperationStatus_SCHEDULED_OPERATION_STATUS_UNSPECIFIED
+}
+
+func (x *ScheduledOperation) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+func (x *ScheduledOperation) GetLastRunAt() int64 {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return 0
+}
+
+func (x *ScheduledOperation) GetLastOperationId() string {
+	if x != nil {
+		return x.LastOperationId
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ScheduledOperation) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type Hold struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BalanceId      string                 `protobuf:"bytes,2,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Amount         int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	CapturedAmount int32                  `protobuf:"varint,4,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	Status         HoldStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=user.HoldStatus" json:"status,omitempty"`
+	Description    string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	OperationId    string                 `protobuf:"bytes,7,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	mi := &file_proto_user_user_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Hold) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Hold) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *Hold) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Hold) GetCapturedAmount() int32 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *Hold) GetStatus() HoldStatus {
+	if x != nil {
+		return x.Status
+	}
+	return HoldStatus_HOLD_STATUS_UNSPECIFIED
+}
+
+func (x *Hold) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Hold) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *Hold) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Hold) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetTrialBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrialBalanceRequest) Reset() {
+	*x = GetTrialBalanceRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrialBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrialBalanceRequest) ProtoMessage() {}
+
+func (x *GetTrialBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrialBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetTrialBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{51}
+}
+
+type GetTrialBalanceResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Lines       []*TrialBalanceLine    `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	TotalDebit  int64                  `protobuf:"varint,2,opt,name=total_debit,json=totalDebit,proto3" json:"total_debit,omitempty"`
+	TotalCredit int64                  `protobuf:"varint,3,opt,name=total_credit,json=totalCredit,proto3" json:"total_credit,omitempty"`
+	// False when debits and credits differ or any wallet mismatches.
+	Balanced         bool                    `protobuf:"varint,4,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	Error            *Error                  `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	WalletMismatches []*LedgerWalletMismatch `protobuf:"bytes,6,rep,name=wallet_mismatches,json=walletMismatches,proto3" json:"wallet_mismatches,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTrialBalanceResponse) Reset() {
+	*x = GetTrialBalanceResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrialBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrialBalanceResponse) ProtoMessage() {}
+
+func (x *GetTrialBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrialBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetTrialBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetTrialBalanceResponse) GetLines() []*TrialBalanceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *GetTrialBalanceResponse) GetTotalDebit() int64 {
+	if x != nil {
+		return x.TotalDebit
+	}
+	return 0
+}
+
+func (x *GetTrialBalanceResponse) GetTotalCredit() int64 {
+	if x != nil {
+		return x.TotalCredit
+	}
+	return 0
+}
+
+func (x *GetTrialBalanceResponse) GetBalanced() bool {
+	if x != nil {
+		return x.Balanced
+	}
+	return false
+}
+
+func (x *GetTrialBalanceResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *GetTrialBalanceResponse) GetWalletMismatches() []*LedgerWalletMismatch {
+	if x != nil {
+		return x.WalletMismatches
+	}
+	return nil
+}
+
+// A wallet account whose net ledger balance, credits minus debits, differs
+// from the stored balance.
+type LedgerWalletMismatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	BalanceId     string                 `protobuf:"bytes,2,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	LedgerBalance int64                  `protobuf:"varint,3,opt,name=ledger_balance,json=ledgerBalance,proto3" json:"ledger_balance,omitempty"`
+	Balance       int64                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerWalletMismatch) Reset() {
+	*x = LedgerWalletMismatch{}
+	mi := &file_proto_user_user_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerWalletMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerWalletMismatch) ProtoMessage() {}
+
+func (x *LedgerWalletMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerWalletMismatch.ProtoReflect.Descriptor instead.
+func (*LedgerWalletMismatch) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *LedgerWalletMismatch) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *LedgerWalletMismatch) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *LedgerWalletMismatch) GetLedgerBalance() int64 {
+	if x != nil {
+		return x.LedgerBalance
+	}
+	return 0
+}
+
+func (x *LedgerWalletMismatch) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type TrialBalanceLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Kind          LedgerAccountKind      `protobuf:"varint,2,opt,name=kind,proto3,enum=user.LedgerAccountKind" json:"kind,omitempty"`
+	Debit         int64                  `protobuf:"varint,3,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit        int64                  `protobuf:"varint,4,opt,name=credit,proto3" json:"credit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialBalanceLine) Reset() {
+	*x = TrialBalanceLine{}
+	mi := &file_proto_user_user_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialBalanceLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialBalanceLine) ProtoMessage() {}
+
+func (x *TrialBalanceLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialBalanceLine.ProtoReflect.Descriptor instead.
+func (*TrialBalanceLine) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TrialBalanceLine) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetKind() LedgerAccountKind {
+	if x != nil {
+		return x.Kind
+	}
+	return LedgerAccountKind_LEDGER_ACCOUNT_KIND_UNSPECIFIED
+}
+
+func (x *TrialBalanceLine) GetDebit() int64 {
+	if x != nil {
+		return x.Debit
+	}
+	return 0
+}
+
+func (x *TrialBalanceLine) GetCredit() int64 {
+	if x != nil {
+		return x.Credit
+	}
+	return 0
+}
+
+type ReverseOperationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OperationId    string                 `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReverseOperationRequest) Reset() {
+	*x = ReverseOperationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseOperationRequest) ProtoMessage() {}
+
+func (x *ReverseOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseOperationRequest.ProtoReflect.Descriptor instead.
+func (*ReverseOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ReverseOperationRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *ReverseOperationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReverseOperationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ReverseOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     *BalanceOperation      `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseOperationResponse) Reset() {
+	*x = ReverseOperationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseOperationResponse) ProtoMessage() {}
+
+func (x *ReverseOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseOperationResponse.ProtoReflect.Descriptor instead.
+func (*ReverseOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ReverseOperationResponse) GetOperation() *BalanceOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *ReverseOperationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BalanceOperation struct {
+	state                 protoimpl.MessageState    `protogen:"open.v1"`
+	Id                    string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BalanceId             string                    `protobuf:"bytes,2,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Amount                int32                     `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type                  BalanceOperationType      `protobuf:"varint,4,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
+	Description           string                    `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt             int32                     `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TransferId            string                    `protobuf:"bytes,7,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	ReversesOperationId   string                    `protobuf:"bytes,8,opt,name=reverses_operation_id,json=reversesOperationId,proto3" json:"reverses_operation_id,omitempty"`
+	ReversedByOperationId string                    `protobuf:"bytes,9,opt,name=reversed_by_operation_id,json=reversedByOperationId,proto3" json:"reversed_by_operation_id,omitempty"`
+	ReasonCode            BalanceOperationReason    `protobuf:"varint,10,opt,name=reason_code,json=reasonCode,proto3,enum=user.BalanceOperationReason" json:"reason_code,omitempty"`
+	Metadata              *BalanceOperationMetadata `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Set for deposits made with apply_coefficient, zero otherwise.
+	BaseAmount  int32      `protobuf:"varint,12,opt,name=base_amount,json=baseAmount,proto3" json:"base_amount,omitempty"`
+	Coefficient float64    `protobuf:"fixed64,13,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
+	WalletType  WalletType `protobuf:"varint,14,opt,name=wallet_type,json=walletType,proto3,enum=user.WalletType" json:"wallet_type,omitempty"`
+	// Set for manual adjustments, empty otherwise.
+	ActorMaxId    string `protobuf:"bytes,15,opt,name=actor_max_id,json=actorMaxId,proto3" json:"actor_max_id,omitempty"`
+	Justification string `protobuf:"bytes,16,opt,name=justification,proto3" json:"justification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceOperation) Reset() {
+	*x = BalanceOperation{}
+	mi := &file_proto_user_user_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceOperation) ProtoMessage() {}
+
+func (x *BalanceOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceOperation.ProtoReflect.Descriptor instead.
+func (*BalanceOperation) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *BalanceOperation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BalanceOperation) GetType() BalanceOperationType {
+	if x != nil {
+		return x.Type
+	}
+	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *BalanceOperation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetCreatedAt() int32 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *BalanceOperation) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetReversesOperationId() string {
+	if x != nil {
+		return x.ReversesOperationId
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetReversedByOperationId() string {
+	if x != nil {
+		return x.ReversedByOperationId
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetReasonCode() BalanceOperationReason {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return BalanceOperationReason_BALANCE_OPERATION_REASON_UNSPECIFIED
+}
+
+func (x *BalanceOperation) GetMetadata() *BalanceOperationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BalanceOperation) GetBaseAmount() int32 {
+	if x != nil {
+		return x.BaseAmount
+	}
+	return 0
+}
+
+func (x *BalanceOperation) GetCoefficient() float64 {
+	if x != nil {
+		return x.Coefficient
+	}
+	return 0
+}
+
+func (x *BalanceOperation) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+func (x *BalanceOperation) GetActorMaxId() string {
+	if x != nil {
+		return x.ActorMaxId
+	}
+	return ""
+}
+
+func (x *BalanceOperation) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+type BalanceOperationMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceService string                 `protobuf:"bytes,1,opt,name=source_service,json=sourceService,proto3" json:"source_service,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Extra         map[string]string      `protobuf:"bytes,4,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceOperationMetadata) Reset() {
+	*x = BalanceOperationMetadata{}
+	mi := &file_proto_user_user_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceOperationMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceOperationMetadata) ProtoMessage() {}
+
+func (x *BalanceOperationMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceOperationMetadata.ProtoReflect.Descriptor instead.
+func (*BalanceOperationMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *BalanceOperationMetadata) GetSourceService() string {
+	if x != nil {
+		return x.SourceService
+	}
+	return ""
+}
+
+func (x *BalanceOperationMetadata) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *BalanceOperationMetadata) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *BalanceOperationMetadata) GetExtra() map[string]string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type User struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaxId           string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Geolocation     string                 `protobuf:"bytes,4,opt,name=geolocation,proto3" json:"geolocation,omitempty"`
+	Age             int32                  `protobuf:"varint,5,opt,name=age,proto3" json:"age,omitempty"`
+	Sex             Sex                    `protobuf:"varint,6,opt,name=sex,proto3,enum=user.Sex" json:"sex,omitempty"`
+	About           string                 `protobuf:"bytes,7,opt,name=about,proto3" json:"about,omitempty"`
+	Role            Role                   `protobuf:"varint,8,opt,name=role,proto3,enum=user.Role" json:"role,omitempty"`
+	Status          Status                 `protobuf:"varint,9,opt,name=status,proto3,enum=user.Status" json:"status,omitempty"`
+	ReputationGroup *ReputationGroup       `protobuf:"bytes,10,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_proto_user_user_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *User) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetGeolocation() string {
+	if x != nil {
+		return x.Geolocation
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *User) GetSex() Sex {
+	if x != nil {
+		return x.Sex
+	}
+	return Sex_SEX_UNSPECIFIED
+}
+
+func (x *User) GetAbout() string {
+	if x != nil {
+		return x.About
+	}
+	return ""
+}
+
+func (x *User) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetReputationGroup() *ReputationGroup {
+	if x != nil {
+		return x.ReputationGroup
+	}
+	return nil
+}
+
+type ReputationGroup struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Coefficient    float64                `protobuf:"fixed64,4,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
+	ReputationNeed int32                  `protobuf:"varint,5,opt,name=reputation_need,json=reputationNeed,proto3" json:"reputation_need,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReputationGroup) Reset() {
+	*x = ReputationGroup{}
+	mi := &file_proto_user_user_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReputationGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReputationGroup) ProtoMessage() {}
+
+func (x *ReputationGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReputationGroup.ProtoReflect.Descriptor instead.
+func (*ReputationGroup) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ReputationGroup) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReputationGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReputationGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReputationGroup) GetCoefficient() float64 {
+	if x != nil {
+		return x.Coefficient
+	}
+	return 0
+}
+
+func (x *ReputationGroup) GetReputationNeed() int32 {
+	if x != nil {
+		return x.ReputationNeed
+	}
+	return 0
+}
+
+type GetReputationGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationGroupsRequest) Reset() {
+	*x = GetReputationGroupsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationGroupsRequest) ProtoMessage() {}
+
+func (x *GetReputationGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationGroupsRequest.ProtoReflect.Descriptor instead.
+func (*GetReputationGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{61}
+}
+
+type GetReputationGroupsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReputationGroups []*ReputationGroup     `protobuf:"bytes,1,rep,name=reputation_groups,json=reputationGroups,proto3" json:"reputation_groups,omitempty"`
+	Error            *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetReputationGroupsResponse) Reset() {
+	*x = GetReputationGroupsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationGroupsResponse) ProtoMessage() {}
+
+func (x *GetReputationGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationGroupsResponse.ProtoReflect.Descriptor instead.
+func (*GetReputationGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetReputationGroupsResponse) GetReputationGroups() []*ReputationGroup {
+	if x != nil {
+		return x.ReputationGroups
+	}
+	return nil
+}
+
+func (x *GetReputationGroupsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GetReputationGroupByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationGroupByIDRequest) Reset() {
+	*x = GetReputationGroupByIDRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationGroupByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationGroupByIDRequest) ProtoMessage() {}
+
+func (x *GetReputationGroupByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationGroupByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetReputationGroupByIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetReputationGroupByIDRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetReputationGroupByIDResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReputationGroup *ReputationGroup       `protobuf:"bytes,1,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
+	Error           *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetReputationGroupByIDResponse) Reset() {
+	*x = GetReputationGroupByIDResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationGroupByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationGroupByIDResponse) ProtoMessage() {}
+
+func (x *GetReputationGroupByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationGroupByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetReputationGroupByIDResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetReputationGroupByIDResponse) GetReputationGroup() *ReputationGroup {
+	if x != nil {
+		return x.ReputationGroup
+	}
+	return nil
+}
+
+func (x *GetReputationGroupByIDResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Moves the user's reputation score and with it their reputation group.
+// Karma deposits and flagged adjustments add events of their own, and
+// reversing them takes those events back.
+type AddReputationEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MaxId string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Positive for task_completed and event_participation, negative for
+	// moderation_penalty, either for the rest. Must not be zero.
+	Amount      int32                 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason      ReputationEventReason `protobuf:"varint,3,opt,name=reason,proto3,enum=user.ReputationEventReason" json:"reason,omitempty"`
+	Description string                `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// What the event is for, such as a task or moderation case id. A user
+	// gets at most one event per reason and reference.
+	ReferenceId    string `protobuf:"bytes,5,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddReputationEventRequest) Reset() {
+	*x = AddReputationEventRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReputationEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReputationEventRequest) ProtoMessage() {}
+
+func (x *AddReputationEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReputationEventRequest.ProtoReflect.Descriptor instead.
+func (*AddReputationEventRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *AddReputationEventRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *AddReputationEventRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AddReputationEventRequest) GetReason() ReputationEventReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReputationEventReason_REPUTATION_EVENT_REASON_UNSPECIFIED
+}
+
+func (x *AddReputationEventRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AddReputationEventRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *AddReputationEventRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type AddReputationEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *ReputationEvent       `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Reputation    *Reputation            `protobuf:"bytes,2,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReputationEventResponse) Reset() {
+	*x = AddReputationEventResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReputationEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReputationEventResponse) ProtoMessage() {}
+
+func (x *AddReputationEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReputationEventResponse.ProtoReflect.Descriptor instead.
+func (*AddReputationEventResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AddReputationEventResponse) GetEvent() *ReputationEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *AddReputationEventResponse) GetReputation() *Reputation {
+	if x != nil {
+		return x.Reputation
+	}
+	return nil
+}
+
+func (x *AddReputationEventResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GetReputationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MaxId string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Events page, newest first. Defaults to 20, at most 100.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationRequest) Reset() {
+	*x = GetReputationRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationRequest) ProtoMessage() {}
+
+func (x *GetReputationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationRequest.ProtoReflect.Descriptor instead.
+func (*GetReputationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetReputationRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetReputationRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReputationRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetReputationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reputation    *Reputation            `protobuf:"bytes,1,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	Events        []*ReputationEvent     `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	TotalEvents   int32                  `protobuf:"varint,3,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationResponse) Reset() {
+	*x = GetReputationResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationResponse) ProtoMessage() {}
+
+func (x *GetReputationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationResponse.ProtoReflect.Descriptor instead.
+func (*GetReputationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetReputationResponse) GetReputation() *Reputation {
+	if x != nil {
+		return x.Reputation
+	}
+	return nil
+}
+
+func (x *GetReputationResponse) GetEvents() []*ReputationEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetReputationResponse) GetTotalEvents() int32 {
+	if x != nil {
+		return x.TotalEvents
+	}
+	return 0
+}
+
+func (x *GetReputationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type Reputation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaxId           string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Score           int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	ReputationGroup *ReputationGroup       `protobuf:"bytes,3,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
+	// Unset in the top group.
+	NextReputationGroup *ReputationGroup `protobuf:"bytes,4,opt,name=next_reputation_group,json=nextReputationGroup,proto3" json:"next_reputation_group,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Reputation) Reset() {
+	*x = Reputation{}
+	mi := &file_proto_user_user_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reputation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reputation) ProtoMessage() {}
+
+func (x *Reputation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reputation.ProtoReflect.Descriptor instead.
+func (*Reputation) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *Reputation) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *Reputation) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Reputation) GetReputationGroup() *ReputationGroup {
+	if x != nil {
+		return x.ReputationGroup
+	}
+	return nil
+}
+
+func (x *Reputation) GetNextReputationGroup() *ReputationGroup {
+	if x != nil {
+		return x.NextReputationGroup
+	}
+	return nil
+}
+
+type ReputationEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaxId         string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        ReputationEventReason  `protobuf:"varint,4,opt,name=reason,proto3,enum=user.ReputationEventReason" json:"reason,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,6,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReputationEvent) Reset() {
+	*x = ReputationEvent{}
+	mi := &file_proto_user_user_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReputationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReputationEvent) ProtoMessage() {}
+
+func (x *ReputationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReputationEvent.ProtoReflect.Descriptor instead.
+func (*ReputationEvent) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ReputationEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReputationEvent) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *ReputationEvent) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ReputationEvent) GetReason() ReputationEventReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReputationEventReason_REPUTATION_EVENT_REASON_UNSPECIFIED
+}
+
+func (x *ReputationEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReputationEvent) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReputationEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetReputationHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MaxId string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// Newest first. Defaults to 20, at most 100.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationHistoryRequest) Reset() {
+	*x = GetReputationHistoryRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationHistoryRequest) ProtoMessage() {}
+
+func (x *GetReputationHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetReputationHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetReputationHistoryRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *GetReputationHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReputationHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetReputationHistoryResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Changes       []*ReputationGroupChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Total         int32                    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         *Error                   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationHistoryResponse) Reset() {
+	*x = GetReputationHistoryResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationHistoryResponse) ProtoMessage() {}
+
+func (x *GetReputationHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetReputationHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetReputationHistoryResponse) GetChanges() []*ReputationGroupChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetReputationHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetReputationHistoryResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// A move of a user to another group. Group names are the ones at the time of
+// the move. reputation_event_id is set when an event caused it and
+// reference_id is that event's reference, such as a balance operation.
+type ReputationGroupChange struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaxId             string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	FromGroupId       int32                  `protobuf:"varint,3,opt,name=from_group_id,json=fromGroupId,proto3" json:"from_group_id,omitempty"`
+	FromGroupName     string                 `protobuf:"bytes,4,opt,name=from_group_name,json=fromGroupName,proto3" json:"from_group_name,omitempty"`
+	ToGroupId         int32                  `protobuf:"varint,5,opt,name=to_group_id,json=toGroupId,proto3" json:"to_group_id,omitempty"`
+	ToGroupName       string                 `protobuf:"bytes,6,opt,name=to_group_name,json=toGroupName,proto3" json:"to_group_name,omitempty"`
+	Promoted          bool                   `protobuf:"varint,7,opt,name=promoted,proto3" json:"promoted,omitempty"`
+	Score             int32                  `protobuf:"varint,8,opt,name=score,proto3" json:"score,omitempty"`
+	Cause             ReputationChangeCause  `protobuf:"varint,9,opt,name=cause,proto3,enum=user.ReputationChangeCause" json:"cause,omitempty"`
+	ReputationEventId string                 `protobuf:"bytes,10,opt,name=reputation_event_id,json=reputationEventId,proto3" json:"reputation_event_id,omitempty"`
+	ReferenceId       string                 `protobuf:"bytes,11,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReputationGroupChange) Reset() {
+	*x = ReputationGroupChange{}
+	mi := &file_proto_user_user_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReputationGroupChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReputationGroupChange) ProtoMessage() {}
+
+func (x *ReputationGroupChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReputationGroupChange.ProtoReflect.Descriptor instead.
+func (*ReputationGroupChange) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ReputationGroupChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReputationGroupChange) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+func (x *ReputationGroupChange) GetFromGroupId() int32 {
+	if x != nil {
+		return x.FromGroupId
+	}
+	return 0
+}
+
+func (x *ReputationGroupChange) GetFromGroupName() string {
+	if x != nil {
+		return x.FromGroupName
+	}
+	return ""
+}
+
+func (x *ReputationGroupChange) GetToGroupId() int32 {
+	if x != nil {
+		return x.ToGroupId
+	}
+	return 0
+}
+
+func (x *ReputationGroupChange) GetToGroupName() string {
+	if x != nil {
+		return x.ToGroupName
+	}
+	return ""
+}
+
+func (x *ReputationGroupChange) GetPromoted() bool {
+	if x != nil {
+		return x.Promoted
+	}
+	return false
+}
+
+func (x *ReputationGroupChange) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ReputationGroupChange) GetCause() ReputationChangeCause {
+	if x != nil {
+		return x.Cause
+	}
+	return ReputationChangeCause_REPUTATION_CHANGE_CAUSE_UNSPECIFIED
+}
+
+func (x *ReputationGroupChange) GetReputationEventId() string {
+	if x != nil {
+		return x.ReputationEventId
+	}
+	return ""
+}
+
+func (x *ReputationGroupChange) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReputationGroupChange) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Names must be unique and every group needs its own reputation_need. The
+// coefficient must be positive.
+type CreateReputationGroupRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Coefficient float64                `protobuf:"fixed64,3,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
+	// Must be positive; only the default group has a zero threshold.
+	ReputationNeed int32 `protobuf:"varint,4,opt,name=reputation_need,json=reputationNeed,proto3" json:"reputation_need,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateReputationGroupRequest) Reset() {
+	*x = CreateReputationGroupRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReputationGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReputationGroupRequest) ProtoMessage() {}
+
+func (x *CreateReputationGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReputationGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateReputationGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CreateReputationGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateReputationGroupRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateReputationGroupRequest) GetCoefficient() float64 {
+	if x != nil {
+		return x.Coefficient
+	}
+	return 0
+}
+
+func (x *CreateReputationGroupRequest) GetReputationNeed() int32 {
+	if x != nil {
+		return x.ReputationNeed
+	}
+	return 0
+}
+
+type CreateReputationGroupResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReputationGroup *ReputationGroup       `protobuf:"bytes,1,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
+	Error           *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateReputationGroupResponse) Reset() {
+	*x = CreateReputationGroupResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReputationGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReputationGroupResponse) ProtoMessage() {}
+
+func (x *CreateReputationGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReputationGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateReputationGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CreateReputationGroupResponse) GetReputationGroup() *ReputationGroup {
+	if x != nil {
+		return x.ReputationGroup
+	}
+	return nil
+}
+
+func (x *CreateReputationGroupResponse) GetError() *Error {
 	if x != nil {
 		return x.Error
 	}
 	return nil
 }
 
-type GetBalanceOperationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxId         string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Replaces every field of the group. Members are not regrouped.
+type UpdateReputationGroupRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Coefficient    float64                `protobuf:"fixed64,4,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
+	ReputationNeed int32                  `protobuf:"varint,5,opt,name=reputation_need,json=reputationNeed,proto3" json:"reputation_need,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetBalanceOperationsRequest) Reset() {
-	*x = GetBalanceOperationsRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[2]
+func (x *UpdateReputationGroupRequest) Reset() {
+	*x = UpdateReputationGroupRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBalanceOperationsRequest) String() string {
+func (x *UpdateReputationGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalanceOperationsRequest) ProtoMessage() {}
+func (*UpdateReputationGroupRequest) ProtoMessage() {}
 
-func (x *GetBalanceOperationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[2]
+func (x *UpdateReputationGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,56 +6436,69 @@ func (x *GetBalanceOperationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalanceOperationsRequest.ProtoReflect.Descriptor instead.
-func (*GetBalanceOperationsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use UpdateReputationGroupRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReputationGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetBalanceOperationsRequest) GetMaxId() string {
+func (x *UpdateReputationGroupRequest) GetId() int32 {
 	if x != nil {
-		return x.MaxId
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateReputationGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GetBalanceOperationsRequest) GetLimit() int32 {
+func (x *UpdateReputationGroupRequest) GetDescription() string {
 	if x != nil {
-		return x.Limit
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateReputationGroupRequest) GetCoefficient() float64 {
+	if x != nil {
+		return x.Coefficient
 	}
 	return 0
 }
 
-func (x *GetBalanceOperationsRequest) GetOffset() int32 {
+func (x *UpdateReputationGroupRequest) GetReputationNeed() int32 {
 	if x != nil {
-		return x.Offset
+		return x.ReputationNeed
 	}
 	return 0
 }
 
-type GetBalanceOperationsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Operations    []*BalanceOperation    `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type UpdateReputationGroupResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReputationGroup *ReputationGroup       `protobuf:"bytes,1,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
+	Error           *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetBalanceOperationsResponse) Reset() {
-	*x = GetBalanceOperationsResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[3]
+func (x *UpdateReputationGroupResponse) Reset() {
+	*x = UpdateReputationGroupResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBalanceOperationsResponse) String() string {
+func (x *UpdateReputationGroupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalanceOperationsResponse) ProtoMessage() {}
+func (*UpdateReputationGroupResponse) ProtoMessage() {}
 
-func (x *GetBalanceOperationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[3]
+func (x *UpdateReputationGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,57 +6509,51 @@ func (x *GetBalanceOperationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalanceOperationsResponse.ProtoReflect.Descriptor instead.
-func (*GetBalanceOperationsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UpdateReputationGroupResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReputationGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *GetBalanceOperationsResponse) GetOperations() []*BalanceOperation {
+func (x *UpdateReputationGroupResponse) GetReputationGroup() *ReputationGroup {
 	if x != nil {
-		return x.Operations
+		return x.ReputationGroup
 	}
 	return nil
 }
 
-func (x *GetBalanceOperationsResponse) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *GetBalanceOperationsResponse) GetError() *Error {
+func (x *UpdateReputationGroupResponse) GetError() *Error {
 	if x != nil {
 		return x.Error
 	}
 	return nil
 }
 
-type CreateOperationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxId         string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
-	Amount        int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Type          BalanceOperationType   `protobuf:"varint,3,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// The default group cannot be deleted. A group with members is only deleted
+// when move_members_to_id names the group they move to, otherwise the call
+// fails with ERROR_CODE_CONFLICT.
+type DeleteReputationGroupRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MoveMembersToId int32                  `protobuf:"varint,2,opt,name=move_members_to_id,json=moveMembersToId,proto3" json:"move_members_to_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *CreateOperationRequest) Reset() {
-	*x = CreateOperationRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[4]
+func (x *DeleteReputationGroupRequest) Reset() {
+	*x = DeleteReputationGroupRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOperationRequest) String() string {
+func (x *DeleteReputationGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOperationRequest) ProtoMessage() {}
+func (*DeleteReputationGroupRequest) ProtoMessage() {}
 
-func (x *CreateOperationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[4]
+func (x *DeleteReputationGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,62 +6564,48 @@ func (x *CreateOperationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOperationRequest.ProtoReflect.Descriptor instead.
-func (*CreateOperationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *CreateOperationRequest) GetMaxId() string {
-	if x != nil {
-		return x.MaxId
-	}
-	return ""
+// Deprecated: Use DeleteReputationGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReputationGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *CreateOperationRequest) GetAmount() int32 {
+func (x *DeleteReputationGroupRequest) GetId() int32 {
 	if x != nil {
-		return x.Amount
+		return x.Id
 	}
 	return 0
 }
 
-func (x *CreateOperationRequest) GetType() BalanceOperationType {
-	if x != nil {
-		return x.Type
-	}
-	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
-}
-
-func (x *CreateOperationRequest) GetDescription() string {
+func (x *DeleteReputationGroupRequest) GetMoveMembersToId() int32 {
 	if x != nil {
-		return x.Description
+		return x.MoveMembersToId
 	}
-	return ""
+	return 0
 }
 
-type CreateOperationResponse struct {
+type DeleteReputationGroupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Operation     *BalanceOperation      `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	MovedMembers  int32                  `protobuf:"varint,1,opt,name=moved_members,json=movedMembers,proto3" json:"moved_members,omitempty"`
 	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOperationResponse) Reset() {
-	*x = CreateOperationResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[5]
+func (x *DeleteReputationGroupResponse) Reset() {
+	*x = DeleteReputationGroupResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOperationResponse) String() string {
+func (x *DeleteReputationGroupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOperationResponse) ProtoMessage() {}
+func (*DeleteReputationGroupResponse) ProtoMessage() {}
 
-func (x *CreateOperationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[5]
+func (x *DeleteReputationGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,52 +6616,52 @@ func (x *CreateOperationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOperationResponse.ProtoReflect.Descriptor instead.
-func (*CreateOperationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use DeleteReputationGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReputationGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *CreateOperationResponse) GetOperation() *BalanceOperation {
+func (x *DeleteReputationGroupResponse) GetMovedMembers() int32 {
 	if x != nil {
-		return x.Operation
+		return x.MovedMembers
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateOperationResponse) GetError() *Error {
+func (x *DeleteReputationGroupResponse) GetError() *Error {
 	if x != nil {
 		return x.Error
 	}
 	return nil
 }
 
-type BalanceOperation struct {
+// Moves users into the group their score falls in, in max_id order. A call
+// checks at most limit users, 5000 by default and 50000 at most; pass
+// next_max_id back as after_max_id to continue until it comes back empty.
+type RecalculateReputationGroupsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	BalanceId     string                 `protobuf:"bytes,2,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
-	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Type          BalanceOperationType   `protobuf:"varint,4,opt,name=type,proto3,enum=user.BalanceOperationType" json:"type,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	CreatedAt     int32                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	AfterMaxId    string                 `protobuf:"bytes,2,opt,name=after_max_id,json=afterMaxId,proto3" json:"after_max_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BalanceOperation) Reset() {
-	*x = BalanceOperation{}
-	mi := &file_proto_user_user_proto_msgTypes[6]
+func (x *RecalculateReputationGroupsRequest) Reset() {
+	*x = RecalculateReputationGroupsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BalanceOperation) String() string {
+func (x *RecalculateReputationGroupsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BalanceOperation) ProtoMessage() {}
+func (*RecalculateReputationGroupsRequest) ProtoMessage() {}
 
-func (x *BalanceOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[6]
+func (x *RecalculateReputationGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,83 +6672,58 @@ func (x *BalanceOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BalanceOperation.ProtoReflect.Descriptor instead.
-func (*BalanceOperation) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *BalanceOperation) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *BalanceOperation) GetBalanceId() string {
-	if x != nil {
-		return x.BalanceId
-	}
-	return ""
-}
-
-func (x *BalanceOperation) GetAmount() int32 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
+// Deprecated: Use RecalculateReputationGroupsRequest.ProtoReflect.Descriptor instead.
+func (*RecalculateReputationGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *BalanceOperation) GetType() BalanceOperationType {
+func (x *RecalculateReputationGroupsRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Type
+		return x.DryRun
 	}
-	return BalanceOperationType_BALANCE_OPERATION_TYPE_UNSPECIFIED
+	return false
 }
 
-func (x *BalanceOperation) GetDescription() string {
+func (x *RecalculateReputationGroupsRequest) GetAfterMaxId() string {
 	if x != nil {
-		return x.Description
+		return x.AfterMaxId
 	}
 	return ""
 }
 
-func (x *BalanceOperation) GetCreatedAt() int32 {
+func (x *RecalculateReputationGroupsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Limit
 	}
 	return 0
 }
 
-type User struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	MaxId           string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
-	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Geolocation     string                 `protobuf:"bytes,4,opt,name=geolocation,proto3" json:"geolocation,omitempty"`
-	Age             int32                  `protobuf:"varint,5,opt,name=age,proto3" json:"age,omitempty"`
-	Sex             Sex                    `protobuf:"varint,6,opt,name=sex,proto3,enum=user.Sex" json:"sex,omitempty"`
-	About           string                 `protobuf:"bytes,7,opt,name=about,proto3" json:"about,omitempty"`
-	Role            Role                   `protobuf:"varint,8,opt,name=role,proto3,enum=user.Role" json:"role,omitempty"`
-	Status          Status                 `protobuf:"varint,9,opt,name=status,proto3,enum=user.Status" json:"status,omitempty"`
-	ReputationGroup *ReputationGroup       `protobuf:"bytes,10,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type RecalculateReputationGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scanned       int32                  `protobuf:"varint,1,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Moved         int32                  `protobuf:"varint,2,opt,name=moved,proto3" json:"moved,omitempty"`
+	Moves         []*ReputationGroupMove `protobuf:"bytes,3,rep,name=moves,proto3" json:"moves,omitempty"`
+	NextMaxId     string                 `protobuf:"bytes,4,opt,name=next_max_id,json=nextMaxId,proto3" json:"next_max_id,omitempty"`
+	Error         *Error                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
-	mi := &file_proto_user_user_proto_msgTypes[7]
+func (x *RecalculateReputationGroupsResponse) Reset() {
+	*x = RecalculateReputationGroupsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *RecalculateReputationGroupsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*RecalculateReputationGroupsResponse) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[7]
+func (x *RecalculateReputationGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,100 +6734,74 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *User) GetMaxId() string {
-	if x != nil {
-		return x.MaxId
-	}
-	return ""
-}
-
-func (x *User) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use RecalculateReputationGroupsResponse.ProtoReflect.Descriptor instead.
+func (*RecalculateReputationGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *User) GetGeolocation() string {
+func (x *RecalculateReputationGroupsResponse) GetScanned() int32 {
 	if x != nil {
-		return x.Geolocation
+		return x.Scanned
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetAge() int32 {
+func (x *RecalculateReputationGroupsResponse) GetMoved() int32 {
 	if x != nil {
-		return x.Age
+		return x.Moved
 	}
 	return 0
 }
 
-func (x *User) GetSex() Sex {
+func (x *RecalculateReputationGroupsResponse) GetMoves() []*ReputationGroupMove {
 	if x != nil {
-		return x.Sex
+		return x.Moves
 	}
-	return Sex_SEX_UNSPECIFIED
+	return nil
 }
 
-func (x *User) GetAbout() string {
+func (x *RecalculateReputationGroupsResponse) GetNextMaxId() string {
 	if x != nil {
-		return x.About
+		return x.NextMaxId
 	}
 	return ""
 }
 
-func (x *User) GetRole() Role {
-	if x != nil {
-		return x.Role
-	}
-	return Role_ROLE_UNSPECIFIED
-}
-
-func (x *User) GetStatus() Status {
-	if x != nil {
-		return x.Status
-	}
-	return Status_STATUS_UNSPECIFIED
-}
-
-func (x *User) GetReputationGroup() *ReputationGroup {
+func (x *RecalculateReputationGroupsResponse) GetError() *Error {
 	if x != nil {
-		return x.ReputationGroup
+		return x.Error
 	}
 	return nil
 }
 
-type ReputationGroup struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Coefficient    float64                `protobuf:"fixed64,4,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
-	ReputationNeed int32                  `protobuf:"varint,5,opt,name=reputation_need,json=reputationNeed,proto3" json:"reputation_need,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// How many users moved, or would move with dry_run, between two groups.
+type ReputationGroupMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromGroupId   int32                  `protobuf:"varint,1,opt,name=from_group_id,json=fromGroupId,proto3" json:"from_group_id,omitempty"`
+	FromGroupName string                 `protobuf:"bytes,2,opt,name=from_group_name,json=fromGroupName,proto3" json:"from_group_name,omitempty"`
+	ToGroupId     int32                  `protobuf:"varint,3,opt,name=to_group_id,json=toGroupId,proto3" json:"to_group_id,omitempty"`
+	ToGroupName   string                 `protobuf:"bytes,4,opt,name=to_group_name,json=toGroupName,proto3" json:"to_group_name,omitempty"`
+	Promoted      bool                   `protobuf:"varint,5,opt,name=promoted,proto3" json:"promoted,omitempty"`
+	Count         int32                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReputationGroup) Reset() {
-	*x = ReputationGroup{}
-	mi := &file_proto_user_user_proto_msgTypes[8]
+func (x *ReputationGroupMove) Reset() {
+	*x = ReputationGroupMove{}
+	mi := &file_proto_user_user_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReputationGroup) String() string {
+func (x *ReputationGroupMove) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReputationGroup) ProtoMessage() {}
+func (*ReputationGroupMove) ProtoMessage() {}
 
-func (x *ReputationGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[8]
+func (x *ReputationGroupMove) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,67 +6812,75 @@ func (x *ReputationGroup) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReputationGroup.ProtoReflect.Descriptor instead.
-func (*ReputationGroup) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ReputationGroupMove.ProtoReflect.Descriptor instead.
+func (*ReputationGroupMove) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *ReputationGroup) GetId() int32 {
+func (x *ReputationGroupMove) GetFromGroupId() int32 {
 	if x != nil {
-		return x.Id
+		return x.FromGroupId
 	}
 	return 0
 }
 
-func (x *ReputationGroup) GetName() string {
+func (x *ReputationGroupMove) GetFromGroupName() string {
 	if x != nil {
-		return x.Name
+		return x.FromGroupName
 	}
 	return ""
 }
 
-func (x *ReputationGroup) GetDescription() string {
+func (x *ReputationGroupMove) GetToGroupId() int32 {
 	if x != nil {
-		return x.Description
+		return x.ToGroupId
+	}
+	return 0
+}
+
+func (x *ReputationGroupMove) GetToGroupName() string {
+	if x != nil {
+		return x.ToGroupName
 	}
 	return ""
 }
 
-func (x *ReputationGroup) GetCoefficient() float64 {
+func (x *ReputationGroupMove) GetPromoted() bool {
 	if x != nil {
-		return x.Coefficient
+		return x.Promoted
 	}
-	return 0
+	return false
 }
 
-func (x *ReputationGroup) GetReputationNeed() int32 {
+func (x *ReputationGroupMove) GetCount() int32 {
 	if x != nil {
-		return x.ReputationNeed
+		return x.Count
 	}
 	return 0
 }
 
-type GetReputationGroupsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetReputationGroupLimitsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReputationGroupId int32                  `protobuf:"varint,1,opt,name=reputation_group_id,json=reputationGroupId,proto3" json:"reputation_group_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *GetReputationGroupsRequest) Reset() {
-	*x = GetReputationGroupsRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[9]
+func (x *GetReputationGroupLimitsRequest) Reset() {
+	*x = GetReputationGroupLimitsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReputationGroupsRequest) String() string {
+func (x *GetReputationGroupLimitsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReputationGroupsRequest) ProtoMessage() {}
+func (*GetReputationGroupLimitsRequest) ProtoMessage() {}
 
-func (x *GetReputationGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[9]
+func (x *GetReputationGroupLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,34 +6891,41 @@ func (x *GetReputationGroupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReputationGroupsRequest.ProtoReflect.Descriptor instead.
-func (*GetReputationGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetReputationGroupLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetReputationGroupLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{83}
 }
 
-type GetReputationGroupsResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	ReputationGroups []*ReputationGroup     `protobuf:"bytes,1,rep,name=reputation_groups,json=reputationGroups,proto3" json:"reputation_groups,omitempty"`
-	Error            *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *GetReputationGroupLimitsRequest) GetReputationGroupId() int32 {
+	if x != nil {
+		return x.ReputationGroupId
+	}
+	return 0
 }
 
-func (x *GetReputationGroupsResponse) Reset() {
-	*x = GetReputationGroupsResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[10]
+type GetReputationGroupLimitsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Limits        []*ReputationGroupLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	Error         *Error                  `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationGroupLimitsResponse) Reset() {
+	*x = GetReputationGroupLimitsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReputationGroupsResponse) String() string {
+func (x *GetReputationGroupLimitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReputationGroupsResponse) ProtoMessage() {}
+func (*GetReputationGroupLimitsResponse) ProtoMessage() {}
 
-func (x *GetReputationGroupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[10]
+func (x *GetReputationGroupLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,47 +6936,49 @@ func (x *GetReputationGroupsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReputationGroupsResponse.ProtoReflect.Descriptor instead.
-func (*GetReputationGroupsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetReputationGroupLimitsResponse.ProtoReflect.Descriptor instead.
+func (*GetReputationGroupLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *GetReputationGroupsResponse) GetReputationGroups() []*ReputationGroup {
+func (x *GetReputationGroupLimitsResponse) GetLimits() []*ReputationGroupLimit {
 	if x != nil {
-		return x.ReputationGroups
+		return x.Limits
 	}
 	return nil
 }
 
-func (x *GetReputationGroupsResponse) GetError() *Error {
+func (x *GetReputationGroupLimitsResponse) GetError() *Error {
 	if x != nil {
 		return x.Error
 	}
 	return nil
 }
 
-type GetReputationGroupByIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+type SetReputationGroupLimitsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReputationGroupId int32                  `protobuf:"varint,1,opt,name=reputation_group_id,json=reputationGroupId,proto3" json:"reputation_group_id,omitempty"`
+	// Replaces every limit of the group. An empty list removes all limits.
+	Limits        []*ReputationGroupLimit `protobuf:"bytes,2,rep,name=limits,proto3" json:"limits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReputationGroupByIDRequest) Reset() {
-	*x = GetReputationGroupByIDRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[11]
+func (x *SetReputationGroupLimitsRequest) Reset() {
+	*x = SetReputationGroupLimitsRequest{}
+	mi := &file_proto_user_user_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReputationGroupByIDRequest) String() string {
+func (x *SetReputationGroupLimitsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReputationGroupByIDRequest) ProtoMessage() {}
+func (*SetReputationGroupLimitsRequest) ProtoMessage() {}
 
-func (x *GetReputationGroupByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[11]
+func (x *SetReputationGroupLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,41 +6989,48 @@ func (x *GetReputationGroupByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReputationGroupByIDRequest.ProtoReflect.Descriptor instead.
-func (*GetReputationGroupByIDRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SetReputationGroupLimitsRequest.ProtoReflect.Descriptor instead.
+func (*SetReputationGroupLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *GetReputationGroupByIDRequest) GetId() int32 {
+func (x *SetReputationGroupLimitsRequest) GetReputationGroupId() int32 {
 	if x != nil {
-		return x.Id
+		return x.ReputationGroupId
 	}
 	return 0
 }
 
-type GetReputationGroupByIDResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ReputationGroup *ReputationGroup       `protobuf:"bytes,1,opt,name=reputation_group,json=reputationGroup,proto3" json:"reputation_group,omitempty"`
-	Error           *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *SetReputationGroupLimitsRequest) GetLimits() []*ReputationGroupLimit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
 }
 
-func (x *GetReputationGroupByIDResponse) Reset() {
-	*x = GetReputationGroupByIDResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[12]
+type SetReputationGroupLimitsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Limits        []*ReputationGroupLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	Error         *Error                  `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReputationGroupLimitsResponse) Reset() {
+	*x = SetReputationGroupLimitsResponse{}
+	mi := &file_proto_user_user_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReputationGroupByIDResponse) String() string {
+func (x *SetReputationGroupLimitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReputationGroupByIDResponse) ProtoMessage() {}
+func (*SetReputationGroupLimitsResponse) ProtoMessage() {}
 
-func (x *GetReputationGroupByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[12]
+func (x *SetReputationGroupLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1044,35 +7041,97 @@ func (x *GetReputationGroupByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReputationGroupByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetReputationGroupByIDResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SetReputationGroupLimitsResponse.ProtoReflect.Descriptor instead.
+func (*SetReputationGroupLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *GetReputationGroupByIDResponse) GetReputationGroup() *ReputationGroup {
+func (x *SetReputationGroupLimitsResponse) GetLimits() []*ReputationGroupLimit {
 	if x != nil {
-		return x.ReputationGroup
+		return x.Limits
 	}
 	return nil
 }
 
-func (x *GetReputationGroupByIDResponse) GetError() *Error {
+func (x *SetReputationGroupLimitsResponse) GetError() *Error {
 	if x != nil {
 		return x.Error
 	}
 	return nil
 }
 
-type CreateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+type ReputationGroupLimit struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Direction LimitDirection         `protobuf:"varint,1,opt,name=direction,proto3,enum=user.LimitDirection" json:"direction,omitempty"`
+	// Length of the rolling window, e.g. 86400 for a day.
+	WindowSeconds int32 `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	MaxAmount     int32 `protobuf:"varint,3,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ReputationGroupLimit) Reset() {
+	*x = ReputationGroupLimit{}
+	mi := &file_proto_user_user_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReputationGroupLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReputationGroupLimit) ProtoMessage() {}
+
+func (x *ReputationGroupLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReputationGroupLimit.ProtoReflect.Descriptor instead.
+func (*ReputationGroupLimit) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ReputationGroupLimit) GetDirection() LimitDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return LimitDirection_LIMIT_DIRECTION_UNSPECIFIED
+}
+
+func (x *ReputationGroupLimit) GetWindowSeconds() int32 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *ReputationGroupLimit) GetMaxAmount() int32 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+type CreateUserRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	User           *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[13]
+	mi := &file_proto_user_user_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1084,7 +7143,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[13]
+	mi := &file_proto_user_user_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1097,7 +7156,7 @@ func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
 func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{13}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *CreateUserRequest) GetUser() *User {
@@ -1107,6 +7166,13 @@ func (x *CreateUserRequest) GetUser() *User {
 	return nil
 }
 
+func (x *CreateUserRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type GetUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MaxId         string                 `protobuf:"bytes,1,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
@@ -1120,7 +7186,7 @@ type GetUsersRequest struct {
 
 func (x *GetUsersRequest) Reset() {
 	*x = GetUsersRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[14]
+	mi := &file_proto_user_user_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1132,7 +7198,7 @@ func (x *GetUsersRequest) String() string {
 func (*GetUsersRequest) ProtoMessage() {}
 
 func (x *GetUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[14]
+	mi := &file_proto_user_user_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1145,7 +7211,7 @@ func (x *GetUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUsersRequest.ProtoReflect.Descriptor instead.
 func (*GetUsersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{14}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *GetUsersRequest) GetMaxId() string {
@@ -1194,7 +7260,7 @@ type GetUsersResponse struct {
 
 func (x *GetUsersResponse) Reset() {
 	*x = GetUsersResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[15]
+	mi := &file_proto_user_user_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1206,7 +7272,7 @@ func (x *GetUsersResponse) String() string {
 func (*GetUsersResponse) ProtoMessage() {}
 
 func (x *GetUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[15]
+	mi := &file_proto_user_user_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1219,7 +7285,7 @@ func (x *GetUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUsersResponse.ProtoReflect.Descriptor instead.
 func (*GetUsersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{15}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *GetUsersResponse) GetUsers() []*User {
@@ -1252,7 +7318,7 @@ type GetUserByMaxIDRequest struct {
 
 func (x *GetUserByMaxIDRequest) Reset() {
 	*x = GetUserByMaxIDRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[16]
+	mi := &file_proto_user_user_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1264,7 +7330,7 @@ func (x *GetUserByMaxIDRequest) String() string {
 func (*GetUserByMaxIDRequest) ProtoMessage() {}
 
 func (x *GetUserByMaxIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[16]
+	mi := &file_proto_user_user_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1277,7 +7343,7 @@ func (x *GetUserByMaxIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserByMaxIDRequest.ProtoReflect.Descriptor instead.
 func (*GetUserByMaxIDRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{16}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *GetUserByMaxIDRequest) GetMaxId() string {
@@ -1297,7 +7363,7 @@ type GetUserByMaxIDResponse struct {
 
 func (x *GetUserByMaxIDResponse) Reset() {
 	*x = GetUserByMaxIDResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[17]
+	mi := &file_proto_user_user_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1309,7 +7375,7 @@ func (x *GetUserByMaxIDResponse) String() string {
 func (*GetUserByMaxIDResponse) ProtoMessage() {}
 
 func (x *GetUserByMaxIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[17]
+	mi := &file_proto_user_user_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1322,7 +7388,7 @@ func (x *GetUserByMaxIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserByMaxIDResponse.ProtoReflect.Descriptor instead.
 func (*GetUserByMaxIDResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{17}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *GetUserByMaxIDResponse) GetUser() *User {
@@ -1340,15 +7406,16 @@ func (x *GetUserByMaxIDResponse) GetError() *Error {
 }
 
 type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	User           *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[18]
+	mi := &file_proto_user_user_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1360,7 +7427,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[18]
+	mi := &file_proto_user_user_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1373,7 +7440,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{18}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *UpdateUserRequest) GetUser() *User {
@@ -1383,6 +7450,13 @@ func (x *UpdateUserRequest) GetUser() *User {
 	return nil
 }
 
+func (x *UpdateUserRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -1393,7 +7467,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[19]
+	mi := &file_proto_user_user_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1405,7 +7479,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[19]
+	mi := &file_proto_user_user_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1418,7 +7492,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{19}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *UpdateUserResponse) GetUser() *User {
@@ -1444,7 +7518,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_proto_user_user_proto_msgTypes[20]
+	mi := &file_proto_user_user_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1456,7 +7530,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[20]
+	mi := &file_proto_user_user_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1469,7 +7543,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{20}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *DeleteUserRequest) GetMaxId() string {
@@ -1489,7 +7563,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[21]
+	mi := &file_proto_user_user_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1501,7 +7575,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[21]
+	mi := &file_proto_user_user_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1514,7 +7588,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{21}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *DeleteUserResponse) GetMaxId() string {
@@ -1541,7 +7615,7 @@ type CreateUserResponse struct {
 
 func (x *CreateUserResponse) Reset() {
 	*x = CreateUserResponse{}
-	mi := &file_proto_user_user_proto_msgTypes[22]
+	mi := &file_proto_user_user_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1553,7 +7627,7 @@ func (x *CreateUserResponse) String() string {
 func (*CreateUserResponse) ProtoMessage() {}
 
 func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[22]
+	mi := &file_proto_user_user_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1566,7 +7640,7 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{22}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *CreateUserResponse) GetUser() *User {
@@ -1593,7 +7667,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_proto_user_user_proto_msgTypes[23]
+	mi := &file_proto_user_user_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1605,7 +7679,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_user_proto_msgTypes[23]
+	mi := &file_proto_user_user_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1618,7 +7692,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_proto_user_user_proto_rawDescGZIP(), []int{23}
+	return file_proto_user_user_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *Error) GetCode() ErrorCode {
@@ -1639,30 +7713,351 @@ var File_proto_user_user_proto protoreflect.FileDescriptor
 
 const file_proto_user_user_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/user/user.proto\x12\x04user\"*\n" +
+	"\x15proto/user/user.proto\x12\x04user\"]\n" +
 	"\x11GetBalanceRequest\x12\x15\n" +
-	"\x06max_id\x18\x01 \x01(\tR\x05maxId\"Q\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x121\n" +
+	"\vwallet_type\x18\x02 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"\xdd\x01\n" +
 	"\x12GetBalanceResponse\x12\x18\n" +
 	"\abalance\x18\x01 \x01(\x05R\abalance\x12!\n" +
-	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"b\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\x129\n" +
+	"\rexpiring_soon\x18\x04 \x03(\v2\x14.user.ExpiringPointsR\fexpiringSoon\x121\n" +
+	"\vwallet_type\x18\x05 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"G\n" +
+	"\x0eExpiringPoints\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x05R\x06amount\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"_\n" +
+	"\x13WatchBalanceRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x121\n" +
+	"\vwallet_type\x18\x02 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"\xc0\x01\n" +
+	"\x14WatchBalanceResponse\x12\x18\n" +
+	"\abalance\x18\x01 \x01(\x05R\abalance\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\x05R\tavailable\x124\n" +
+	"\toperation\x18\x03 \x01(\v2\x16.user.BalanceOperationR\toperation\x12!\n" +
+	"\x05error\x18\x04 \x01(\v2\v.user.ErrorR\x05error\x12\x17\n" +
+	"\ahold_id\x18\x05 \x01(\tR\x06holdId\"}\n" +
+	"\x13GetBalanceAtRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x121\n" +
+	"\vwallet_type\x18\x03 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"S\n" +
+	"\x14GetBalanceAtResponse\x12\x18\n" +
+	"\abalance\x18\x01 \x01(\x05R\abalance\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xcb\x01\n" +
+	"\x18GetBalanceHistoryRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12A\n" +
+	"\vgranularity\x18\x04 \x01(\x0e2\x1f.user.BalanceHistoryGranularityR\vgranularity\x121\n" +
+	"\vwallet_type\x18\x05 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"q\n" +
+	"\x19GetBalanceHistoryResponse\x121\n" +
+	"\x06points\x18\x01 \x03(\v2\x19.user.BalanceHistoryPointR\x06points\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\x80\x01\n" +
+	"\x13BalanceHistoryPoint\x12!\n" +
+	"\fperiod_start\x18\x01 \x01(\x03R\vperiodStart\x12\x1d\n" +
+	"\n" +
+	"period_end\x18\x02 \x01(\x03R\tperiodEnd\x12'\n" +
+	"\x0fclosing_balance\x18\x03 \x01(\x05R\x0eclosingBalance\"\xbc\x01\n" +
+	"\x16GetBalanceStatsRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x121\n" +
+	"\vwallet_type\x18\x04 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\x124\n" +
+	"\bgroup_by\x18\x05 \x01(\x0e2\x19.user.BalanceStatsGroupByR\agroupBy\"\x9c\x02\n" +
+	"\x17GetBalanceStatsResponse\x12\x16\n" +
+	"\x06earned\x18\x01 \x01(\x03R\x06earned\x12\x14\n" +
+	"\x05spent\x18\x02 \x01(\x03R\x05spent\x12'\n" +
+	"\x0flifetime_earned\x18\x03 \x01(\x03R\x0elifetimeEarned\x12%\n" +
+	"\x0elifetime_spent\x18\x04 \x01(\x03R\rlifetimeSpent\x12/\n" +
+	"\x06months\x18\x05 \x03(\v2\x17.user.BalanceStatsMonthR\x06months\x12/\n" +
+	"\x06groups\x18\x06 \x03(\v2\x17.user.BalanceStatsGroupR\x06groups\x12!\n" +
+	"\x05error\x18\a \x01(\v2\v.user.ErrorR\x05error\"b\n" +
+	"\x11BalanceStatsMonth\x12\x1f\n" +
+	"\vmonth_start\x18\x01 \x01(\x03R\n" +
+	"monthStart\x12\x16\n" +
+	"\x06earned\x18\x02 \x01(\x03R\x06earned\x12\x14\n" +
+	"\x05spent\x18\x03 \x01(\x03R\x05spent\"i\n" +
+	"\x11BalanceStatsGroup\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x16\n" +
+	"\x06earned\x18\x03 \x01(\x03R\x06earned\x12\x14\n" +
+	"\x05spent\x18\x04 \x01(\x03R\x05spent\"\x9a\x04\n" +
 	"\x1bGetBalanceOperationsRequest\x12\x15\n" +
 	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x8f\x01\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x120\n" +
+	"\x05types\x18\x04 \x03(\x0e2\x1a.user.BalanceOperationTypeR\x05types\x12!\n" +
+	"\fcreated_from\x18\x05 \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\x03R\tcreatedTo\x12\"\n" +
+	"\n" +
+	"amount_min\x18\a \x01(\x05H\x00R\tamountMin\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"amount_max\x18\b \x01(\x05H\x01R\tamountMax\x88\x01\x01\x121\n" +
+	"\x14description_contains\x18\t \x01(\tR\x13descriptionContains\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\x12\x1d\n" +
+	"\n" +
+	"skip_total\x18\v \x01(\bR\tskipTotal\x12?\n" +
+	"\freason_codes\x18\f \x03(\x0e2\x1c.user.BalanceOperationReasonR\vreasonCodes\x121\n" +
+	"\vwallet_type\x18\r \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletTypeB\r\n" +
+	"\v_amount_minB\r\n" +
+	"\v_amount_max\"\xb0\x01\n" +
 	"\x1cGetBalanceOperationsResponse\x126\n" +
 	"\n" +
 	"operations\x18\x01 \x03(\v2\x16.user.BalanceOperationR\n" +
 	"operations\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12!\n" +
-	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\"\x99\x01\n" +
+	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"\xae\x01\n" +
+	" GetBalanceOperationTotalsRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12!\n" +
+	"\fcreated_from\x18\x02 \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x03 \x01(\x03R\tcreatedTo\x121\n" +
+	"\vwallet_type\x18\x04 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"\x82\x01\n" +
+	"!GetBalanceOperationTotalsResponse\x12:\n" +
+	"\x06totals\x18\x01 \x03(\v2\".user.BalanceOperationReasonTotalsR\x06totals\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xa9\x01\n" +
+	"\x1cBalanceOperationReasonTotals\x12=\n" +
+	"\vreason_code\x18\x01 \x01(\x0e2\x1c.user.BalanceOperationReasonR\n" +
+	"reasonCode\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x1a\n" +
+	"\bcredited\x18\x03 \x01(\x03R\bcredited\x12\x18\n" +
+	"\adebited\x18\x04 \x01(\x03R\adebited\"\xa1\x04\n" +
 	"\x16CreateOperationRequest\x12\x15\n" +
 	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12.\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x1a.user.BalanceOperationTypeR\x04type\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\"r\n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12=\n" +
+	"\vreason_code\x18\x06 \x01(\x0e2\x1c.user.BalanceOperationReasonR\n" +
+	"reasonCode\x12:\n" +
+	"\bmetadata\x18\a \x01(\v2\x1e.user.BalanceOperationMetadataR\bmetadata\x12+\n" +
+	"\x11apply_coefficient\x18\b \x01(\bR\x10applyCoefficient\x121\n" +
+	"\vwallet_type\x18\t \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\x12 \n" +
+	"\factor_max_id\x18\n" +
+	" \x01(\tR\n" +
+	"actorMaxId\x12$\n" +
+	"\rjustification\x18\v \x01(\tR\rjustification\x12:\n" +
+	"\x19counts_towards_reputation\x18\f \x01(\bR\x17countsTowardsReputation\"r\n" +
 	"\x17CreateOperationResponse\x124\n" +
 	"\toperation\x18\x01 \x01(\v2\x16.user.BalanceOperationR\toperation\x12!\n" +
-	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xca\x01\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\x9c\x01\n" +
+	"\x1cCreateOperationsBatchRequest\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.user.BatchOperationItemR\x05items\x12#\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x0f.user.BatchModeR\x04mode\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\xaa\x01\n" +
+	"\x1dCreateOperationsBatchResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.user.BatchOperationResultR\aresults\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\x05R\aapplied\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12!\n" +
+	"\x05error\x18\x04 \x01(\v2\v.user.ErrorR\x05error\"\xc3\x02\n" +
+	"\x12BatchOperationItem\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12.\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1a.user.BalanceOperationTypeR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12=\n" +
+	"\vreason_code\x18\x05 \x01(\x0e2\x1c.user.BalanceOperationReasonR\n" +
+	"reasonCode\x12:\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x1e.user.BalanceOperationMetadataR\bmetadata\x121\n" +
+	"\vwallet_type\x18\a \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\"\x85\x01\n" +
+	"\x14BatchOperationResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x124\n" +
+	"\toperation\x18\x02 \x01(\v2\x16.user.BalanceOperationR\toperation\x12!\n" +
+	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\"\xb6\x01\n" +
+	"\x15TransferPointsRequest\x12\x1e\n" +
+	"\vfrom_max_id\x18\x01 \x01(\tR\tfromMaxId\x12\x1a\n" +
+	"\tto_max_id\x18\x02 \x01(\tR\atoMaxId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"\x98\x02\n" +
+	"\x16TransferPointsResponse\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12=\n" +
+	"\x0efrom_operation\x18\x02 \x01(\v2\x16.user.BalanceOperationR\rfromOperation\x129\n" +
+	"\fto_operation\x18\x03 \x01(\v2\x16.user.BalanceOperationR\vtoOperation\x12!\n" +
+	"\ffrom_balance\x18\x04 \x01(\x05R\vfromBalance\x12\x1d\n" +
+	"\n" +
+	"to_balance\x18\x05 \x01(\x05R\ttoBalance\x12!\n" +
+	"\x05error\x18\x06 \x01(\v2\v.user.ErrorR\x05error\"\x9d\x02\n" +
+	"\x15GetLeaderboardRequest\x12/\n" +
+	"\x06period\x18\x01 \x01(\x0e2\x17.user.LeaderboardPeriodR\x06period\x12/\n" +
+	"\x06metric\x18\x02 \x01(\x0e2\x17.user.LeaderboardMetricR\x06metric\x12 \n" +
+	"\vgeolocation\x18\x03 \x01(\tR\vgeolocation\x12.\n" +
+	"\x13reputation_group_id\x18\x04 \x01(\x05R\x11reputationGroupId\x12\"\n" +
+	"\rcaller_max_id\x18\x05 \x01(\tR\vcallerMaxId\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\a \x01(\x05R\x06offset\"\xd6\x01\n" +
+	"\x16GetLeaderboardResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.user.LeaderboardEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12.\n" +
+	"\x06caller\x18\x03 \x01(\v2\x16.user.LeaderboardEntryR\x06caller\x12!\n" +
+	"\frefreshed_at\x18\x04 \x01(\x03R\vrefreshedAt\x12!\n" +
+	"\x05error\x18\x05 \x01(\v2\v.user.ErrorR\x05error\"g\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\tR\x05maxId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\",\n" +
+	"\x18ReconcileBalancesRequest\x12\x10\n" +
+	"\x03fix\x18\x01 \x01(\bR\x03fix\"\xdd\x01\n" +
+	"\x19ReconcileBalancesResponse\x12\x18\n" +
+	"\ascanned\x18\x01 \x01(\x05R\ascanned\x12\x1a\n" +
+	"\badjusted\x18\x02 \x01(\x05R\badjusted\x125\n" +
+	"\n" +
+	"mismatches\x18\x03 \x03(\v2\x15.user.BalanceMismatchR\n" +
+	"mismatches\x12!\n" +
+	"\x05error\x18\x04 \x01(\v2\v.user.ErrorR\x05error\x120\n" +
+	"\x14negative_balance_ids\x18\x05 \x03(\tR\x12negativeBalanceIds\"\xd3\x01\n" +
+	"\x0fBalanceMismatch\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tR\tbalanceId\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\tR\x05maxId\x12\x1a\n" +
+	"\bexpected\x18\x03 \x01(\x05R\bexpected\x12\x16\n" +
+	"\x06actual\x18\x04 \x01(\x05R\x06actual\x12\x1e\n" +
+	"\n" +
+	"difference\x18\x05 \x01(\x05R\n" +
+	"difference\x126\n" +
+	"\x17adjustment_operation_id\x18\x06 \x01(\tR\x15adjustmentOperationId\"\xae\x01\n" +
+	"\x11CreateHoldRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"W\n" +
+	"\x12CreateHoldResponse\x12\x1e\n" +
+	"\x04hold\x18\x01 \x01(\v2\n" +
+	".user.HoldR\x04hold\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"n\n" +
+	"\x12CaptureHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\x8e\x01\n" +
+	"\x13CaptureHoldResponse\x12\x1e\n" +
+	"\x04hold\x18\x01 \x01(\v2\n" +
+	".user.HoldR\x04hold\x124\n" +
+	"\toperation\x18\x02 \x01(\v2\x16.user.BalanceOperationR\toperation\x12!\n" +
+	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\"-\n" +
+	"\x12ReleaseHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"X\n" +
+	"\x13ReleaseHoldResponse\x12\x1e\n" +
+	"\x04hold\x18\x01 \x01(\v2\n" +
+	".user.HoldR\x04hold\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xb2\x03\n" +
+	"\x18ScheduleOperationRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12.\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1a.user.BalanceOperationTypeR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12=\n" +
+	"\vreason_code\x18\x05 \x01(\x0e2\x1c.user.BalanceOperationReasonR\n" +
+	"reasonCode\x12:\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x1e.user.BalanceOperationMetadataR\bmetadata\x121\n" +
+	"\vwallet_type\x18\a \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\x12\x15\n" +
+	"\x06run_at\x18\b \x01(\x03R\x05runAt\x12'\n" +
+	"\x0fcron_expression\x18\t \x01(\tR\x0ecronExpression\x12'\n" +
+	"\x0fidempotency_key\x18\n" +
+	" \x01(\tR\x0eidempotencyKey\"\x89\x01\n" +
+	"\x19ScheduleOperationResponse\x12I\n" +
+	"\x13scheduled_operation\x18\x01 \x01(\v2\x18.user.ScheduledOperationR\x12scheduledOperation\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xa1\x01\n" +
+	"\x1eListScheduledOperationsRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12:\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x1e.user.ScheduledOperationStatusR\bstatuses\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\xa7\x01\n" +
+	"\x1fListScheduledOperationsResponse\x12K\n" +
+	"\x14scheduled_operations\x18\x01 \x03(\v2\x18.user.ScheduledOperationR\x13scheduledOperations\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12!\n" +
+	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\"0\n" +
+	"\x1ePauseScheduledOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8f\x01\n" +
+	"\x1fPauseScheduledOperationResponse\x12I\n" +
+	"\x13scheduled_operation\x18\x01 \x01(\v2\x18.user.ScheduledOperationR\x12scheduledOperation\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"1\n" +
+	"\x1fResumeScheduledOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x01\n" +
+	" ResumeScheduledOperationResponse\x12I\n" +
+	"\x13scheduled_operation\x18\x01 \x01(\v2\x18.user.ScheduledOperationR\x12scheduledOperation\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"1\n" +
+	"\x1fCancelScheduledOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x01\n" +
+	" CancelScheduledOperationResponse\x12I\n" +
+	"\x13scheduled_operation\x18\x01 \x01(\v2\x18.user.ScheduledOperationR\x12scheduledOperation\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xde\x04\n" +
+	"\x12ScheduledOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\tR\x05maxId\x121\n" +
+	"\vwallet_type\x18\x03 \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x05R\x06amount\x12.\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x1a.user.BalanceOperationTypeR\x04type\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12=\n" +
+	"\vreason_code\x18\a \x01(\x0e2\x1c.user.BalanceOperationReasonR\n" +
+	"reasonCode\x12:\n" +
+	"\bmetadata\x18\b \x01(\v2\x1e.user.BalanceOperationMetadataR\bmetadata\x12'\n" +
+	"\x0fcron_expression\x18\t \x01(\tR\x0ecronExpression\x126\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x1e.user.ScheduledOperationStatusR\x06status\x12\x1e\n" +
+	"\vnext_run_at\x18\v \x01(\x03R\tnextRunAt\x12\x1e\n" +
+	"\vlast_run_at\x18\f \x01(\x03R\tlastRunAt\x12*\n" +
+	"\x11last_operation_id\x18\r \x01(\tR\x0flastOperationId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x0e \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\x03R\tcreatedAt\"\xa3\x02\n" +
+	"\x04Hold\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x02 \x01(\tR\tbalanceId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\x12'\n" +
+	"\x0fcaptured_amount\x18\x04 \x01(\x05R\x0ecapturedAmount\x12(\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x10.user.HoldStatusR\x06status\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12!\n" +
+	"\foperation_id\x18\a \x01(\tR\voperationId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"\x18\n" +
+	"\x16GetTrialBalanceRequest\"\x93\x02\n" +
+	"\x17GetTrialBalanceResponse\x12,\n" +
+	"\x05lines\x18\x01 \x03(\v2\x16.user.TrialBalanceLineR\x05lines\x12\x1f\n" +
+	"\vtotal_debit\x18\x02 \x01(\x03R\n" +
+	"totalDebit\x12!\n" +
+	"\ftotal_credit\x18\x03 \x01(\x03R\vtotalCredit\x12\x1a\n" +
+	"\bbalanced\x18\x04 \x01(\bR\bbalanced\x12!\n" +
+	"\x05error\x18\x05 \x01(\v2\v.user.ErrorR\x05error\x12G\n" +
+	"\x11wallet_mismatches\x18\x06 \x03(\v2\x1a.user.LedgerWalletMismatchR\x10walletMismatches\"\x90\x01\n" +
+	"\x14LedgerWalletMismatch\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x02 \x01(\tR\tbalanceId\x12%\n" +
+	"\x0eledger_balance\x18\x03 \x01(\x03R\rledgerBalance\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\"\x87\x01\n" +
+	"\x10TrialBalanceLine\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12+\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x17.user.LedgerAccountKindR\x04kind\x12\x14\n" +
+	"\x05debit\x18\x03 \x01(\x03R\x05debit\x12\x16\n" +
+	"\x06credit\x18\x04 \x01(\x03R\x06credit\"}\n" +
+	"\x17ReverseOperationRequest\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"s\n" +
+	"\x18ReverseOperationResponse\x124\n" +
+	"\toperation\x18\x01 \x01(\v2\x16.user.BalanceOperationR\toperation\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\x91\x05\n" +
 	"\x10BalanceOperation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1671,7 +8066,32 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\x04type\x18\x04 \x01(\x0e2\x1a.user.BalanceOperationTypeR\x04type\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\x05R\tcreatedAt\"\xa6\x02\n" +
+	"created_at\x18\x06 \x01(\x05R\tcreatedAt\x12\x1f\n" +
+	"\vtransfer_id\x18\a \x01(\tR\n" +
+	"transferId\x122\n" +
+	"\x15reverses_operation_id\x18\b \x01(\tR\x13reversesOperationId\x127\n" +
+	"\x18reversed_by_operation_id\x18\t \x01(\tR\x15reversedByOperationId\x12=\n" +
+	"\vreason_code\x18\n" +
+	" \x01(\x0e2\x1c.user.BalanceOperationReasonR\n" +
+	"reasonCode\x12:\n" +
+	"\bmetadata\x18\v \x01(\v2\x1e.user.BalanceOperationMetadataR\bmetadata\x12\x1f\n" +
+	"\vbase_amount\x18\f \x01(\x05R\n" +
+	"baseAmount\x12 \n" +
+	"\vcoefficient\x18\r \x01(\x01R\vcoefficient\x121\n" +
+	"\vwallet_type\x18\x0e \x01(\x0e2\x10.user.WalletTypeR\n" +
+	"walletType\x12 \n" +
+	"\factor_max_id\x18\x0f \x01(\tR\n" +
+	"actorMaxId\x12$\n" +
+	"\rjustification\x18\x10 \x01(\tR\rjustification\"\xf0\x01\n" +
+	"\x18BalanceOperationMetadata\x12%\n" +
+	"\x0esource_service\x18\x01 \x01(\tR\rsourceService\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12?\n" +
+	"\x05extra\x18\x04 \x03(\v2).user.BalanceOperationMetadata.ExtraEntryR\x05extra\x1a8\n" +
+	"\n" +
+	"ExtraEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x02\n" +
 	"\x04User\x12\x15\n" +
 	"\x06max_id\x18\x02 \x01(\tR\x05maxId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
@@ -1698,10 +8118,130 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"\x85\x01\n" +
 	"\x1eGetReputationGroupByIDResponse\x12@\n" +
 	"\x10reputation_group\x18\x01 \x01(\v2\x15.user.ReputationGroupR\x0freputationGroup\x12!\n" +
-	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"3\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xed\x01\n" +
+	"\x19AddReputationEventRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x123\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1b.user.ReputationEventReasonR\x06reason\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12!\n" +
+	"\freference_id\x18\x05 \x01(\tR\vreferenceId\x12'\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\"\x9e\x01\n" +
+	"\x1aAddReputationEventResponse\x12+\n" +
+	"\x05event\x18\x01 \x01(\v2\x15.user.ReputationEventR\x05event\x120\n" +
+	"\n" +
+	"reputation\x18\x02 \x01(\v2\x10.user.ReputationR\n" +
+	"reputation\x12!\n" +
+	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\"[\n" +
+	"\x14GetReputationRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xbe\x01\n" +
+	"\x15GetReputationResponse\x120\n" +
+	"\n" +
+	"reputation\x18\x01 \x01(\v2\x10.user.ReputationR\n" +
+	"reputation\x12-\n" +
+	"\x06events\x18\x02 \x03(\v2\x15.user.ReputationEventR\x06events\x12!\n" +
+	"\ftotal_events\x18\x03 \x01(\x05R\vtotalEvents\x12!\n" +
+	"\x05error\x18\x04 \x01(\v2\v.user.ErrorR\x05error\"\xc6\x01\n" +
+	"\n" +
+	"Reputation\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12@\n" +
+	"\x10reputation_group\x18\x03 \x01(\v2\x15.user.ReputationGroupR\x0freputationGroup\x12I\n" +
+	"\x15next_reputation_group\x18\x04 \x01(\v2\x15.user.ReputationGroupR\x13nextReputationGroup\"\xe9\x01\n" +
+	"\x0fReputationEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\tR\x05maxId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\x123\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x1b.user.ReputationEventReasonR\x06reason\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12!\n" +
+	"\freference_id\x18\x06 \x01(\tR\vreferenceId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"b\n" +
+	"\x1bGetReputationHistoryRequest\x12\x15\n" +
+	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x8e\x01\n" +
+	"\x1cGetReputationHistoryResponse\x125\n" +
+	"\achanges\x18\x01 \x03(\v2\x1b.user.ReputationGroupChangeR\achanges\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12!\n" +
+	"\x05error\x18\x03 \x01(\v2\v.user.ErrorR\x05error\"\xa5\x03\n" +
+	"\x15ReputationGroupChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\tR\x05maxId\x12\"\n" +
+	"\rfrom_group_id\x18\x03 \x01(\x05R\vfromGroupId\x12&\n" +
+	"\x0ffrom_group_name\x18\x04 \x01(\tR\rfromGroupName\x12\x1e\n" +
+	"\vto_group_id\x18\x05 \x01(\x05R\ttoGroupId\x12\"\n" +
+	"\rto_group_name\x18\x06 \x01(\tR\vtoGroupName\x12\x1a\n" +
+	"\bpromoted\x18\a \x01(\bR\bpromoted\x12\x14\n" +
+	"\x05score\x18\b \x01(\x05R\x05score\x121\n" +
+	"\x05cause\x18\t \x01(\x0e2\x1b.user.ReputationChangeCauseR\x05cause\x12.\n" +
+	"\x13reputation_event_id\x18\n" +
+	" \x01(\tR\x11reputationEventId\x12!\n" +
+	"\freference_id\x18\v \x01(\tR\vreferenceId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\x03R\tcreatedAt\"\x9f\x01\n" +
+	"\x1cCreateReputationGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12 \n" +
+	"\vcoefficient\x18\x03 \x01(\x01R\vcoefficient\x12'\n" +
+	"\x0freputation_need\x18\x04 \x01(\x05R\x0ereputationNeed\"\x84\x01\n" +
+	"\x1dCreateReputationGroupResponse\x12@\n" +
+	"\x10reputation_group\x18\x01 \x01(\v2\x15.user.ReputationGroupR\x0freputationGroup\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\xaf\x01\n" +
+	"\x1cUpdateReputationGroupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12 \n" +
+	"\vcoefficient\x18\x04 \x01(\x01R\vcoefficient\x12'\n" +
+	"\x0freputation_need\x18\x05 \x01(\x05R\x0ereputationNeed\"\x84\x01\n" +
+	"\x1dUpdateReputationGroupResponse\x12@\n" +
+	"\x10reputation_group\x18\x01 \x01(\v2\x15.user.ReputationGroupR\x0freputationGroup\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"[\n" +
+	"\x1cDeleteReputationGroupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12+\n" +
+	"\x12move_members_to_id\x18\x02 \x01(\x05R\x0fmoveMembersToId\"g\n" +
+	"\x1dDeleteReputationGroupResponse\x12#\n" +
+	"\rmoved_members\x18\x01 \x01(\x05R\fmovedMembers\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"u\n" +
+	"\"RecalculateReputationGroupsRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12 \n" +
+	"\fafter_max_id\x18\x02 \x01(\tR\n" +
+	"afterMaxId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xc9\x01\n" +
+	"#RecalculateReputationGroupsResponse\x12\x18\n" +
+	"\ascanned\x18\x01 \x01(\x05R\ascanned\x12\x14\n" +
+	"\x05moved\x18\x02 \x01(\x05R\x05moved\x12/\n" +
+	"\x05moves\x18\x03 \x03(\v2\x19.user.ReputationGroupMoveR\x05moves\x12\x1e\n" +
+	"\vnext_max_id\x18\x04 \x01(\tR\tnextMaxId\x12!\n" +
+	"\x05error\x18\x05 \x01(\v2\v.user.ErrorR\x05error\"\xd7\x01\n" +
+	"\x13ReputationGroupMove\x12\"\n" +
+	"\rfrom_group_id\x18\x01 \x01(\x05R\vfromGroupId\x12&\n" +
+	"\x0ffrom_group_name\x18\x02 \x01(\tR\rfromGroupName\x12\x1e\n" +
+	"\vto_group_id\x18\x03 \x01(\x05R\ttoGroupId\x12\"\n" +
+	"\rto_group_name\x18\x04 \x01(\tR\vtoGroupName\x12\x1a\n" +
+	"\bpromoted\x18\x05 \x01(\bR\bpromoted\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x05R\x05count\"Q\n" +
+	"\x1fGetReputationGroupLimitsRequest\x12.\n" +
+	"\x13reputation_group_id\x18\x01 \x01(\x05R\x11reputationGroupId\"y\n" +
+	" GetReputationGroupLimitsResponse\x122\n" +
+	"\x06limits\x18\x01 \x03(\v2\x1a.user.ReputationGroupLimitR\x06limits\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\x85\x01\n" +
+	"\x1fSetReputationGroupLimitsRequest\x12.\n" +
+	"\x13reputation_group_id\x18\x01 \x01(\x05R\x11reputationGroupId\x122\n" +
+	"\x06limits\x18\x02 \x03(\v2\x1a.user.ReputationGroupLimitR\x06limits\"y\n" +
+	" SetReputationGroupLimitsResponse\x122\n" +
+	"\x06limits\x18\x01 \x03(\v2\x1a.user.ReputationGroupLimitR\x06limits\x12!\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\x90\x01\n" +
+	"\x14ReputationGroupLimit\x122\n" +
+	"\tdirection\x18\x01 \x01(\x0e2\x14.user.LimitDirectionR\tdirection\x12%\n" +
+	"\x0ewindow_seconds\x18\x02 \x01(\x05R\rwindowSeconds\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x03 \x01(\x05R\tmaxAmount\"\\\n" +
 	"\x11CreateUserRequest\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"\x9c\x01\n" +
+	".user.UserR\x04user\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\"\x9c\x01\n" +
 	"\x0fGetUsersRequest\x12\x15\n" +
 	"\x06max_id\x18\x01 \x01(\tR\x05maxId\x12$\n" +
 	"\x06status\x18\x02 \x01(\x0e2\f.user.StatusR\x06status\x12\x1e\n" +
@@ -1719,10 +8259,11 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\x16GetUserByMaxIDResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
 	".user.UserR\x04user\x12!\n" +
-	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"3\n" +
+	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"\\\n" +
 	"\x11UpdateUserRequest\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"W\n" +
+	".user.UserR\x04user\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\"W\n" +
 	"\x12UpdateUserResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
 	".user.UserR\x04user\x12!\n" +
@@ -1738,11 +8279,87 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\x05error\x18\x02 \x01(\v2\v.user.ErrorR\x05error\"F\n" +
 	"\x05Error\x12#\n" +
 	"\x04code\x18\x01 \x01(\x0e2\x0f.user.ErrorCodeR\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage*\x87\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*\xba\x01\n" +
+	"\x19BalanceHistoryGranularity\x12+\n" +
+	"'BALANCE_HISTORY_GRANULARITY_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fBALANCE_HISTORY_GRANULARITY_DAY\x10\x01\x12$\n" +
+	" BALANCE_HISTORY_GRANULARITY_WEEK\x10\x02\x12%\n" +
+	"!BALANCE_HISTORY_GRANULARITY_MONTH\x10\x03*\x88\x01\n" +
+	"\x13BalanceStatsGroupBy\x12&\n" +
+	"\"BALANCE_STATS_GROUP_BY_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dBALANCE_STATS_GROUP_BY_REASON\x10\x01\x12&\n" +
+	"\"BALANCE_STATS_GROUP_BY_DESCRIPTION\x10\x02*b\n" +
+	"\tBatchMode\x12\x1a\n" +
+	"\x16BATCH_MODE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19BATCH_MODE_ALL_OR_NOTHING\x10\x01\x12\x1a\n" +
+	"\x16BATCH_MODE_BEST_EFFORT\x10\x02*\x93\x01\n" +
+	"\x11LeaderboardPeriod\x12\"\n" +
+	"\x1eLEADERBOARD_PERIOD_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bLEADERBOARD_PERIOD_ALL_TIME\x10\x01\x12\x1c\n" +
+	"\x18LEADERBOARD_PERIOD_MONTH\x10\x02\x12\x1b\n" +
+	"\x17LEADERBOARD_PERIOD_WEEK\x10\x03*v\n" +
+	"\x11LeaderboardMetric\x12\"\n" +
+	"\x1eLEADERBOARD_METRIC_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aLEADERBOARD_METRIC_BALANCE\x10\x01\x12\x1d\n" +
+	"\x19LEADERBOARD_METRIC_EARNED\x10\x02*\xe8\x01\n" +
+	"\x18ScheduledOperationStatus\x12*\n" +
+	"&SCHEDULED_OPERATION_STATUS_UNSPECIFIED\x10\x00\x12%\n" +
+	"!SCHEDULED_OPERATION_STATUS_ACTIVE\x10\x01\x12%\n" +
+	"!SCHEDULED_OPERATION_STATUS_PAUSED\x10\x02\x12(\n" +
+	"$SCHEDULED_OPERATION_STATUS_CANCELLED\x10\x03\x12(\n" +
+	"$SCHEDULED_OPERATION_STATUS_COMPLETED\x10\x04*\x8e\x01\n" +
+	"\n" +
+	"HoldStatus\x12\x1b\n" +
+	"\x17HOLD_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12HOLD_STATUS_ACTIVE\x10\x01\x12\x18\n" +
+	"\x14HOLD_STATUS_CAPTURED\x10\x02\x12\x18\n" +
+	"\x14HOLD_STATUS_RELEASED\x10\x03\x12\x17\n" +
+	"\x13HOLD_STATUS_EXPIRED\x10\x04*x\n" +
+	"\x11LedgerAccountKind\x12#\n" +
+	"\x1fLEDGER_ACCOUNT_KIND_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aLEDGER_ACCOUNT_KIND_WALLET\x10\x01\x12\x1e\n" +
+	"\x1aLEDGER_ACCOUNT_KIND_SYSTEM\x10\x02*\x82\x03\n" +
+	"\x16BalanceOperationReason\x12(\n" +
+	"$BALANCE_OPERATION_REASON_UNSPECIFIED\x10\x00\x12(\n" +
+	"$BALANCE_OPERATION_REASON_TASK_REWARD\x10\x01\x12(\n" +
+	"$BALANCE_OPERATION_REASON_EVENT_BONUS\x10\x02\x12*\n" +
+	"&BALANCE_OPERATION_REASON_SHOP_PURCHASE\x10\x03\x12%\n" +
+	"!BALANCE_OPERATION_REASON_TRANSFER\x10\x04\x12%\n" +
+	"!BALANCE_OPERATION_REASON_REVERSAL\x10\x05\x12'\n" +
+	"#BALANCE_OPERATION_REASON_ADJUSTMENT\x10\x06\x12\"\n" +
+	"\x1eBALANCE_OPERATION_REASON_OTHER\x10\a\x12#\n" +
+	"\x1fBALANCE_OPERATION_REASON_EXPIRY\x10\b*v\n" +
+	"\n" +
+	"WalletType\x12\x1b\n" +
+	"\x17WALLET_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12WALLET_TYPE_POINTS\x10\x01\x12\x15\n" +
+	"\x11WALLET_TYPE_KARMA\x10\x02\x12\x1c\n" +
+	"\x18WALLET_TYPE_EVENT_TOKENS\x10\x03*\xd1\x01\n" +
 	"\x14BalanceOperationType\x12&\n" +
 	"\"BALANCE_OPERATION_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eBALANCE_OPERATION_TYPE_DEPOSIT\x10\x01\x12#\n" +
-	"\x1fBALANCE_OPERATION_TYPE_WITHDRAW\x10\x02*8\n" +
+	"\x1fBALANCE_OPERATION_TYPE_WITHDRAW\x10\x02\x12%\n" +
+	"!BALANCE_OPERATION_TYPE_ADJUSTMENT\x10\x03\x12!\n" +
+	"\x1dBALANCE_OPERATION_TYPE_EXPIRE\x10\x04*\xdf\x01\n" +
+	"\x15ReputationChangeCause\x12'\n" +
+	"#REPUTATION_CHANGE_CAUSE_UNSPECIFIED\x10\x00\x12,\n" +
+	"(REPUTATION_CHANGE_CAUSE_REPUTATION_EVENT\x10\x01\x12!\n" +
+	"\x1dREPUTATION_CHANGE_CAUSE_ADMIN\x10\x02\x12!\n" +
+	"\x1dREPUTATION_CHANGE_CAUSE_DECAY\x10\x03\x12)\n" +
+	"%REPUTATION_CHANGE_CAUSE_RECALCULATION\x10\x04*\xe2\x02\n" +
+	"\x15ReputationEventReason\x12'\n" +
+	"#REPUTATION_EVENT_REASON_UNSPECIFIED\x10\x00\x12*\n" +
+	"&REPUTATION_EVENT_REASON_TASK_COMPLETED\x10\x01\x12/\n" +
+	"+REPUTATION_EVENT_REASON_EVENT_PARTICIPATION\x10\x02\x12.\n" +
+	"*REPUTATION_EVENT_REASON_MODERATION_PENALTY\x10\x03\x12&\n" +
+	"\"REPUTATION_EVENT_REASON_ADJUSTMENT\x10\x04\x12%\n" +
+	"!REPUTATION_EVENT_REASON_MIGRATION\x10\x05\x12!\n" +
+	"\x1dREPUTATION_EVENT_REASON_OTHER\x10\x06\x12!\n" +
+	"\x1dREPUTATION_EVENT_REASON_DECAY\x10\a*f\n" +
+	"\x0eLimitDirection\x12\x1f\n" +
+	"\x1bLIMIT_DIRECTION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14LIMIT_DIRECTION_EARN\x10\x01\x12\x19\n" +
+	"\x15LIMIT_DIRECTION_SPEND\x10\x02*8\n" +
 	"\x03Sex\x12\x13\n" +
 	"\x0fSEX_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bSEX_MALE\x10\x01\x12\x0e\n" +
@@ -1756,14 +8373,18 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\x06Status\x12\x16\n" +
 	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rSTATUS_ACTIVE\x10\x01\x12\x13\n" +
-	"\x0fSTATUS_INACTIVE\x10\x02*\xaf\x01\n" +
+	"\x0fSTATUS_INACTIVE\x10\x02*\xa8\x02\n" +
 	"\tErrorCode\x12\x1a\n" +
 	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15ERROR_CODE_VALIDATION\x10\x01\x12\x18\n" +
 	"\x14ERROR_CODE_NOT_FOUND\x10\x02\x12\x17\n" +
 	"\x13ERROR_CODE_INTERNAL\x10\x03\x12\x1d\n" +
 	"\x19ERROR_CODE_ALREADY_EXISTS\x10\x04\x12\x19\n" +
-	"\x15ERROR_CODE_NOT_ENOUGH\x10\x052\x89\x06\n" +
+	"\x15ERROR_CODE_NOT_ENOUGH\x10\x05\x12%\n" +
+	"!ERROR_CODE_IDEMPOTENCY_KEY_REUSED\x10\x06\x12\x17\n" +
+	"\x13ERROR_CODE_CONFLICT\x10\a\x12\x1d\n" +
+	"\x19ERROR_CODE_LIMIT_EXCEEDED\x10\b\x12\x18\n" +
+	"\x14ERROR_CODE_FORBIDDEN\x10\t2\xd9\x19\n" +
 	"\vUserService\x12?\n" +
 	"\n" +
 	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x18.user.CreateUserResponse\x129\n" +
@@ -1774,11 +8395,40 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\n" +
 	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x18.user.DeleteUserResponse\x12Z\n" +
 	"\x13GetReputationGroups\x12 .user.GetReputationGroupsRequest\x1a!.user.GetReputationGroupsResponse\x12c\n" +
-	"\x16GetReputationGroupByID\x12#.user.GetReputationGroupByIDRequest\x1a$.user.GetReputationGroupByIDResponse\x12?\n" +
+	"\x16GetReputationGroupByID\x12#.user.GetReputationGroupByIDRequest\x1a$.user.GetReputationGroupByIDResponse\x12i\n" +
+	"\x18GetReputationGroupLimits\x12%.user.GetReputationGroupLimitsRequest\x1a&.user.GetReputationGroupLimitsResponse\x12i\n" +
+	"\x18SetReputationGroupLimits\x12%.user.SetReputationGroupLimitsRequest\x1a&.user.SetReputationGroupLimitsResponse\x12W\n" +
+	"\x12AddReputationEvent\x12\x1f.user.AddReputationEventRequest\x1a .user.AddReputationEventResponse\x12H\n" +
+	"\rGetReputation\x12\x1a.user.GetReputationRequest\x1a\x1b.user.GetReputationResponse\x12]\n" +
+	"\x14GetReputationHistory\x12!.user.GetReputationHistoryRequest\x1a\".user.GetReputationHistoryResponse\x12`\n" +
+	"\x15CreateReputationGroup\x12\".user.CreateReputationGroupRequest\x1a#.user.CreateReputationGroupResponse\x12`\n" +
+	"\x15UpdateReputationGroup\x12\".user.UpdateReputationGroupRequest\x1a#.user.UpdateReputationGroupResponse\x12`\n" +
+	"\x15DeleteReputationGroup\x12\".user.DeleteReputationGroupRequest\x1a#.user.DeleteReputationGroupResponse\x12r\n" +
+	"\x1bRecalculateReputationGroups\x12(.user.RecalculateReputationGroupsRequest\x1a).user.RecalculateReputationGroupsResponse\x12?\n" +
 	"\n" +
 	"GetBalance\x12\x17.user.GetBalanceRequest\x1a\x18.user.GetBalanceResponse\x12]\n" +
-	"\x14GetBalanceOperations\x12!.user.GetBalanceOperationsRequest\x1a\".user.GetBalanceOperationsResponse\x12N\n" +
-	"\x0fCreateOperation\x12\x1c.user.CreateOperationRequest\x1a\x1d.user.CreateOperationResponseB7Z5DobrikaDev/user-service/internal/generated/proto/userb\x06proto3"
+	"\x14GetBalanceOperations\x12!.user.GetBalanceOperationsRequest\x1a\".user.GetBalanceOperationsResponse\x12l\n" +
+	"\x19GetBalanceOperationTotals\x12&.user.GetBalanceOperationTotalsRequest\x1a'.user.GetBalanceOperationTotalsResponse\x12E\n" +
+	"\fGetBalanceAt\x12\x19.user.GetBalanceAtRequest\x1a\x1a.user.GetBalanceAtResponse\x12G\n" +
+	"\fWatchBalance\x12\x19.user.WatchBalanceRequest\x1a\x1a.user.WatchBalanceResponse0\x01\x12T\n" +
+	"\x11GetBalanceHistory\x12\x1e.user.GetBalanceHistoryRequest\x1a\x1f.user.GetBalanceHistoryResponse\x12N\n" +
+	"\x0fGetBalanceStats\x12\x1c.user.GetBalanceStatsRequest\x1a\x1d.user.GetBalanceStatsResponse\x12N\n" +
+	"\x0fCreateOperation\x12\x1c.user.CreateOperationRequest\x1a\x1d.user.CreateOperationResponse\x12`\n" +
+	"\x15CreateOperationsBatch\x12\".user.CreateOperationsBatchRequest\x1a#.user.CreateOperationsBatchResponse\x12K\n" +
+	"\x0eTransferPoints\x12\x1b.user.TransferPointsRequest\x1a\x1c.user.TransferPointsResponse\x12Q\n" +
+	"\x10ReverseOperation\x12\x1d.user.ReverseOperationRequest\x1a\x1e.user.ReverseOperationResponse\x12K\n" +
+	"\x0eGetLeaderboard\x12\x1b.user.GetLeaderboardRequest\x1a\x1c.user.GetLeaderboardResponse\x12N\n" +
+	"\x0fGetTrialBalance\x12\x1c.user.GetTrialBalanceRequest\x1a\x1d.user.GetTrialBalanceResponse\x12T\n" +
+	"\x11ReconcileBalances\x12\x1e.user.ReconcileBalancesRequest\x1a\x1f.user.ReconcileBalancesResponse\x12?\n" +
+	"\n" +
+	"CreateHold\x12\x17.user.CreateHoldRequest\x1a\x18.user.CreateHoldResponse\x12B\n" +
+	"\vCaptureHold\x12\x18.user.CaptureHoldRequest\x1a\x19.user.CaptureHoldResponse\x12B\n" +
+	"\vReleaseHold\x12\x18.user.ReleaseHoldRequest\x1a\x19.user.ReleaseHoldResponse\x12T\n" +
+	"\x11ScheduleOperation\x12\x1e.user.ScheduleOperationRequest\x1a\x1f.user.ScheduleOperationResponse\x12f\n" +
+	"\x17ListScheduledOperations\x12$.user.ListScheduledOperationsRequest\x1a%.user.ListScheduledOperationsResponse\x12f\n" +
+	"\x17PauseScheduledOperation\x12$.user.PauseScheduledOperationRequest\x1a%.user.PauseScheduledOperationResponse\x12i\n" +
+	"\x18ResumeScheduledOperation\x12%.user.ResumeScheduledOperationRequest\x1a&.user.ResumeScheduledOperationResponse\x12i\n" +
+	"\x18CancelScheduledOperation\x12%.user.CancelScheduledOperationRequest\x1a&.user.CancelScheduledOperationResponseB7Z5DobrikaDev/user-service/internal/generated/proto/userb\x06proto3"
 
 var (
 	file_proto_user_user_proto_rawDescOnce sync.Once
@@ -1792,94 +8442,350 @@ func file_proto_user_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_user_proto_rawDescData
 }
 
-var file_proto_user_user_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_proto_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_proto_user_user_proto_enumTypes = make([]protoimpl.EnumInfo, 18)
+var file_proto_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 100)
 var file_proto_user_user_proto_goTypes = []any{
-	(BalanceOperationType)(0),              // 0: user.BalanceOperationType
-	(Sex)(0),                               // 1: user.Sex
-	(Role)(0),                              // 2: user.Role
-	(Status)(0),                            // 3: user.Status
-	(ErrorCode)(0),                         // 4: user.ErrorCode
-	(*GetBalanceRequest)(nil),              // 5: user.GetBalanceRequest
-	(*GetBalanceResponse)(nil),             // 6: user.GetBalanceResponse
-	(*GetBalanceOperationsRequest)(nil),    // 7: user.GetBalanceOperationsRequest
-	(*GetBalanceOperationsResponse)(nil),   // 8: user.GetBalanceOperationsResponse
-	(*CreateOperationRequest)(nil),         // 9: user.CreateOperationRequest
-	(*CreateOperationResponse)(nil),        // 10: user.CreateOperationResponse
-	(*BalanceOperation)(nil),               // 11: user.BalanceOperation
-	(*User)(nil),                           // 12: user.User
-	(*ReputationGroup)(nil),                // 13: user.ReputationGroup
-	(*GetReputationGroupsRequest)(nil),     // 14: user.GetReputationGroupsRequest
-	(*GetReputationGroupsResponse)(nil),    // 15: user.GetReputationGroupsResponse
-	(*GetReputationGroupByIDRequest)(nil),  // 16: user.GetReputationGroupByIDRequest
-	(*GetReputationGroupByIDResponse)(nil), // 17: user.GetReputationGroupByIDResponse
-	(*CreateUserRequest)(nil),              // 18: user.CreateUserRequest
-	(*GetUsersRequest)(nil),                // 19: user.GetUsersRequest
-	(*GetUsersResponse)(nil),               // 20: user.GetUsersResponse
-	(*GetUserByMaxIDRequest)(nil),          // 21: user.GetUserByMaxIDRequest
-	(*GetUserByMaxIDResponse)(nil),         // 22: user.GetUserByMaxIDResponse
-	(*UpdateUserRequest)(nil),              // 23: user.UpdateUserRequest
-	(*UpdateUserResponse)(nil),             // 24: user.UpdateUserResponse
-	(*DeleteUserRequest)(nil),              // 25: user.DeleteUserRequest
-	(*DeleteUserResponse)(nil),             // 26: user.DeleteUserResponse
-	(*CreateUserResponse)(nil),             // 27: user.CreateUserResponse
-	(*Error)(nil),                          // 28: user.Error
+	(BalanceHistoryGranularity)(0),              // 0: user.BalanceHistoryGranularity
+	(BalanceStatsGroupBy)(0),                    // 1: user.BalanceStatsGroupBy
+	(BatchMode)(0),                              // 2: user.BatchMode
+	(LeaderboardPeriod)(0),                      // 3: user.LeaderboardPeriod
+	(LeaderboardMetric)(0),                      // 4: user.LeaderboardMetric
+	(ScheduledOperationStatus)(0),               // 5: user.ScheduledOperationStatus
+	(HoldStatus)(0),                             // 6: user.HoldStatus
+	(LedgerAccountKind)(0),                      // 7: user.LedgerAccountKind
+	(BalanceOperationReason)(0),                 // 8: user.BalanceOperationReason
+	(WalletType)(0),                             // 9: user.WalletType
+	(BalanceOperationType)(0),                   // 10: user.BalanceOperationType
+	(ReputationChangeCause)(0),                  // 11: user.ReputationChangeCause
+	(ReputationEventReason)(0),                  // 12: user.ReputationEventReason
+	(LimitDirection)(0),                         // 13: user.LimitDirection
+	(Sex)(0),                                    // 14: user.Sex
+	(Role)(0),                                   // 15: user.Role
+	(Status)(0),                                 // 16: user.Status
+	(ErrorCode)(0),                              // 17: user.ErrorCode
+	(*GetBalanceRequest)(nil),                   // 18: user.GetBalanceRequest
+	(*GetBalanceResponse)(nil),                  // 19: user.GetBalanceResponse
+	(*ExpiringPoints)(nil),                      // 20: user.ExpiringPoints
+	(*WatchBalanceRequest)(nil),                 // 21: user.WatchBalanceRequest
+	(*WatchBalanceResponse)(nil),                // 22: user.WatchBalanceResponse
+	(*GetBalanceAtRequest)(nil),                 // 23: user.GetBalanceAtRequest
+	(*GetBalanceAtResponse)(nil),                // 24: user.GetBalanceAtResponse
+	(*GetBalanceHistoryRequest)(nil),            // 25: user.GetBalanceHistoryRequest
+	(*GetBalanceHistoryResponse)(nil),           // 26: user.GetBalanceHistoryResponse
+	(*BalanceHistoryPoint)(nil),                 // 27: user.BalanceHistoryPoint
+	(*GetBalanceStatsRequest)(nil),              // 28: user.GetBalanceStatsRequest
+	(*GetBalanceStatsResponse)(nil),             // 29: user.GetBalanceStatsResponse
+	(*BalanceStatsMonth)(nil),                   // 30: user.BalanceStatsMonth
+	(*BalanceStatsGroup)(nil),                   // 31: user.BalanceStatsGroup
+	(*GetBalanceOperationsRequest)(nil),         // 32: user.GetBalanceOperationsRequest
+	(*GetBalanceOperationsResponse)(nil),        // 33: user.GetBalanceOperationsResponse
+	(*GetBalanceOperationTotalsRequest)(nil),    // 34: user.GetBalanceOperationTotalsRequest
+	(*GetBalanceOperationTotalsResponse)(nil),   // 35: user.GetBalanceOperationTotalsResponse
+	(*BalanceOperationReasonTotals)(nil),        // 36: user.BalanceOperationReasonTotals
+	(*CreateOperationRequest)(nil),              // 37: user.CreateOperationRequest
+	(*CreateOperationResponse)(nil),             // 38: user.CreateOperationResponse
+	(*CreateOperationsBatchRequest)(nil),        // 39: user.CreateOperationsBatchRequest
+	(*CreateOperationsBatchResponse)(nil),       // 40: user.CreateOperationsBatchResponse
+	(*BatchOperationItem)(nil),                  // 41: user.BatchOperationItem
+	(*BatchOperationResult)(nil),                // 42: user.BatchOperationResult
+	(*TransferPointsRequest)(nil),               // 43: user.TransferPointsRequest
+	(*TransferPointsResponse)(nil),              // 44: user.TransferPointsResponse
+	(*GetLeaderboardRequest)(nil),               // 45: user.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),              // 46: user.GetLeaderboardResponse
+	(*LeaderboardEntry)(nil),                    // 47: user.LeaderboardEntry
+	(*ReconcileBalancesRequest)(nil),            // 48: user.ReconcileBalancesRequest
+	(*ReconcileBalancesResponse)(nil),           // 49: user.ReconcileBalancesResponse
+	(*BalanceMismatch)(nil),                     // 50: user.BalanceMismatch
+	(*CreateHoldRequest)(nil),                   // 51: user.CreateHoldRequest
+	(*CreateHoldResponse)(nil),                  // 52: user.CreateHoldResponse
+	(*CaptureHoldRequest)(nil),                  // 53: user.CaptureHoldRequest
+	(*CaptureHoldResponse)(nil),                 // 54: user.CaptureHoldResponse
+	(*ReleaseHoldRequest)(nil),                  // 55: user.ReleaseHoldRequest
+	(*ReleaseHoldResponse)(nil),                 // 56: user.ReleaseHoldResponse
+	(*ScheduleOperationRequest)(nil),            // 57: user.ScheduleOperationRequest
+	(*ScheduleOperationResponse)(nil),           // 58: user.ScheduleOperationResponse
+	(*ListScheduledOperationsRequest)(nil),      // 59: user.ListScheduledOperationsRequest
+	(*ListScheduledOperationsResponse)(nil),     // 60: user.ListScheduledOperationsResponse
+	(*PauseScheduledOperationRequest)(nil),      // 61: user.PauseScheduledOperationRequest
+	(*PauseScheduledOperationResponse)(nil),     // 62: user.PauseScheduledOperationResponse
+	(*ResumeScheduledOperationRequest)(nil),     // 63: user.ResumeScheduledOperationRequest
+	(*ResumeScheduledOperationResponse)(nil),    // 64: user.ResumeScheduledOperationResponse
+	(*CancelScheduledOperationRequest)(nil),     // 65: user.CancelScheduledOperationRequest
+	(*CancelScheduledOperationResponse)(nil),    // 66: user.CancelScheduledOperationResponse
+	(*ScheduledOperation)(nil),                  // 67: user.ScheduledOperation
+	(*Hold)(nil),                                // 68: user.Hold
+	(*GetTrialBalanceRequest)(nil),              // 69: user.GetTrialBalanceRequest
+	(*GetTrialBalanceResponse)(nil),             // 70: user.GetTrialBalanceResponse
+	(*LedgerWalletMismatch)(nil),                // 71: user.LedgerWalletMismatch
+	(*TrialBalanceLine)(nil),                    // 72: user.TrialBalanceLine
+	(*ReverseOperationRequest)(nil),             // 73: user.ReverseOperationRequest
+	(*ReverseOperationResponse)(nil),            // 74: user.ReverseOperationResponse
+	(*BalanceOperation)(nil),                    // 75: user.BalanceOperation
+	(*BalanceOperationMetadata)(nil),            // 76: user.BalanceOperationMetadata
+	(*User)(nil),                                // 77: user.User
+	(*ReputationGroup)(nil),                     // 78: user.ReputationGroup
+	(*GetReputationGroupsRequest)(nil),          // 79: user.GetReputationGroupsRequest
+	(*GetReputationGroupsResponse)(nil),         // 80: user.GetReputationGroupsResponse
+	(*GetReputationGroupByIDRequest)(nil),       // 81: user.GetReputationGroupByIDRequest
+	(*GetReputationGroupByIDResponse)(nil),      // 82: user.GetReputationGroupByIDResponse
+	(*AddReputationEventRequest)(nil),           // 83: user.AddReputationEventRequest
+	(*AddReputationEventResponse)(nil),          // 84: user.AddReputationEventResponse
+	(*GetReputationRequest)(nil),                // 85: user.GetReputationRequest
+	(*GetReputationResponse)(nil),               // 86: user.GetReputationResponse
+	(*Reputation)(nil),                          // 87: user.Reputation
+	(*ReputationEvent)(nil),                     // 88: user.ReputationEvent
+	(*GetReputationHistoryRequest)(nil),         // 89: user.GetReputationHistoryRequest
+	(*GetReputationHistoryResponse)(nil),        // 90: user.GetReputationHistoryResponse
+	(*ReputationGroupChange)(nil),               // 91: user.ReputationGroupChange
+	(*CreateReputationGroupRequest)(nil),        // 92: user.CreateReputationGroupRequest
+	(*CreateReputationGroupResponse)(nil),       // 93: user.CreateReputationGroupResponse
+	(*UpdateReputationGroupRequest)(nil),        // 94: user.UpdateReputationGroupRequest
+	(*UpdateReputationGroupResponse)(nil),       // 95: user.UpdateReputationGroupResponse
+	(*DeleteReputationGroupRequest)(nil),        // 96: user.DeleteReputationGroupRequest
+	(*DeleteReputationGroupResponse)(nil),       // 97: user.DeleteReputationGroupResponse
+	(*RecalculateReputationGroupsRequest)(nil),  // 98: user.RecalculateReputationGroupsRequest
+	(*RecalculateReputationGroupsResponse)(nil), // 99: user.RecalculateReputationGroupsResponse
+	(*ReputationGroupMove)(nil),                 // 100: user.ReputationGroupMove
+	(*GetReputationGroupLimitsRequest)(nil),     // 101: user.GetReputationGroupLimitsRequest
+	(*GetReputationGroupLimitsResponse)(nil),    // 102: user.GetReputationGroupLimitsResponse
+	(*SetReputationGroupLimitsRequest)(nil),     // 103: user.SetReputationGroupLimitsRequest
+	(*SetReputationGroupLimitsResponse)(nil),    // 104: user.SetReputationGroupLimitsResponse
+	(*ReputationGroupLimit)(nil),                // 105: user.ReputationGroupLimit
+	(*CreateUserRequest)(nil),                   // 106: user.CreateUserRequest
+	(*GetUsersRequest)(nil),                     // 107: user.GetUsersRequest
+	(*GetUsersResponse)(nil),                    // 108: user.GetUsersResponse
+	(*GetUserByMaxIDRequest)(nil),               // 109: user.GetUserByMaxIDRequest
+	(*GetUserByMaxIDResponse)(nil),              // 110: user.GetUserByMaxIDResponse
+	(*UpdateUserRequest)(nil),                   // 111: user.UpdateUserRequest
+	(*UpdateUserResponse)(nil),                  // 112: user.UpdateUserResponse
+	(*DeleteUserRequest)(nil),                   // 113: user.DeleteUserRequest
+	(*DeleteUserResponse)(nil),                  // 114: user.DeleteUserResponse
+	(*CreateUserResponse)(nil),                  // 115: user.CreateUserResponse
+	(*Error)(nil),                               // 116: user.Error
+	nil,                                         // 117: user.BalanceOperationMetadata.ExtraEntry
 }
 var file_proto_user_user_proto_depIdxs = []int32{
-	28, // 0: user.GetBalanceResponse.error:type_name -> user.Error
-	11, // 1: user.GetBalanceOperationsResponse.operations:type_name -> user.BalanceOperation
-	28, // 2: user.GetBalanceOperationsResponse.error:type_name -> user.Error
-	0,  // 3: user.CreateOperationRequest.type:type_name -> user.BalanceOperationType
-	11, // 4: user.CreateOperationResponse.operation:type_name -> user.BalanceOperation
-	28, // 5: user.CreateOperationResponse.error:type_name -> user.Error
-	0,  // 6: user.BalanceOperation.type:type_name -> user.BalanceOperationType
-	1,  // 7: user.User.sex:type_name -> user.Sex
-	2,  // 8: user.User.role:type_name -> user.Role
-	3,  // 9: user.User.status:type_name -> user.Status
-	13, // 10: user.User.reputation_group:type_name -> user.ReputationGroup
-	13, // 11: user.GetReputationGroupsResponse.reputation_groups:type_name -> user.ReputationGroup
-	28, // 12: user.GetReputationGroupsResponse.error:type_name -> user.Error
-	13, // 13: user.GetReputationGroupByIDResponse.reputation_group:type_name -> user.ReputationGroup
-	28, // 14: user.GetReputationGroupByIDResponse.error:type_name -> user.Error
-	12, // 15: user.CreateUserRequest.user:type_name -> user.User
-	3,  // 16: user.GetUsersRequest.status:type_name -> user.Status
-	2,  // 17: user.GetUsersRequest.role:type_name -> user.Role
-	12, // 18: user.GetUsersResponse.users:type_name -> user.User
-	28, // 19: user.GetUsersResponse.error:type_name -> user.Error
-	12, // 20: user.GetUserByMaxIDResponse.user:type_name -> user.User
-	28, // 21: user.GetUserByMaxIDResponse.error:type_name -> user.Error
-	12, // 22: user.UpdateUserRequest.user:type_name -> user.User
-	12, // 23: user.UpdateUserResponse.user:type_name -> user.User
-	28, // 24: user.UpdateUserResponse.error:type_name -> user.Error
-	28, // 25: user.DeleteUserResponse.error:type_name -> user.Error
-	12, // 26: user.CreateUserResponse.user:type_name -> user.User
-	28, // 27: user.CreateUserResponse.error:type_name -> user.Error
-	4,  // 28: user.Error.code:type_name -> user.ErrorCode
-	18, // 29: user.UserService.CreateUser:input_type -> user.CreateUserRequest
-	19, // 30: user.UserService.GetUsers:input_type -> user.GetUsersRequest
-	21, // 31: user.UserService.GetUserByMaxID:input_type -> user.GetUserByMaxIDRequest
-	23, // 32: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	25, // 33: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
-	14, // 34: user.UserService.GetReputationGroups:input_type -> user.GetReputationGroupsRequest
-	16, // 35: user.UserService.GetReputationGroupByID:input_type -> user.GetReputationGroupByIDRequest
-	5,  // 36: user.UserService.GetBalance:input_type -> user.GetBalanceRequest
-	7,  // 37: user.UserService.GetBalanceOperations:input_type -> user.GetBalanceOperationsRequest
-	9,  // 38: user.UserService.CreateOperation:input_type -> user.CreateOperationRequest
-	27, // 39: user.UserService.CreateUser:output_type -> user.CreateUserResponse
-	20, // 40: user.UserService.GetUsers:output_type -> user.GetUsersResponse
-	22, // 41: user.UserService.GetUserByMaxID:output_type -> user.GetUserByMaxIDResponse
-	24, // 42: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
-	26, // 43: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
-	15, // 44: user.UserService.GetReputationGroups:output_type -> user.GetReputationGroupsResponse
-	17, // 45: user.UserService.GetReputationGroupByID:output_type -> user.GetReputationGroupByIDResponse
-	6,  // 46: user.UserService.GetBalance:output_type -> user.GetBalanceResponse
-	8,  // 47: user.UserService.GetBalanceOperations:output_type -> user.GetBalanceOperationsResponse
-	10, // 48: user.UserService.CreateOperation:output_type -> user.CreateOperationResponse
-	39, // [39:49] is the sub-list for method output_type
-	29, // [29:39] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	9,   // 0: user.GetBalanceRequest.wallet_type:type_name -> user.WalletType
+	116, // 1: user.GetBalanceResponse.error:type_name -> user.Error
+	20,  // 2: user.GetBalanceResponse.expiring_soon:type_name -> user.ExpiringPoints
+	9,   // 3: user.GetBalanceResponse.wallet_type:type_name -> user.WalletType
+	9,   // 4: user.WatchBalanceRequest.wallet_type:type_name -> user.WalletType
+	75,  // 5: user.WatchBalanceResponse.operation:type_name -> user.BalanceOperation
+	116, // 6: user.WatchBalanceResponse.error:type_name -> user.Error
+	9,   // 7: user.GetBalanceAtRequest.wallet_type:type_name -> user.WalletType
+	116, // 8: user.GetBalanceAtResponse.error:type_name -> user.Error
+	0,   // 9: user.GetBalanceHistoryRequest.granularity:type_name -> user.BalanceHistoryGranularity
+	9,   // 10: user.GetBalanceHistoryRequest.wallet_type:type_name -> user.WalletType
+	27,  // 11: user.GetBalanceHistoryResponse.points:type_name -> user.BalanceHistoryPoint
+	116, // 12: user.GetBalanceHistoryResponse.error:type_name -> user.Error
+	9,   // 13: user.GetBalanceStatsRequest.wallet_type:type_name -> user.WalletType
+	1,   // 14: user.GetBalanceStatsRequest.group_by:type_name -> user.BalanceStatsGroupBy
+	30,  // 15: user.GetBalanceStatsResponse.months:type_name -> user.BalanceStatsMonth
+	31,  // 16: user.GetBalanceStatsResponse.groups:type_name -> user.BalanceStatsGroup
+	116, // 17: user.GetBalanceStatsResponse.error:type_name -> user.Error
+	10,  // 18: user.GetBalanceOperationsRequest.types:type_name -> user.BalanceOperationType
+	8,   // 19: user.GetBalanceOperationsRequest.reason_codes:type_name -> user.BalanceOperationReason
+	9,   // 20: user.GetBalanceOperationsRequest.wallet_type:type_name -> user.WalletType
+	75,  // 21: user.GetBalanceOperationsResponse.operations:type_name -> user.BalanceOperation
+	116, // 22: user.GetBalanceOperationsResponse.error:type_name -> user.Error
+	9,   // 23: user.GetBalanceOperationTotalsRequest.wallet_type:type_name -> user.WalletType
+	36,  // 24: user.GetBalanceOperationTotalsResponse.totals:type_name -> user.BalanceOperationReasonTotals
+	116, // 25: user.GetBalanceOperationTotalsResponse.error:type_name -> user.Error
+	8,   // 26: user.BalanceOperationReasonTotals.reason_code:type_name -> user.BalanceOperationReason
+	10,  // 27: user.CreateOperationRequest.type:type_name -> user.BalanceOperationType
+	8,   // 28: user.CreateOperationRequest.reason_code:type_name -> user.BalanceOperationReason
+	76,  // 29: user.CreateOperationRequest.metadata:type_name -> user.BalanceOperationMetadata
+	9,   // 30: user.CreateOperationRequest.wallet_type:type_name -> user.WalletType
+	75,  // 31: user.CreateOperationResponse.operation:type_name -> user.BalanceOperation
+	116, // 32: user.CreateOperationResponse.error:type_name -> user.Error
+	41,  // 33: user.CreateOperationsBatchRequest.items:type_name -> user.BatchOperationItem
+	2,   // 34: user.CreateOperationsBatchRequest.mode:type_name -> user.BatchMode
+	42,  // 35: user.CreateOperationsBatchResponse.results:type_name -> user.BatchOperationResult
+	116, // 36: user.CreateOperationsBatchResponse.error:type_name -> user.Error
+	10,  // 37: user.BatchOperationItem.type:type_name -> user.BalanceOperationType
+	8,   // 38: user.BatchOperationItem.reason_code:type_name -> user.BalanceOperationReason
+	76,  // 39: user.BatchOperationItem.metadata:type_name -> user.BalanceOperationMetadata
+	9,   // 40: user.BatchOperationItem.wallet_type:type_name -> user.WalletType
+	75,  // 41: user.BatchOperationResult.operation:type_name -> user.BalanceOperation
+	116, // 42: user.BatchOperationResult.error:type_name -> user.Error
+	75,  // 43: user.TransferPointsResponse.from_operation:type_name -> user.BalanceOperation
+	75,  // 44: user.TransferPointsResponse.to_operation:type_name -> user.BalanceOperation
+	116, // 45: user.TransferPointsResponse.error:type_name -> user.Error
+	3,   // 46: user.GetLeaderboardRequest.period:type_name -> user.LeaderboardPeriod
+	4,   // 47: user.GetLeaderboardRequest.metric:type_name -> user.LeaderboardMetric
+	47,  // 48: user.GetLeaderboardResponse.entries:type_name -> user.LeaderboardEntry
+	47,  // 49: user.GetLeaderboardResponse.caller:type_name -> user.LeaderboardEntry
+	116, // 50: user.GetLeaderboardResponse.error:type_name -> user.Error
+	50,  // 51: user.ReconcileBalancesResponse.mismatches:type_name -> user.BalanceMismatch
+	116, // 52: user.ReconcileBalancesResponse.error:type_name -> user.Error
+	68,  // 53: user.CreateHoldResponse.hold:type_name -> user.Hold
+	116, // 54: user.CreateHoldResponse.error:type_name -> user.Error
+	68,  // 55: user.CaptureHoldResponse.hold:type_name -> user.Hold
+	75,  // 56: user.CaptureHoldResponse.operation:type_name -> user.BalanceOperation
+	116, // 57: user.CaptureHoldResponse.error:type_name -> user.Error
+	68,  // 58: user.ReleaseHoldResponse.hold:type_name -> user.Hold
+	116, // 59: user.ReleaseHoldResponse.error:type_name -> user.Error
+	10,  // 60: user.ScheduleOperationRequest.type:type_name -> user.BalanceOperationType
+	8,   // 61: user.ScheduleOperationRequest.reason_code:type_name -> user.BalanceOperationReason
+	76,  // 62: user.ScheduleOperationRequest.metadata:type_name -> user.BalanceOperationMetadata
+	9,   // 63: user.ScheduleOperationRequest.wallet_type:type_name -> user.WalletType
+	67,  // 64: user.ScheduleOperationResponse.scheduled_operation:type_name -> user.ScheduledOperation
+	116, // 65: user.ScheduleOperationResponse.error:type_name -> user.Error
+	5,   // 66: user.ListScheduledOperationsRequest.statuses:type_name -> user.ScheduledOperationStatus
+	67,  // 67: user.ListScheduledOperationsResponse.scheduled_operations:type_name -> user.ScheduledOperation
+	116, // 68: user.ListScheduledOperationsResponse.error:type_name -> user.Error
+	67,  // 69: user.PauseScheduledOperationResponse.scheduled_operation:type_name -> user.ScheduledOperation
+	116, // 70: user.PauseScheduledOperationResponse.error:type_name -> user.Error
+	67,  // 71: user.ResumeScheduledOperationResponse.scheduled_operation:type_name -> user.ScheduledOperation
+	116, // 72: user.ResumeScheduledOperationResponse.error:type_name -> user.Error
+	67,  // 73: user.CancelScheduledOperationResponse.scheduled_operation:type_name -> user.ScheduledOperation
+	116, // 74: user.CancelScheduledOperationResponse.error:type_name -> user.Error
+	9,   // 75: user.ScheduledOperation.wallet_type:type_name -> user.WalletType
+	10,  // 76: user.ScheduledOperation.type:type_name -> user.BalanceOperationType
+	8,   // 77: user.ScheduledOperation.reason_code:type_name -> user.BalanceOperationReason
+	76,  // 78: user.ScheduledOperation.metadata:type_name -> user.BalanceOperationMetadata
+	5,   // 79: user.ScheduledOperation.status:type_name -> user.ScheduledOperationStatus
+	6,   // 80: user.Hold.status:type_name -> user.HoldStatus
+	72,  // 81: user.GetTrialBalanceResponse.lines:type_name -> user.TrialBalanceLine
+	116, // 82: user.GetTrialBalanceResponse.error:type_name -> user.Error
+	71,  // 83: user.GetTrialBalanceResponse.wallet_mismatches:type_name -> user.LedgerWalletMismatch
+	7,   // 84: user.TrialBalanceLine.kind:type_name -> user.LedgerAccountKind
+	75,  // 85: user.ReverseOperationResponse.operation:type_name -> user.BalanceOperation
+	116, // 86: user.ReverseOperationResponse.error:type_name -> user.Error
+	10,  // 87: user.BalanceOperation.type:type_name -> user.BalanceOperationType
+	8,   // 88: user.BalanceOperation.reason_code:type_name -> user.BalanceOperationReason
+	76,  // 89: user.BalanceOperation.metadata:type_name -> user.BalanceOperationMetadata
+	9,   // 90: user.BalanceOperation.wallet_type:type_name -> user.WalletType
+	117, // 91: user.BalanceOperationMetadata.extra:type_name -> user.BalanceOperationMetadata.ExtraEntry
+	14,  // 92: user.User.sex:type_name -> user.Sex
+	15,  // 93: user.User.role:type_name -> user.Role
+	16,  // 94: user.User.status:type_name -> user.Status
+	78,  // 95: user.User.reputation_group:type_name -> user.ReputationGroup
+	78,  // 96: user.GetReputationGroupsResponse.reputation_groups:type_name -> user.ReputationGroup
+	116, // 97: user.GetReputationGroupsResponse.error:type_name -> user.Error
+	78,  // 98: user.GetReputationGroupByIDResponse.reputation_group:type_name -> user.ReputationGroup
+	116, // 99: user.GetReputationGroupByIDResponse.error:type_name -> user.Error
+	12,  // 100: user.AddReputationEventRequest.reason:type_name -> user.ReputationEventReason
+	88,  // 101: user.AddReputationEventResponse.event:type_name -> user.ReputationEvent
+	87,  // 102: user.AddReputationEventResponse.reputation:type_name -> user.Reputation
+	116, // 103: user.AddReputationEventResponse.error:type_name -> user.Error
+	87,  // 104: user.GetReputationResponse.reputation:type_name -> user.Reputation
+	88,  // 105: user.GetReputationResponse.events:type_name -> user.ReputationEvent
+	116, // 106: user.GetReputationResponse.error:type_name -> user.Error
+	78,  // 107: user.Reputation.reputation_group:type_name -> user.ReputationGroup
+	78,  // 108: user.Reputation.next_reputation_group:type_name -> user.ReputationGroup
+	12,  // 109: user.ReputationEvent.reason:type_name -> user.ReputationEventReason
+	91,  // 110: user.GetReputationHistoryResponse.changes:type_name -> user.ReputationGroupChange
+	116, // 111: user.GetReputationHistoryResponse.error:type_name -> user.Error
+	11,  // 112: user.ReputationGroupChange.cause:type_name -> user.ReputationChangeCause
+	78,  // 113: user.CreateReputationGroupResponse.reputation_group:type_name -> user.ReputationGroup
+	116, // 114: user.CreateReputationGroupResponse.error:type_name -> user.Error
+	78,  // 115: user.UpdateReputationGroupResponse.reputation_group:type_name -> user.ReputationGroup
+	116, // 116: user.UpdateReputationGroupResponse.error:type_name -> user.Error
+	116, // 117: user.DeleteReputationGroupResponse.error:type_name -> user.Error
+	100, // 118: user.RecalculateReputationGroupsResponse.moves:type_name -> user.ReputationGroupMove
+	116, // 119: user.RecalculateReputationGroupsResponse.error:type_name -> user.Error
+	105, // 120: user.GetReputationGroupLimitsResponse.limits:type_name -> user.ReputationGroupLimit
+	116, // 121: user.GetReputationGroupLimitsResponse.error:type_name -> user.Error
+	105, // 122: user.SetReputationGroupLimitsRequest.limits:type_name -> user.ReputationGroupLimit
+	105, // 123: user.SetReputationGroupLimitsResponse.limits:type_name -> user.ReputationGroupLimit
+	116, // 124: user.SetReputationGroupLimitsResponse.error:type_name -> user.Error
+	13,  // 125: user.ReputationGroupLimit.direction:type_name -> user.LimitDirection
+	77,  // 126: user.CreateUserRequest.user:type_name -> user.User
+	16,  // 127: user.GetUsersRequest.status:type_name -> user.Status
+	15,  // 128: user.GetUsersRequest.role:type_name -> user.Role
+	77,  // 129: user.GetUsersResponse.users:type_name -> user.User
+	116, // 130: user.GetUsersResponse.error:type_name -> user.Error
+	77,  // 131: user.GetUserByMaxIDResponse.user:type_name -> user.User
+	116, // 132: user.GetUserByMaxIDResponse.error:type_name -> user.Error
+	77,  // 133: user.UpdateUserRequest.user:type_name -> user.User
+	77,  // 134: user.UpdateUserResponse.user:type_name -> user.User
+	116, // 135: user.UpdateUserResponse.error:type_name -> user.Error
+	116, // 136: user.DeleteUserResponse.error:type_name -> user.Error
+	77,  // 137: user.CreateUserResponse.user:type_name -> user.User
+	116, // 138: user.CreateUserResponse.error:type_name -> user.Error
+	17,  // 139: user.Error.code:type_name -> user.ErrorCode
+	106, // 140: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	107, // 141: user.UserService.GetUsers:input_type -> user.GetUsersRequest
+	109, // 142: user.UserService.GetUserByMaxID:input_type -> user.GetUserByMaxIDRequest
+	111, // 143: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	113, // 144: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	79,  // 145: user.UserService.GetReputationGroups:input_type -> user.GetReputationGroupsRequest
+	81,  // 146: user.UserService.GetReputationGroupByID:input_type -> user.GetReputationGroupByIDRequest
+	101, // 147: user.UserService.GetReputationGroupLimits:input_type -> user.GetReputationGroupLimitsRequest
+	103, // 148: user.UserService.SetReputationGroupLimits:input_type -> user.SetReputationGroupLimitsRequest
+	83,  // 149: user.UserService.AddReputationEvent:input_type -> user.AddReputationEventRequest
+	85,  // 150: user.UserService.GetReputation:input_type -> user.GetReputationRequest
+	89,  // 151: user.UserService.GetReputationHistory:input_type -> user.GetReputationHistoryRequest
+	92,  // 152: user.UserService.CreateReputationGroup:input_type -> user.CreateReputationGroupRequest
+	94,  // 153: user.UserService.UpdateReputationGroup:input_type -> user.UpdateReputationGroupRequest
+	96,  // 154: user.UserService.DeleteReputationGroup:input_type -> user.DeleteReputationGroupRequest
+	98,  // 155: user.UserService.RecalculateReputationGroups:input_type -> user.RecalculateReputationGroupsRequest
+	18,  // 156: user.UserService.GetBalance:input_type -> user.GetBalanceRequest
+	32,  // 157: user.UserService.GetBalanceOperations:input_type -> user.GetBalanceOperationsRequest
+	34,  // 158: user.UserService.GetBalanceOperationTotals:input_type -> user.GetBalanceOperationTotalsRequest
+	23,  // 159: user.UserService.GetBalanceAt:input_type -> user.GetBalanceAtRequest
+	21,  // 160: user.UserService.WatchBalance:input_type -> user.WatchBalanceRequest
+	25,  // 161: user.UserService.GetBalanceHistory:input_type -> user.GetBalanceHistoryRequest
+	28,  // 162: user.UserService.GetBalanceStats:input_type -> user.GetBalanceStatsRequest
+	37,  // 163: user.UserService.CreateOperation:input_type -> user.CreateOperationRequest
+	39,  // 164: user.UserService.CreateOperationsBatch:input_type -> user.CreateOperationsBatchRequest
+	43,  // 165: user.UserService.TransferPoints:input_type -> user.TransferPointsRequest
+	73,  // 166: user.UserService.ReverseOperation:input_type -> user.ReverseOperationRequest
+	45,  // 167: user.UserService.GetLeaderboard:input_type -> user.GetLeaderboardRequest
+	69,  // 168: user.UserService.GetTrialBalance:input_type -> user.GetTrialBalanceRequest
+	48,  // 169: user.UserService.ReconcileBalances:input_type -> user.ReconcileBalancesRequest
+	51,  // 170: user.UserService.CreateHold:input_type -> user.CreateHoldRequest
+	53,  // 171: user.UserService.CaptureHold:input_type -> user.CaptureHoldRequest
+	55,  // 172: user.UserService.ReleaseHold:input_type -> user.ReleaseHoldRequest
+	57,  // 173: user.UserService.ScheduleOperation:input_type -> user.ScheduleOperationRequest
+	59,  // 174: user.UserService.ListScheduledOperations:input_type -> user.ListScheduledOperationsRequest
+	61,  // 175: user.UserService.PauseScheduledOperation:input_type -> user.PauseScheduledOperationRequest
+	63,  // 176: user.UserService.ResumeScheduledOperation:input_type -> user.ResumeScheduledOperationRequest
+	65,  // 177: user.UserService.CancelScheduledOperation:input_type -> user.CancelScheduledOperationRequest
+	115, // 178: user.UserService.CreateUser:output_type -> user.CreateUserResponse
+	108, // 179: user.UserService.GetUsers:output_type -> user.GetUsersResponse
+	110, // 180: user.UserService.GetUserByMaxID:output_type -> user.GetUserByMaxIDResponse
+	112, // 181: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
+	114, // 182: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
+	80,  // 183: user.UserService.GetReputationGroups:output_type -> user.GetReputationGroupsResponse
+	82,  // 184: user.UserService.GetReputationGroupByID:output_type -> user.GetReputationGroupByIDResponse
+	102, // 185: user.UserService.GetReputationGroupLimits:output_type -> user.GetReputationGroupLimitsResponse
+	104, // 186: user.UserService.SetReputationGroupLimits:output_type -> user.SetReputationGroupLimitsResponse
+	84,  // 187: user.UserService.AddReputationEvent:output_type -> user.AddReputationEventResponse
+	86,  // 188: user.UserService.GetReputation:output_type -> user.GetReputationResponse
+	90,  // 189: user.UserService.GetReputationHistory:output_type -> user.GetReputationHistoryResponse
+	93,  // 190: user.UserService.CreateReputationGroup:output_type -> user.CreateReputationGroupResponse
+	95,  // 191: user.UserService.UpdateReputationGroup:output_type -> user.UpdateReputationGroupResponse
+	97,  // 192: user.UserService.DeleteReputationGroup:output_type -> user.DeleteReputationGroupResponse
+	99,  // 193: user.UserService.RecalculateReputationGroups:output_type -> user.RecalculateReputationGroupsResponse
+	19,  // 194: user.UserService.GetBalance:output_type -> user.GetBalanceResponse
+	33,  // 195: user.UserService.GetBalanceOperations:output_type -> user.GetBalanceOperationsResponse
+	35,  // 196: user.UserService.GetBalanceOperationTotals:output_type -> user.GetBalanceOperationTotalsResponse
+	24,  // 197: user.UserService.GetBalanceAt:output_type -> user.GetBalanceAtResponse
+	22,  // 198: user.UserService.WatchBalance:output_type -> user.WatchBalanceResponse
+	26,  // 199: user.UserService.GetBalanceHistory:output_type -> user.GetBalanceHistoryResponse
+	29,  // 200: user.UserService.GetBalanceStats:output_type -> user.GetBalanceStatsResponse
+	38,  // 201: user.UserService.CreateOperation:output_type -> user.CreateOperationResponse
+	40,  // 202: user.UserService.CreateOperationsBatch:output_type -> user.CreateOperationsBatchResponse
+	44,  // 203: user.UserService.TransferPoints:output_type -> user.TransferPointsResponse
+	74,  // 204: user.UserService.ReverseOperation:output_type -> user.ReverseOperationResponse
+	46,  // 205: user.UserService.GetLeaderboard:output_type -> user.GetLeaderboardResponse
+	70,  // 206: user.UserService.GetTrialBalance:output_type -> user.GetTrialBalanceResponse
+	49,  // 207: user.UserService.ReconcileBalances:output_type -> user.ReconcileBalancesResponse
+	52,  // 208: user.UserService.CreateHold:output_type -> user.CreateHoldResponse
+	54,  // 209: user.UserService.CaptureHold:output_type -> user.CaptureHoldResponse
+	56,  // 210: user.UserService.ReleaseHold:output_type -> user.ReleaseHoldResponse
+	58,  // 211: user.UserService.ScheduleOperation:output_type -> user.ScheduleOperationResponse
+	60,  // 212: user.UserService.ListScheduledOperations:output_type -> user.ListScheduledOperationsResponse
+	62,  // 213: user.UserService.PauseScheduledOperation:output_type -> user.PauseScheduledOperationResponse
+	64,  // 214: user.UserService.ResumeScheduledOperation:output_type -> user.ResumeScheduledOperationResponse
+	66,  // 215: user.UserService.CancelScheduledOperation:output_type -> user.CancelScheduledOperationResponse
+	178, // [178:216] is the sub-list for method output_type
+	140, // [140:178] is the sub-list for method input_type
+	140, // [140:140] is the sub-list for extension type_name
+	140, // [140:140] is the sub-list for extension extendee
+	0,   // [0:140] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_user_proto_init() }
@@ -1887,13 +8793,14 @@ func file_proto_user_user_proto_init() {
 	if File_proto_user_user_proto != nil {
 		return
 	}
+	file_proto_user_user_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_user_proto_rawDesc), len(file_proto_user_user_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   24,
+			NumEnums:      18,
+			NumMessages:   100,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
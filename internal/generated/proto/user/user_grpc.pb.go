@@ -19,16 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_CreateUser_FullMethodName             = "/user.UserService/CreateUser"
-	UserService_GetUsers_FullMethodName               = "/user.UserService/GetUsers"
-	UserService_GetUserByMaxID_FullMethodName         = "/user.UserService/GetUserByMaxID"
-	UserService_UpdateUser_FullMethodName             = "/user.UserService/UpdateUser"
-	UserService_DeleteUser_FullMethodName             = "/user.UserService/DeleteUser"
-	UserService_GetReputationGroups_FullMethodName    = "/user.UserService/GetReputationGroups"
-	UserService_GetReputationGroupByID_FullMethodName = "/user.UserService/GetReputationGroupByID"
-	UserService_GetBalance_FullMethodName             = "/user.UserService/GetBalance"
-	UserService_GetBalanceOperations_FullMethodName   = "/user.UserService/GetBalanceOperations"
-	UserService_CreateOperation_FullMethodName        = "/user.UserService/CreateOperation"
+	UserService_CreateUser_FullMethodName                  = "/user.UserService/CreateUser"
+	UserService_GetUsers_FullMethodName                    = "/user.UserService/GetUsers"
+	UserService_GetUserByMaxID_FullMethodName              = "/user.UserService/GetUserByMaxID"
+	UserService_UpdateUser_FullMethodName                  = "/user.UserService/UpdateUser"
+	UserService_DeleteUser_FullMethodName                  = "/user.UserService/DeleteUser"
+	UserService_GetReputationGroups_FullMethodName         = "/user.UserService/GetReputationGroups"
+	UserService_GetReputationGroupByID_FullMethodName      = "/user.UserService/GetReputationGroupByID"
+	UserService_GetReputationGroupLimits_FullMethodName    = "/user.UserService/GetReputationGroupLimits"
+	UserService_SetReputationGroupLimits_FullMethodName    = "/user.UserService/SetReputationGroupLimits"
+	UserService_AddReputationEvent_FullMethodName          = "/user.UserService/AddReputationEvent"
+	UserService_GetReputation_FullMethodName               = "/user.UserService/GetReputation"
+	UserService_GetReputationHistory_FullMethodName        = "/user.UserService/GetReputationHistory"
+	UserService_CreateReputationGroup_FullMethodName       = "/user.UserService/CreateReputationGroup"
+	UserService_UpdateReputationGroup_FullMethodName       = "/user.UserService/UpdateReputationGroup"
+	UserService_DeleteReputationGroup_FullMethodName       = "/user.UserService/DeleteReputationGroup"
+	UserService_RecalculateReputationGroups_FullMethodName = "/user.UserService/RecalculateReputationGroups"
+	UserService_GetBalance_FullMethodName                  = "/user.UserService/GetBalance"
+	UserService_GetBalanceOperations_FullMethodName        = "/user.UserService/GetBalanceOperations"
+	UserService_GetBalanceOperationTotals_FullMethodName   = "/user.UserService/GetBalanceOperationTotals"
+	UserService_GetBalanceAt_FullMethodName                = "/user.UserService/GetBalanceAt"
+	UserService_WatchBalance_FullMethodName                = "/user.UserService/WatchBalance"
+	UserService_GetBalanceHistory_FullMethodName           = "/user.UserService/GetBalanceHistory"
+	UserService_GetBalanceStats_FullMethodName             = "/user.UserService/GetBalanceStats"
+	UserService_CreateOperation_FullMethodName             = "/user.UserService/CreateOperation"
+	UserService_CreateOperationsBatch_FullMethodName       = "/user.UserService/CreateOperationsBatch"
+	UserService_TransferPoints_FullMethodName              = "/user.UserService/TransferPoints"
+	UserService_ReverseOperation_FullMethodName            = "/user.UserService/ReverseOperation"
+	UserService_GetLeaderboard_FullMethodName              = "/user.UserService/GetLeaderboard"
+	UserService_GetTrialBalance_FullMethodName             = "/user.UserService/GetTrialBalance"
+	UserService_ReconcileBalances_FullMethodName           = "/user.UserService/ReconcileBalances"
+	UserService_CreateHold_FullMethodName                  = "/user.UserService/CreateHold"
+	UserService_CaptureHold_FullMethodName                 = "/user.UserService/CaptureHold"
+	UserService_ReleaseHold_FullMethodName                 = "/user.UserService/ReleaseHold"
+	UserService_ScheduleOperation_FullMethodName           = "/user.UserService/ScheduleOperation"
+	UserService_ListScheduledOperations_FullMethodName     = "/user.UserService/ListScheduledOperations"
+	UserService_PauseScheduledOperation_FullMethodName     = "/user.UserService/PauseScheduledOperation"
+	UserService_ResumeScheduledOperation_FullMethodName    = "/user.UserService/ResumeScheduledOperation"
+	UserService_CancelScheduledOperation_FullMethodName    = "/user.UserService/CancelScheduledOperation"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -42,9 +70,37 @@ type UserServiceClient interface {
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	GetReputationGroups(ctx context.Context, in *GetReputationGroupsRequest, opts ...grpc.CallOption) (*GetReputationGroupsResponse, error)
 	GetReputationGroupByID(ctx context.Context, in *GetReputationGroupByIDRequest, opts ...grpc.CallOption) (*GetReputationGroupByIDResponse, error)
+	GetReputationGroupLimits(ctx context.Context, in *GetReputationGroupLimitsRequest, opts ...grpc.CallOption) (*GetReputationGroupLimitsResponse, error)
+	SetReputationGroupLimits(ctx context.Context, in *SetReputationGroupLimitsRequest, opts ...grpc.CallOption) (*SetReputationGroupLimitsResponse, error)
+	AddReputationEvent(ctx context.Context, in *AddReputationEventRequest, opts ...grpc.CallOption) (*AddReputationEventResponse, error)
+	GetReputation(ctx context.Context, in *GetReputationRequest, opts ...grpc.CallOption) (*GetReputationResponse, error)
+	GetReputationHistory(ctx context.Context, in *GetReputationHistoryRequest, opts ...grpc.CallOption) (*GetReputationHistoryResponse, error)
+	CreateReputationGroup(ctx context.Context, in *CreateReputationGroupRequest, opts ...grpc.CallOption) (*CreateReputationGroupResponse, error)
+	UpdateReputationGroup(ctx context.Context, in *UpdateReputationGroupRequest, opts ...grpc.CallOption) (*UpdateReputationGroupResponse, error)
+	DeleteReputationGroup(ctx context.Context, in *DeleteReputationGroupRequest, opts ...grpc.CallOption) (*DeleteReputationGroupResponse, error)
+	RecalculateReputationGroups(ctx context.Context, in *RecalculateReputationGroupsRequest, opts ...grpc.CallOption) (*RecalculateReputationGroupsResponse, error)
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
 	GetBalanceOperations(ctx context.Context, in *GetBalanceOperationsRequest, opts ...grpc.CallOption) (*GetBalanceOperationsResponse, error)
+	GetBalanceOperationTotals(ctx context.Context, in *GetBalanceOperationTotalsRequest, opts ...grpc.CallOption) (*GetBalanceOperationTotalsResponse, error)
+	GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*GetBalanceAtResponse, error)
+	WatchBalance(ctx context.Context, in *WatchBalanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchBalanceResponse], error)
+	GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error)
+	GetBalanceStats(ctx context.Context, in *GetBalanceStatsRequest, opts ...grpc.CallOption) (*GetBalanceStatsResponse, error)
 	CreateOperation(ctx context.Context, in *CreateOperationRequest, opts ...grpc.CallOption) (*CreateOperationResponse, error)
+	CreateOperationsBatch(ctx context.Context, in *CreateOperationsBatchRequest, opts ...grpc.CallOption) (*CreateOperationsBatchResponse, error)
+	TransferPoints(ctx context.Context, in *TransferPointsRequest, opts ...grpc.CallOption) (*TransferPointsResponse, error)
+	ReverseOperation(ctx context.Context, in *ReverseOperationRequest, opts ...grpc.CallOption) (*ReverseOperationResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	GetTrialBalance(ctx context.Context, in *GetTrialBalanceRequest, opts ...grpc.CallOption) (*GetTrialBalanceResponse, error)
+	ReconcileBalances(ctx context.Context, in *ReconcileBalancesRequest, opts ...grpc.CallOption) (*ReconcileBalancesResponse, error)
+	CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*CreateHoldResponse, error)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error)
+	ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error)
+	ScheduleOperation(ctx context.Context, in *ScheduleOperationRequest, opts ...grpc.CallOption) (*ScheduleOperationResponse, error)
+	ListScheduledOperations(ctx context.Context, in *ListScheduledOperationsRequest, opts ...grpc.CallOption) (*ListScheduledOperationsResponse, error)
+	PauseScheduledOperation(ctx context.Context, in *PauseScheduledOperationRequest, opts ...grpc.CallOption) (*PauseScheduledOperationResponse, error)
+	ResumeScheduledOperation(ctx context.Context, in *ResumeScheduledOperationRequest, opts ...grpc.CallOption) (*ResumeScheduledOperationResponse, error)
+	CancelScheduledOperation(ctx context.Context, in *CancelScheduledOperationRequest, opts ...grpc.CallOption) (*CancelScheduledOperationResponse, error)
 }
 
 type userServiceClient struct {
@@ -125,6 +181,96 @@ func (c *userServiceClient) GetReputationGroupByID(ctx context.Context, in *GetR
 	return out, nil
 }
 
+func (c *userServiceClient) GetReputationGroupLimits(ctx context.Context, in *GetReputationGroupLimitsRequest, opts ...grpc.CallOption) (*GetReputationGroupLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReputationGroupLimitsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetReputationGroupLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetReputationGroupLimits(ctx context.Context, in *SetReputationGroupLimitsRequest, opts ...grpc.CallOption) (*SetReputationGroupLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetReputationGroupLimitsResponse)
+	err := c.cc.Invoke(ctx, UserService_SetReputationGroupLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AddReputationEvent(ctx context.Context, in *AddReputationEventRequest, opts ...grpc.CallOption) (*AddReputationEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReputationEventResponse)
+	err := c.cc.Invoke(ctx, UserService_AddReputationEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetReputation(ctx context.Context, in *GetReputationRequest, opts ...grpc.CallOption) (*GetReputationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReputationResponse)
+	err := c.cc.Invoke(ctx, UserService_GetReputation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetReputationHistory(ctx context.Context, in *GetReputationHistoryRequest, opts ...grpc.CallOption) (*GetReputationHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReputationHistoryResponse)
+	err := c.cc.Invoke(ctx, UserService_GetReputationHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateReputationGroup(ctx context.Context, in *CreateReputationGroupRequest, opts ...grpc.CallOption) (*CreateReputationGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateReputationGroupResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateReputationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateReputationGroup(ctx context.Context, in *UpdateReputationGroupRequest, opts ...grpc.CallOption) (*UpdateReputationGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateReputationGroupResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateReputationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteReputationGroup(ctx context.Context, in *DeleteReputationGroupRequest, opts ...grpc.CallOption) (*DeleteReputationGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteReputationGroupResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteReputationGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RecalculateReputationGroups(ctx context.Context, in *RecalculateReputationGroupsRequest, opts ...grpc.CallOption) (*RecalculateReputationGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecalculateReputationGroupsResponse)
+	err := c.cc.Invoke(ctx, UserService_RecalculateReputationGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBalanceResponse)
@@ -145,6 +291,65 @@ func (c *userServiceClient) GetBalanceOperations(ctx context.Context, in *GetBal
 	return out, nil
 }
 
+func (c *userServiceClient) GetBalanceOperationTotals(ctx context.Context, in *GetBalanceOperationTotalsRequest, opts ...grpc.CallOption) (*GetBalanceOperationTotalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceOperationTotalsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetBalanceOperationTotals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*GetBalanceAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceAtResponse)
+	err := c.cc.Invoke(ctx, UserService_GetBalanceAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) WatchBalance(ctx context.Context, in *WatchBalanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchBalanceResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_WatchBalance_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBalanceRequest, WatchBalanceResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_WatchBalanceClient = grpc.ServerStreamingClient[WatchBalanceResponse]
+
+func (c *userServiceClient) GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceHistoryResponse)
+	err := c.cc.Invoke(ctx, UserService_GetBalanceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetBalanceStats(ctx context.Context, in *GetBalanceStatsRequest, opts ...grpc.CallOption) (*GetBalanceStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetBalanceStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) CreateOperation(ctx context.Context, in *CreateOperationRequest, opts ...grpc.CallOption) (*CreateOperationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateOperationResponse)
@@ -155,6 +360,146 @@ func (c *userServiceClient) CreateOperation(ctx context.Context, in *CreateOpera
 	return out, nil
 }
 
+func (c *userServiceClient) CreateOperationsBatch(ctx context.Context, in *CreateOperationsBatchRequest, opts ...grpc.CallOption) (*CreateOperationsBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOperationsBatchResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateOperationsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) TransferPoints(ctx context.Context, in *TransferPointsRequest, opts ...grpc.CallOption) (*TransferPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_TransferPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReverseOperation(ctx context.Context, in *ReverseOperationRequest, opts ...grpc.CallOption) (*ReverseOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReverseOperationResponse)
+	err := c.cc.Invoke(ctx, UserService_ReverseOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, UserService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetTrialBalance(ctx context.Context, in *GetTrialBalanceRequest, opts ...grpc.CallOption) (*GetTrialBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrialBalanceResponse)
+	err := c.cc.Invoke(ctx, UserService_GetTrialBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReconcileBalances(ctx context.Context, in *ReconcileBalancesRequest, opts ...grpc.CallOption) (*ReconcileBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileBalancesResponse)
+	err := c.cc.Invoke(ctx, UserService_ReconcileBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*CreateHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateHoldResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureHoldResponse)
+	err := c.cc.Invoke(ctx, UserService_CaptureHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseHoldResponse)
+	err := c.cc.Invoke(ctx, UserService_ReleaseHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ScheduleOperation(ctx context.Context, in *ScheduleOperationRequest, opts ...grpc.CallOption) (*ScheduleOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleOperationResponse)
+	err := c.cc.Invoke(ctx, UserService_ScheduleOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListScheduledOperations(ctx context.Context, in *ListScheduledOperationsRequest, opts ...grpc.CallOption) (*ListScheduledOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledOperationsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListScheduledOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PauseScheduledOperation(ctx context.Context, in *PauseScheduledOperationRequest, opts ...grpc.CallOption) (*PauseScheduledOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseScheduledOperationResponse)
+	err := c.cc.Invoke(ctx, UserService_PauseScheduledOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ResumeScheduledOperation(ctx context.Context, in *ResumeScheduledOperationRequest, opts ...grpc.CallOption) (*ResumeScheduledOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeScheduledOperationResponse)
+	err := c.cc.Invoke(ctx, UserService_ResumeScheduledOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CancelScheduledOperation(ctx context.Context, in *CancelScheduledOperationRequest, opts ...grpc.CallOption) (*CancelScheduledOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledOperationResponse)
+	err := c.cc.Invoke(ctx, UserService_CancelScheduledOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -166,9 +511,37 @@ type UserServiceServer interface {
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	GetReputationGroups(context.Context, *GetReputationGroupsRequest) (*GetReputationGroupsResponse, error)
 	GetReputationGroupByID(context.Context, *GetReputationGroupByIDRequest) (*GetReputationGroupByIDResponse, error)
+	GetReputationGroupLimits(context.Context, *GetReputationGroupLimitsRequest) (*GetReputationGroupLimitsResponse, error)
+	SetReputationGroupLimits(context.Context, *SetReputationGroupLimitsRequest) (*SetReputationGroupLimitsResponse, error)
+	AddReputationEvent(context.Context, *AddReputationEventRequest) (*AddReputationEventResponse, error)
+	GetReputation(context.Context, *GetReputationRequest) (*GetReputationResponse, error)
+	GetReputationHistory(context.Context, *GetReputationHistoryRequest) (*GetReputationHistoryResponse, error)
+	CreateReputationGroup(context.Context, *CreateReputationGroupRequest) (*CreateReputationGroupResponse, error)
+	UpdateReputationGroup(context.Context, *UpdateReputationGroupRequest) (*UpdateReputationGroupResponse, error)
+	DeleteReputationGroup(context.Context, *DeleteReputationGroupRequest) (*DeleteReputationGroupResponse, error)
+	RecalculateReputationGroups(context.Context, *RecalculateReputationGroupsRequest) (*RecalculateReputationGroupsResponse, error)
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
 	GetBalanceOperations(context.Context, *GetBalanceOperationsRequest) (*GetBalanceOperationsResponse, error)
+	GetBalanceOperationTotals(context.Context, *GetBalanceOperationTotalsRequest) (*GetBalanceOperationTotalsResponse, error)
+	GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error)
+	WatchBalance(*WatchBalanceRequest, grpc.ServerStreamingServer[WatchBalanceResponse]) error
+	GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error)
+	GetBalanceStats(context.Context, *GetBalanceStatsRequest) (*GetBalanceStatsResponse, error)
 	CreateOperation(context.Context, *CreateOperationRequest) (*CreateOperationResponse, error)
+	CreateOperationsBatch(context.Context, *CreateOperationsBatchRequest) (*CreateOperationsBatchResponse, error)
+	TransferPoints(context.Context, *TransferPointsRequest) (*TransferPointsResponse, error)
+	ReverseOperation(context.Context, *ReverseOperationRequest) (*ReverseOperationResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	GetTrialBalance(context.Context, *GetTrialBalanceRequest) (*GetTrialBalanceResponse, error)
+	ReconcileBalances(context.Context, *ReconcileBalancesRequest) (*ReconcileBalancesResponse, error)
+	CreateHold(context.Context, *CreateHoldRequest) (*CreateHoldResponse, error)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error)
+	ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error)
+	ScheduleOperation(context.Context, *ScheduleOperationRequest) (*ScheduleOperationResponse, error)
+	ListScheduledOperations(context.Context, *ListScheduledOperationsRequest) (*ListScheduledOperationsResponse, error)
+	PauseScheduledOperation(context.Context, *PauseScheduledOperationRequest) (*PauseScheduledOperationResponse, error)
+	ResumeScheduledOperation(context.Context, *ResumeScheduledOperationRequest) (*ResumeScheduledOperationResponse, error)
+	CancelScheduledOperation(context.Context, *CancelScheduledOperationRequest) (*CancelScheduledOperationResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -200,15 +573,99 @@ func (UnimplementedUserServiceServer) GetReputationGroups(context.Context, *GetR
 func (UnimplementedUserServiceServer) GetReputationGroupByID(context.Context, *GetReputationGroupByIDRequest) (*GetReputationGroupByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetReputationGroupByID not implemented")
 }
+func (UnimplementedUserServiceServer) GetReputationGroupLimits(context.Context, *GetReputationGroupLimitsRequest) (*GetReputationGroupLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReputationGroupLimits not implemented")
+}
+func (UnimplementedUserServiceServer) SetReputationGroupLimits(context.Context, *SetReputationGroupLimitsRequest) (*SetReputationGroupLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReputationGroupLimits not implemented")
+}
+func (UnimplementedUserServiceServer) AddReputationEvent(context.Context, *AddReputationEventRequest) (*AddReputationEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReputationEvent not implemented")
+}
+func (UnimplementedUserServiceServer) GetReputation(context.Context, *GetReputationRequest) (*GetReputationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReputation not implemented")
+}
+func (UnimplementedUserServiceServer) GetReputationHistory(context.Context, *GetReputationHistoryRequest) (*GetReputationHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReputationHistory not implemented")
+}
+func (UnimplementedUserServiceServer) CreateReputationGroup(context.Context, *CreateReputationGroupRequest) (*CreateReputationGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReputationGroup not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateReputationGroup(context.Context, *UpdateReputationGroupRequest) (*UpdateReputationGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReputationGroup not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteReputationGroup(context.Context, *DeleteReputationGroupRequest) (*DeleteReputationGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReputationGroup not implemented")
+}
+func (UnimplementedUserServiceServer) RecalculateReputationGroups(context.Context, *RecalculateReputationGroupsRequest) (*RecalculateReputationGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecalculateReputationGroups not implemented")
+}
 func (UnimplementedUserServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
 }
 func (UnimplementedUserServiceServer) GetBalanceOperations(context.Context, *GetBalanceOperationsRequest) (*GetBalanceOperationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceOperations not implemented")
 }
+func (UnimplementedUserServiceServer) GetBalanceOperationTotals(context.Context, *GetBalanceOperationTotalsRequest) (*GetBalanceOperationTotalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceOperationTotals not implemented")
+}
+func (UnimplementedUserServiceServer) GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAt not implemented")
+}
+func (UnimplementedUserServiceServer) WatchBalance(*WatchBalanceRequest, grpc.ServerStreamingServer[WatchBalanceResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBalance not implemented")
+}
+func (UnimplementedUserServiceServer) GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceHistory not implemented")
+}
+func (UnimplementedUserServiceServer) GetBalanceStats(context.Context, *GetBalanceStatsRequest) (*GetBalanceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceStats not implemented")
+}
 func (UnimplementedUserServiceServer) CreateOperation(context.Context, *CreateOperationRequest) (*CreateOperationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOperation not implemented")
 }
+func (UnimplementedUserServiceServer) CreateOperationsBatch(context.Context, *CreateOperationsBatchRequest) (*CreateOperationsBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOperationsBatch not implemented")
+}
+func (UnimplementedUserServiceServer) TransferPoints(context.Context, *TransferPointsRequest) (*TransferPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferPoints not implemented")
+}
+func (UnimplementedUserServiceServer) ReverseOperation(context.Context, *ReverseOperationRequest) (*ReverseOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseOperation not implemented")
+}
+func (UnimplementedUserServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedUserServiceServer) GetTrialBalance(context.Context, *GetTrialBalanceRequest) (*GetTrialBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrialBalance not implemented")
+}
+func (UnimplementedUserServiceServer) ReconcileBalances(context.Context, *ReconcileBalancesRequest) (*ReconcileBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileBalances not implemented")
+}
+func (UnimplementedUserServiceServer) CreateHold(context.Context, *CreateHoldRequest) (*CreateHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHold not implemented")
+}
+func (UnimplementedUserServiceServer) CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (UnimplementedUserServiceServer) ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseHold not implemented")
+}
+func (UnimplementedUserServiceServer) ScheduleOperation(context.Context, *ScheduleOperationRequest) (*ScheduleOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleOperation not implemented")
+}
+func (UnimplementedUserServiceServer) ListScheduledOperations(context.Context, *ListScheduledOperationsRequest) (*ListScheduledOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledOperations not implemented")
+}
+func (UnimplementedUserServiceServer) PauseScheduledOperation(context.Context, *PauseScheduledOperationRequest) (*PauseScheduledOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseScheduledOperation not implemented")
+}
+func (UnimplementedUserServiceServer) ResumeScheduledOperation(context.Context, *ResumeScheduledOperationRequest) (*ResumeScheduledOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeScheduledOperation not implemented")
+}
+func (UnimplementedUserServiceServer) CancelScheduledOperation(context.Context, *CancelScheduledOperationRequest) (*CancelScheduledOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledOperation not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -356,73 +813,570 @@ func _UserService_GetReputationGroupByID_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBalanceRequest)
+func _UserService_GetReputationGroupLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReputationGroupLimitsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetBalance(ctx, in)
+		return srv.(UserServiceServer).GetReputationGroupLimits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetBalance_FullMethodName,
+		FullMethod: UserService_GetReputationGroupLimits_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+		return srv.(UserServiceServer).GetReputationGroupLimits(ctx, req.(*GetReputationGroupLimitsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetBalanceOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBalanceOperationsRequest)
+func _UserService_SetReputationGroupLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReputationGroupLimitsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetBalanceOperations(ctx, in)
+		return srv.(UserServiceServer).SetReputationGroupLimits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetBalanceOperations_FullMethodName,
+		FullMethod: UserService_SetReputationGroupLimits_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetBalanceOperations(ctx, req.(*GetBalanceOperationsRequest))
+		return srv.(UserServiceServer).SetReputationGroupLimits(ctx, req.(*SetReputationGroupLimitsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_CreateOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateOperationRequest)
+func _UserService_AddReputationEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReputationEventRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).CreateOperation(ctx, in)
+		return srv.(UserServiceServer).AddReputationEvent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_CreateOperation_FullMethodName,
+		FullMethod: UserService_AddReputationEvent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).CreateOperation(ctx, req.(*CreateOperationRequest))
+		return srv.(UserServiceServer).AddReputationEvent(ctx, req.(*AddReputationEventRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var UserService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "user.UserService",
-	HandlerType: (*UserServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateUser",
-			Handler:    _UserService_CreateUser_Handler,
-		},
-		{
-			MethodName: "GetUsers",
+func _UserService_GetReputation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReputationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetReputation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetReputation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetReputation(ctx, req.(*GetReputationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetReputationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReputationHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetReputationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetReputationHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetReputationHistory(ctx, req.(*GetReputationHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateReputationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReputationGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateReputationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateReputationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateReputationGroup(ctx, req.(*CreateReputationGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateReputationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReputationGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateReputationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateReputationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateReputationGroup(ctx, req.(*UpdateReputationGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteReputationGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReputationGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteReputationGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteReputationGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteReputationGroup(ctx, req.(*DeleteReputationGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RecalculateReputationGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecalculateReputationGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RecalculateReputationGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RecalculateReputationGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RecalculateReputationGroups(ctx, req.(*RecalculateReputationGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBalanceOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBalanceOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBalanceOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBalanceOperations(ctx, req.(*GetBalanceOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBalanceOperationTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceOperationTotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBalanceOperationTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBalanceOperationTotals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBalanceOperationTotals(ctx, req.(*GetBalanceOperationTotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBalanceAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBalanceAt(ctx, req.(*GetBalanceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_WatchBalance_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBalanceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).WatchBalance(m, &grpc.GenericServerStream[WatchBalanceRequest, WatchBalanceResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_WatchBalanceServer = grpc.ServerStreamingServer[WatchBalanceResponse]
+
+func _UserService_GetBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBalanceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBalanceHistory(ctx, req.(*GetBalanceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBalanceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBalanceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBalanceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBalanceStats(ctx, req.(*GetBalanceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateOperation(ctx, req.(*CreateOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateOperationsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOperationsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateOperationsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateOperationsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateOperationsBatch(ctx, req.(*CreateOperationsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_TransferPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).TransferPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_TransferPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).TransferPoints(ctx, req.(*TransferPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReverseOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReverseOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReverseOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReverseOperation(ctx, req.(*ReverseOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetTrialBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrialBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetTrialBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetTrialBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetTrialBalance(ctx, req.(*GetTrialBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReconcileBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReconcileBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReconcileBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReconcileBalances(ctx, req.(*ReconcileBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateHold(ctx, req.(*CreateHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CaptureHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReleaseHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReleaseHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReleaseHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReleaseHold(ctx, req.(*ReleaseHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ScheduleOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ScheduleOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ScheduleOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ScheduleOperation(ctx, req.(*ScheduleOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListScheduledOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListScheduledOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListScheduledOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListScheduledOperations(ctx, req.(*ListScheduledOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PauseScheduledOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduledOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PauseScheduledOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PauseScheduledOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PauseScheduledOperation(ctx, req.(*PauseScheduledOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResumeScheduledOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeScheduledOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResumeScheduledOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ResumeScheduledOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResumeScheduledOperation(ctx, req.(*ResumeScheduledOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CancelScheduledOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CancelScheduledOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CancelScheduledOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CancelScheduledOperation(ctx, req.(*CancelScheduledOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "user.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUsers",
 			Handler:    _UserService_GetUsers_Handler,
 		},
 		{
@@ -445,6 +1399,42 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetReputationGroupByID",
 			Handler:    _UserService_GetReputationGroupByID_Handler,
 		},
+		{
+			MethodName: "GetReputationGroupLimits",
+			Handler:    _UserService_GetReputationGroupLimits_Handler,
+		},
+		{
+			MethodName: "SetReputationGroupLimits",
+			Handler:    _UserService_SetReputationGroupLimits_Handler,
+		},
+		{
+			MethodName: "AddReputationEvent",
+			Handler:    _UserService_AddReputationEvent_Handler,
+		},
+		{
+			MethodName: "GetReputation",
+			Handler:    _UserService_GetReputation_Handler,
+		},
+		{
+			MethodName: "GetReputationHistory",
+			Handler:    _UserService_GetReputationHistory_Handler,
+		},
+		{
+			MethodName: "CreateReputationGroup",
+			Handler:    _UserService_CreateReputationGroup_Handler,
+		},
+		{
+			MethodName: "UpdateReputationGroup",
+			Handler:    _UserService_UpdateReputationGroup_Handler,
+		},
+		{
+			MethodName: "DeleteReputationGroup",
+			Handler:    _UserService_DeleteReputationGroup_Handler,
+		},
+		{
+			MethodName: "RecalculateReputationGroups",
+			Handler:    _UserService_RecalculateReputationGroups_Handler,
+		},
 		{
 			MethodName: "GetBalance",
 			Handler:    _UserService_GetBalance_Handler,
@@ -453,11 +1443,89 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBalanceOperations",
 			Handler:    _UserService_GetBalanceOperations_Handler,
 		},
+		{
+			MethodName: "GetBalanceOperationTotals",
+			Handler:    _UserService_GetBalanceOperationTotals_Handler,
+		},
+		{
+			MethodName: "GetBalanceAt",
+			Handler:    _UserService_GetBalanceAt_Handler,
+		},
+		{
+			MethodName: "GetBalanceHistory",
+			Handler:    _UserService_GetBalanceHistory_Handler,
+		},
+		{
+			MethodName: "GetBalanceStats",
+			Handler:    _UserService_GetBalanceStats_Handler,
+		},
 		{
 			MethodName: "CreateOperation",
 			Handler:    _UserService_CreateOperation_Handler,
 		},
+		{
+			MethodName: "CreateOperationsBatch",
+			Handler:    _UserService_CreateOperationsBatch_Handler,
+		},
+		{
+			MethodName: "TransferPoints",
+			Handler:    _UserService_TransferPoints_Handler,
+		},
+		{
+			MethodName: "ReverseOperation",
+			Handler:    _UserService_ReverseOperation_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UserService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetTrialBalance",
+			Handler:    _UserService_GetTrialBalance_Handler,
+		},
+		{
+			MethodName: "ReconcileBalances",
+			Handler:    _UserService_ReconcileBalances_Handler,
+		},
+		{
+			MethodName: "CreateHold",
+			Handler:    _UserService_CreateHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _UserService_CaptureHold_Handler,
+		},
+		{
+			MethodName: "ReleaseHold",
+			Handler:    _UserService_ReleaseHold_Handler,
+		},
+		{
+			MethodName: "ScheduleOperation",
+			Handler:    _UserService_ScheduleOperation_Handler,
+		},
+		{
+			MethodName: "ListScheduledOperations",
+			Handler:    _UserService_ListScheduledOperations_Handler,
+		},
+		{
+			MethodName: "PauseScheduledOperation",
+			Handler:    _UserService_PauseScheduledOperation_Handler,
+		},
+		{
+			MethodName: "ResumeScheduledOperation",
+			Handler:    _UserService_ResumeScheduledOperation_Handler,
+		},
+		{
+			MethodName: "CancelScheduledOperation",
+			Handler:    _UserService_CancelScheduledOperation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBalance",
+			Handler:       _UserService_WatchBalance_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user/user.proto",
 }
@@ -0,0 +1,80 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+const maxOperationsBatchSize = 1000
+
+// applied reports whether anything was written.
+func (s *BalanceService) CreateOperationsBatch(ctx context.Context, items []*domain.BalanceOperationBatchItem, atomic bool) (results []*domain.BalanceOperationBatchResult, applied bool, err error) {
+	if len(items) == 0 || len(items) > maxOperationsBatchSize {
+		return nil, false, ErrBalanceInvalid
+	}
+
+	results = make([]*domain.BalanceOperationBatchResult, len(items))
+	valid := make([]*domain.BalanceOperationBatchItem, 0, len(items))
+	validIndexes := make([]int, 0, len(items))
+	for i, item := range items {
+		if item.MaxID == "" || item.Operation == nil || item.Operation.Amount <= 0 || !isClientReason(item.Operation.ReasonCode) {
+			results[i] = &domain.BalanceOperationBatchResult{Err: ErrBalanceInvalid}
+			continue
+		}
+		walletType, err := resolveWalletType(item.Operation.WalletType)
+		if err != nil {
+			results[i] = &domain.BalanceOperationBatchResult{Err: err}
+			continue
+		}
+		item.Operation.WalletType = walletType
+		valid = append(valid, item)
+		validIndexes = append(validIndexes, i)
+	}
+
+	if len(valid) == 0 || (atomic && len(valid) < len(items)) {
+		for _, i := range validIndexes {
+			results[i] = &domain.BalanceOperationBatchResult{}
+		}
+		return results, false, nil
+	}
+
+	created, err := s.storage.CreateBalanceOperationsBatch(ctx, valid, atomic)
+	if err != nil {
+		s.logger.Error("failed to create operations batch", zap.Error(err), zap.Int("items", len(items)))
+		return nil, false, ErrBalanceInternal
+	}
+
+	for j, result := range created {
+		result.Err = convertBatchItemError(result.Err)
+		if result.Operation != nil {
+			applied = true
+		}
+		results[validIndexes[j]] = result
+	}
+
+	return results, applied, nil
+}
+
+func convertBatchItemError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var limitErr *domain.LimitExceededError
+	switch {
+	case errors.As(err, &limitErr):
+		return limitErr
+	case errors.Is(err, sql.ErrBalanceNotFound):
+		return ErrBalanceNotFound
+	case errors.Is(err, sql.ErrBalanceNotEnough):
+		return ErrBalanceNotEnough
+	case errors.Is(err, sql.ErrBalanceInvalid):
+		return ErrBalanceInvalid
+	default:
+		return ErrBalanceInternal
+	}
+}
@@ -7,4 +7,15 @@ var (
 	ErrBalanceNotEnough = errors.New("balance not enough")
 	ErrBalanceInternal  = errors.New("balance internal error")
 	ErrBalanceInvalid   = errors.New("balance invalid")
+	ErrBalanceForbidden = errors.New("balance operation not allowed for actor")
+
+	ErrBalanceOperationNotFound        = errors.New("balance operation not found")
+	ErrBalanceOperationAlreadyReversed = errors.New("balance operation already reversed")
+	ErrBalanceOperationNotReversible   = errors.New("balance operation not reversible")
+
+	ErrHoldNotFound  = errors.New("hold not found")
+	ErrHoldNotActive = errors.New("hold not active")
+
+	ErrScheduledOperationNotFound       = errors.New("scheduled operation not found")
+	ErrScheduledOperationStatusConflict = errors.New("scheduled operation status does not allow this change")
 )
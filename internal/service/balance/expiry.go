@@ -0,0 +1,34 @@
+package balance
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *BalanceService) RunPointsExpiry(ctx context.Context) {
+	ticker := time.NewTicker(s.pointsExpiryInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				expired, err := s.storage.ExpirePoints(ctx, time.Now().UTC(), pointsExpiryBatchSize)
+				if err != nil {
+					s.logger.Error("failed to expire points", zap.Error(err))
+					break
+				}
+				if expired > 0 {
+					s.logger.Info("points expired", zap.Int("balances", expired))
+				}
+				if expired < pointsExpiryBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
@@ -5,44 +5,179 @@ import (
 	"DobrikaDev/user-service/internal/storage/sql"
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
-func (s *BalanceService) GetBalance(ctx context.Context, maxID string) (*domain.Balance, error) {
-	balance, err := s.storage.GetBalance(ctx, maxID)
+func resolveWalletType(walletType domain.WalletType) (domain.WalletType, error) {
+	if walletType == "" {
+		return domain.WalletTypePoints, nil
+	}
+	if !walletType.Valid() {
+		return "", ErrBalanceInvalid
+	}
+	return walletType, nil
+}
+
+func (s *BalanceService) GetBalance(ctx context.Context, maxID string, walletType domain.WalletType) (*domain.Balance, error) {
+	walletType, err := resolveWalletType(walletType)
 	if err != nil {
-		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", maxID))
+		return nil, err
+	}
+
+	balance, err := s.storage.GetBalance(ctx, maxID, walletType)
+	if err != nil {
+		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", maxID), zap.String("wallet_type", walletType.String()))
 		if errors.Is(err, sql.ErrBalanceNotFound) {
 			return nil, ErrBalanceNotFound
 		}
 		return nil, err
 	}
 
+	var expiring []*domain.ExpiringPoints
+	if walletType.Expires() {
+		expiring, err = s.storage.GetExpiringPoints(ctx, balance.ID, time.Now().UTC().Add(s.pointsExpiringWindow()))
+		if err != nil {
+			s.logger.Error("failed to get expiring points", zap.Error(err), zap.String("max_id", maxID))
+			return nil, ErrBalanceInternal
+		}
+	}
+
 	return &domain.Balance{
-		ID:      balance.ID,
-		UserID:  balance.UserID,
-		Balance: balance.Balance,
+		ID:         balance.ID,
+		UserID:     balance.UserID,
+		WalletType: balance.WalletType,
+		Balance:    balance.Balance,
+		Held:       balance.Held,
+
+		ExpiringSoon: expiring,
 	}, nil
 }
 
-func (s *BalanceService) GetBalanceOperations(ctx context.Context, maxID string, limit int, offset int) ([]*domain.BalanceOperation, int32, error) {
-	operations, total, err := s.storage.GetBalanceOperations(ctx, maxID, limit, offset)
+type GetBalanceOperationsFilter struct {
+	WalletType  domain.WalletType
+	Types       []domain.BalanceOperationType
+	ReasonCodes []domain.BalanceOperationReason
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	AmountMin   *int
+	AmountMax   *int
+	Description string
+	Limit       int
+	Offset      int
+	Cursor      *domain.BalanceOperationCursor
+	SkipTotal   bool
+}
+
+type GetBalanceOperationsResponse struct {
+	Operations []*domain.BalanceOperation     `json:"operations"`
+	Total      int32                          `json:"total"`
+	NextCursor *domain.BalanceOperationCursor `json:"next_cursor"`
+}
+
+func (s *BalanceService) GetBalanceOperations(ctx context.Context, maxID string, filter GetBalanceOperationsFilter) (*GetBalanceOperationsResponse, error) {
+	if filter.Cursor != nil && filter.Offset > 0 {
+		return nil, ErrBalanceInvalid
+	}
+
+	walletType, err := resolveWalletType(filter.WalletType)
 	if err != nil {
-		s.logger.Error("failed to get balance operations", zap.Error(err), zap.String("max_id", maxID), zap.Int("limit", limit), zap.Int("offset", offset))
-		return nil, 0, err
+		return nil, err
+	}
+
+	opts := make([]sql.ListBalanceOperationsOpts, 0, 8)
+	opts = append(opts, sql.ListBalanceOperationsWithWalletType(walletType))
+
+	for _, t := range filter.Types {
+		switch t {
+		case domain.BalanceOperationTypeDeposit,
+			domain.BalanceOperationTypeWithdraw,
+			domain.BalanceOperationTypeAdjustment,
+			domain.BalanceOperationTypeExpire:
+		default:
+			return nil, ErrBalanceInvalid
+		}
 	}
-	return operations, total, nil
+	if len(filter.Types) > 0 {
+		opts = append(opts, sql.ListBalanceOperationsWithTypes(filter.Types))
+	}
+	if len(filter.ReasonCodes) > 0 {
+		opts = append(opts, sql.ListBalanceOperationsWithReasonCodes(filter.ReasonCodes))
+	}
+	if filter.CreatedFrom != nil {
+		opts = append(opts, sql.ListBalanceOperationsWithCreatedFrom(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		opts = append(opts, sql.ListBalanceOperationsWithCreatedTo(*filter.CreatedTo))
+	}
+	if filter.AmountMin != nil {
+		opts = append(opts, sql.ListBalanceOperationsWithAmountMin(*filter.AmountMin))
+	}
+	if filter.AmountMax != nil {
+		opts = append(opts, sql.ListBalanceOperationsWithAmountMax(*filter.AmountMax))
+	}
+	if filter.Description != "" {
+		opts = append(opts, sql.ListBalanceOperationsWithDescription(filter.Description))
+	}
+
+	page := sql.BalanceOperationsPage{
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+		Cursor:    filter.Cursor,
+		SkipTotal: filter.SkipTotal,
+	}
+
+	response, err := s.storage.GetBalanceOperations(ctx, maxID, page, opts...)
+	if err != nil {
+		s.logger.Error("failed to get balance operations", zap.Error(err), zap.String("max_id", maxID), zap.Any("filter", filter))
+		return nil, ErrBalanceInternal
+	}
+
+	return &GetBalanceOperationsResponse{
+		Operations: response.Operations,
+		Total:      response.Total,
+		NextCursor: response.NextCursor,
+	}, nil
 }
 
+// Adjustments must name the admin making them and a justification.
 func (s *BalanceService) CreateOperation(ctx context.Context, maxID string, operation *domain.BalanceOperation) (*domain.BalanceOperation, error) {
-	if operation.Amount <= 0 {
+	if operation.Type == "" || operation.Type == domain.BalanceOperationTypeExpire {
+		return nil, ErrBalanceInvalid
+	}
+	if operation.Type == domain.BalanceOperationTypeAdjustment {
+		operation.Justification = strings.TrimSpace(operation.Justification)
+		if operation.Amount == 0 || operation.ActorMaxID == "" || operation.Justification == "" {
+			return nil, ErrBalanceInvalid
+		}
+		if operation.ReasonCode != "" && operation.ReasonCode != domain.BalanceOperationReasonAdjustment {
+			return nil, ErrBalanceInvalid
+		}
+		operation.ReasonCode = domain.BalanceOperationReasonAdjustment
+	} else {
+		if operation.Amount <= 0 {
+			return nil, ErrBalanceInvalid
+		}
+		if operation.ActorMaxID != "" || operation.Justification != "" || operation.CountsTowardsReputation {
+			return nil, ErrBalanceInvalid
+		}
+		if !isClientReason(operation.ReasonCode) {
+			return nil, ErrBalanceInvalid
+		}
+	}
+	if operation.BaseAmount > 0 && operation.Type != domain.BalanceOperationTypeDeposit {
 		return nil, ErrBalanceInvalid
 	}
-	if operation.Type == "" {
+	walletType, err := resolveWalletType(operation.WalletType)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Type == domain.BalanceOperationTypeWithdraw && !walletType.Spendable() {
 		return nil, ErrBalanceInvalid
 	}
-	balance, err := s.storage.GetBalance(ctx, maxID)
+	balance, err := s.storage.GetBalance(ctx, maxID, walletType)
 	if err != nil {
 		if errors.Is(err, sql.ErrBalanceNotFound) {
 			return nil, ErrBalanceNotFound
@@ -54,6 +189,10 @@ func (s *BalanceService) CreateOperation(ctx context.Context, maxID string, oper
 	created, err := s.storage.CreateBalanceOperation(ctx, operation)
 	if err != nil {
 		s.logger.Error("failed to create operation", zap.Error(err), zap.Any("operation", operation))
+		var limitErr *domain.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
 		if errors.Is(err, sql.ErrBalanceNotFound) {
 			return nil, ErrBalanceNotFound
 		}
@@ -63,14 +202,159 @@ func (s *BalanceService) CreateOperation(ctx context.Context, maxID string, oper
 		if errors.Is(err, sql.ErrBalanceNotEnough) {
 			return nil, ErrBalanceNotEnough
 		}
+		if errors.Is(err, sql.ErrBalanceForbidden) {
+			return nil, ErrBalanceForbidden
+		}
 		return nil, ErrBalanceInternal
 	}
 	return &domain.BalanceOperation{
 		ID:          created.ID,
 		BalanceID:   created.BalanceID,
+		WalletType:  created.WalletType,
 		Amount:      created.Amount,
 		Type:        created.Type,
 		Description: created.Description,
+		TransferID:  created.TransferID,
+		ReasonCode:  created.ReasonCode,
+		Metadata:    created.Metadata,
 		CreatedAt:   created.CreatedAt,
+
+		ReputationAmount: created.ReputationAmount,
+
+		BaseAmount:  created.BaseAmount,
+		Coefficient: created.Coefficient,
+
+		ActorMaxID:    created.ActorMaxID,
+		Justification: created.Justification,
 	}, nil
 }
+
+// Transfer, reversal and adjustment reasons are assigned by the service itself.
+
+func isClientReason(reason domain.BalanceOperationReason) bool {
+	switch reason {
+	case "",
+		domain.BalanceOperationReasonTaskReward,
+		domain.BalanceOperationReasonEventBonus,
+		domain.BalanceOperationReasonShopPurchase,
+		domain.BalanceOperationReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *BalanceService) GetBalanceOperationTotals(ctx context.Context, maxID string, walletType domain.WalletType, createdFrom *time.Time, createdTo *time.Time) ([]*domain.BalanceOperationReasonTotals, error) {
+	walletType, err := resolveWalletType(walletType)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := make([]sql.ListBalanceOperationsOpts, 0, 3)
+	opts = append(opts, sql.ListBalanceOperationsWithWalletType(walletType))
+	if createdFrom != nil {
+		opts = append(opts, sql.ListBalanceOperationsWithCreatedFrom(*createdFrom))
+	}
+	if createdTo != nil {
+		opts = append(opts, sql.ListBalanceOperationsWithCreatedTo(*createdTo))
+	}
+
+	totals, err := s.storage.GetBalanceOperationTotals(ctx, maxID, opts...)
+	if err != nil {
+		s.logger.Error("failed to get balance operation totals", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrBalanceInternal
+	}
+
+	return totals, nil
+}
+
+func (s *BalanceService) ReverseOperation(ctx context.Context, operationID string, reason string) (*domain.BalanceOperation, error) {
+	if operationID == "" || reason == "" {
+		return nil, ErrBalanceInvalid
+	}
+
+	reversal, err := s.storage.ReverseBalanceOperation(ctx, operationID, reason)
+	if err != nil {
+		s.logger.Error("failed to reverse operation", zap.Error(err), zap.String("operation_id", operationID))
+		switch {
+		case errors.Is(err, sql.ErrBalanceOperationNotFound):
+			return nil, ErrBalanceOperationNotFound
+		case errors.Is(err, sql.ErrBalanceOperationAlreadyReversed):
+			return nil, ErrBalanceOperationAlreadyReversed
+		case errors.Is(err, sql.ErrBalanceOperationNotReversible):
+			return nil, ErrBalanceOperationNotReversible
+		case errors.Is(err, sql.ErrBalanceNotFound):
+			return nil, ErrBalanceNotFound
+		case errors.Is(err, sql.ErrBalanceNotEnough):
+			return nil, ErrBalanceNotEnough
+		default:
+			return nil, ErrBalanceInternal
+		}
+	}
+
+	return reversal, nil
+}
+
+func (s *BalanceService) TransferPoints(ctx context.Context, fromMaxID string, toMaxID string, amount int, description string) (*domain.Transfer, error) {
+	if amount <= 0 || fromMaxID == toMaxID {
+		return nil, ErrBalanceInvalid
+	}
+
+	from, err := s.storage.GetBalance(ctx, fromMaxID, domain.WalletTypePoints)
+	if err != nil {
+		if errors.Is(err, sql.ErrBalanceNotFound) {
+			return nil, ErrBalanceNotFound
+		}
+		s.logger.Error("failed to get sender balance", zap.Error(err), zap.String("max_id", fromMaxID))
+		return nil, ErrBalanceInternal
+	}
+
+	to, err := s.storage.GetBalance(ctx, toMaxID, domain.WalletTypePoints)
+	if err != nil {
+		if errors.Is(err, sql.ErrBalanceNotFound) {
+			return nil, ErrBalanceNotFound
+		}
+		s.logger.Error("failed to get recipient balance", zap.Error(err), zap.String("max_id", toMaxID))
+		return nil, ErrBalanceInternal
+	}
+
+	transfer, err := s.storage.CreateTransfer(ctx, &domain.Transfer{
+		Amount:      amount,
+		Description: description,
+		FromBalance: from,
+		ToBalance:   to,
+	})
+	if err != nil {
+		s.logger.Error("failed to create transfer", zap.Error(err), zap.String("from_max_id", fromMaxID), zap.String("to_max_id", toMaxID))
+		var limitErr *domain.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+		if errors.Is(err, sql.ErrBalanceNotFound) {
+			return nil, ErrBalanceNotFound
+		}
+		if errors.Is(err, sql.ErrBalanceInvalid) {
+			return nil, ErrBalanceInvalid
+		}
+		if errors.Is(err, sql.ErrBalanceNotEnough) {
+			return nil, ErrBalanceNotEnough
+		}
+		return nil, ErrBalanceInternal
+	}
+
+	return transfer, nil
+}
+
+func (s *BalanceService) GetTrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	trialBalance, err := s.storage.GetTrialBalance(ctx)
+	if err != nil {
+		s.logger.Error("failed to get trial balance", zap.Error(err))
+		return nil, ErrBalanceInternal
+	}
+
+	if !trialBalance.Balanced() {
+		s.logger.Warn("ledger is out of balance", zap.Int64("total_debit", trialBalance.TotalDebit), zap.Int64("total_credit", trialBalance.TotalCredit))
+	}
+
+	return trialBalance, nil
+}
@@ -0,0 +1,112 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *BalanceService) GetBalanceAt(ctx context.Context, maxID string, walletType domain.WalletType, at time.Time) (int, error) {
+	walletType, err := resolveWalletType(walletType)
+	if err != nil {
+		return 0, err
+	}
+
+	balanceID, err := s.balanceID(ctx, maxID, walletType)
+	if err != nil {
+		return 0, err
+	}
+
+	balance, err := s.storage.GetBalanceAt(ctx, balanceID, at)
+	if err != nil {
+		s.logger.Error("failed to get balance at", zap.Error(err), zap.String("max_id", maxID), zap.Time("at", at))
+		return 0, ErrBalanceInternal
+	}
+
+	return balance, nil
+}
+
+func (s *BalanceService) GetBalanceHistory(ctx context.Context, maxID string, walletType domain.WalletType, from time.Time, to time.Time, granularity domain.BalanceHistoryGranularity) ([]*domain.BalanceHistoryPoint, error) {
+	if !from.Before(to) {
+		return nil, ErrBalanceInvalid
+	}
+
+	// Months are counted as their shortest length so the estimate never
+	// undercounts the number of points.
+	var period time.Duration
+	switch granularity {
+	case domain.BalanceHistoryGranularityDay:
+		period = 24 * time.Hour
+	case domain.BalanceHistoryGranularityWeek:
+		period = 7 * 24 * time.Hour
+	case domain.BalanceHistoryGranularityMonth:
+		period = 28 * 24 * time.Hour
+	default:
+		return nil, ErrBalanceInvalid
+	}
+	if to.Sub(from)/period > maxBalanceHistoryPoints {
+		return nil, ErrBalanceInvalid
+	}
+
+	walletType, err := resolveWalletType(walletType)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceID, err := s.balanceID(ctx, maxID, walletType)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.storage.GetBalanceHistory(ctx, balanceID, from, to, granularity)
+	if err != nil {
+		s.logger.Error("failed to get balance history", zap.Error(err), zap.String("max_id", maxID), zap.String("granularity", string(granularity)))
+		return nil, ErrBalanceInternal
+	}
+
+	return points, nil
+}
+
+func (s *BalanceService) balanceID(ctx context.Context, maxID string, walletType domain.WalletType) (string, error) {
+	balance, err := s.storage.GetBalance(ctx, maxID, walletType)
+	if err != nil {
+		if errors.Is(err, sql.ErrBalanceNotFound) {
+			return "", ErrBalanceNotFound
+		}
+		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", maxID))
+		return "", ErrBalanceInternal
+	}
+	return balance.ID, nil
+}
+
+// Runs after the first one for a given day are no-ops.
+func (s *BalanceService) RunBalanceSnapshots(ctx context.Context) {
+	ticker := time.NewTicker(s.snapshotInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			takenAt := time.Now().UTC().Add(-snapshotGrace).Truncate(24 * time.Hour)
+
+			after := ""
+			for {
+				next, err := s.storage.SnapshotBalances(ctx, takenAt, after, snapshotBatchSize)
+				if err != nil {
+					s.logger.Error("failed to snapshot balances", zap.Error(err), zap.Time("taken_at", takenAt), zap.String("after_balance_id", after))
+					break
+				}
+				if next == "" {
+					break
+				}
+				after = next
+			}
+		}
+	}
+}
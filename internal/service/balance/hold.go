@@ -0,0 +1,112 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *BalanceService) CreateHold(ctx context.Context, maxID string, amount int, description string, ttl time.Duration) (*domain.Hold, error) {
+	if amount <= 0 || ttl < 0 {
+		return nil, ErrBalanceInvalid
+	}
+	if ttl == 0 {
+		ttl = s.holdTTL()
+	}
+
+	balance, err := s.storage.GetBalance(ctx, maxID, domain.WalletTypePoints)
+	if err != nil {
+		if errors.Is(err, sql.ErrBalanceNotFound) {
+			return nil, ErrBalanceNotFound
+		}
+		s.logger.Error("failed to get balance by user id", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrBalanceInternal
+	}
+
+	hold, err := s.storage.CreateHold(ctx, &domain.Hold{
+		BalanceID:   balance.ID,
+		Amount:      amount,
+		Description: description,
+		ExpiresAt:   time.Now().UTC().Add(ttl),
+	})
+	if err != nil {
+		s.logger.Error("failed to create hold", zap.Error(err), zap.String("max_id", maxID), zap.Int("amount", amount))
+		return nil, convertHoldError(err)
+	}
+
+	return hold, nil
+}
+
+func (s *BalanceService) CaptureHold(ctx context.Context, holdID string, amount int) (*domain.Hold, *domain.BalanceOperation, error) {
+	if amount < 0 {
+		return nil, nil, ErrBalanceInvalid
+	}
+
+	hold, operation, err := s.storage.CaptureHold(ctx, holdID, amount)
+	if err != nil {
+		s.logger.Error("failed to capture hold", zap.Error(err), zap.String("hold_id", holdID), zap.Int("amount", amount))
+		return nil, nil, convertHoldError(err)
+	}
+
+	return hold, operation, nil
+}
+
+func (s *BalanceService) ReleaseHold(ctx context.Context, holdID string) (*domain.Hold, error) {
+	hold, err := s.storage.ReleaseHold(ctx, holdID)
+	if err != nil {
+		s.logger.Error("failed to release hold", zap.Error(err), zap.String("hold_id", holdID))
+		return nil, convertHoldError(err)
+	}
+
+	return hold, nil
+}
+
+func (s *BalanceService) RunHoldExpiry(ctx context.Context) {
+	ticker := time.NewTicker(s.holdExpiryInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				expired, err := s.storage.ExpireHolds(ctx, time.Now().UTC(), holdExpiryBatchSize)
+				if err != nil {
+					s.logger.Error("failed to expire holds", zap.Error(err))
+					break
+				}
+				if expired > 0 {
+					s.logger.Info("holds expired", zap.Int("expired", expired))
+				}
+				if expired < holdExpiryBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+func convertHoldError(err error) error {
+	var limitErr *domain.LimitExceededError
+	switch {
+	case errors.As(err, &limitErr):
+		return limitErr
+	case errors.Is(err, sql.ErrHoldNotFound):
+		return ErrHoldNotFound
+	case errors.Is(err, sql.ErrHoldNotActive):
+		return ErrHoldNotActive
+	case errors.Is(err, sql.ErrBalanceNotFound):
+		return ErrBalanceNotFound
+	case errors.Is(err, sql.ErrBalanceNotEnough):
+		return ErrBalanceNotEnough
+	case errors.Is(err, sql.ErrBalanceInvalid):
+		return ErrBalanceInvalid
+	default:
+		return ErrBalanceInternal
+	}
+}
@@ -2,24 +2,133 @@ package balance
 
 import (
 	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
 	"DobrikaDev/user-service/utils/config"
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+const (
+	defaultHoldTTL            = 24 * time.Hour
+	defaultHoldExpiryInterval = time.Minute
+	holdExpiryBatchSize       = 100
+
+	defaultPointsExpiringWindow = 30 * 24 * time.Hour
+	defaultPointsExpiryInterval = time.Hour
+	pointsExpiryBatchSize       = 100
+
+	defaultSnapshotInterval = time.Hour
+	snapshotBatchSize       = 500
+	// snapshotGrace keeps the job away from a midnight that operations started
+	// just before it may still be committing against.
+	snapshotGrace = 5 * time.Minute
+
+	maxBalanceHistoryPoints = 400
+
+	// maxBalanceStatsRange keeps the monthly histogram to about ten years.
+	maxBalanceStatsRange  = 10 * 366 * 24 * time.Hour
+	maxBalanceStatsGroups = 50
+
+	defaultLeaderboardRefreshInterval = 5 * time.Minute
+	defaultLeaderboardLimit           = 20
+	maxLeaderboardLimit               = 100
+
+	defaultSchedulerInterval       = 30 * time.Second
+	scheduledOperationsBatchSize   = 100
+	defaultScheduledOperationLimit = 20
+	maxScheduledOperationLimit     = 100
+)
+
 type storage interface {
-	GetBalance(ctx context.Context, maxID string) (*domain.Balance, error)
-	GetBalanceOperations(ctx context.Context, maxID string, limit int, offset int) ([]*domain.BalanceOperation, int32, error)
+	GetBalance(ctx context.Context, maxID string, walletType domain.WalletType) (*domain.Balance, error)
+	GetBalanceOperations(ctx context.Context, maxID string, page sql.BalanceOperationsPage, opts ...sql.ListBalanceOperationsOpts) (*sql.GetBalanceOperationsResponse, error)
+	GetBalanceOperationTotals(ctx context.Context, maxID string, opts ...sql.ListBalanceOperationsOpts) ([]*domain.BalanceOperationReasonTotals, error)
+	CreateBalanceOperationsBatch(ctx context.Context, items []*domain.BalanceOperationBatchItem, atomic bool) ([]*domain.BalanceOperationBatchResult, error)
 	CreateBalanceOperation(ctx context.Context, operation *domain.BalanceOperation) (*domain.BalanceOperation, error)
+	ReverseBalanceOperation(ctx context.Context, operationID string, reason string) (*domain.BalanceOperation, error)
+	CreateTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error)
+	GetTrialBalance(ctx context.Context) (*domain.TrialBalance, error)
+	GetBalanceReconciliationBatch(ctx context.Context, afterBalanceID string, limit int) ([]*domain.BalanceMismatch, error)
+	CreateReconciliationAdjustment(ctx context.Context, balanceID string, description string) (*domain.BalanceOperation, error)
+	ValidateBalanceNonNegative(ctx context.Context) ([]string, error)
+	CreateHold(ctx context.Context, hold *domain.Hold) (*domain.Hold, error)
+	CaptureHold(ctx context.Context, holdID string, amount int) (*domain.Hold, *domain.BalanceOperation, error)
+	ReleaseHold(ctx context.Context, holdID string) (*domain.Hold, error)
+	ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error)
+	GetExpiringPoints(ctx context.Context, balanceID string, until time.Time) ([]*domain.ExpiringPoints, error)
+	ExpirePoints(ctx context.Context, now time.Time, limit int) (int, error)
+	GetBalanceAt(ctx context.Context, balanceID string, at time.Time) (int, error)
+	GetBalanceHistory(ctx context.Context, balanceID string, from time.Time, to time.Time, granularity domain.BalanceHistoryGranularity) ([]*domain.BalanceHistoryPoint, error)
+	GetBalanceStats(ctx context.Context, balanceID string, from time.Time, to time.Time, groupBy domain.BalanceStatsGroupBy, groupLimit int) (*domain.BalanceStats, error)
+	SnapshotBalances(ctx context.Context, takenAt time.Time, afterBalanceID string, limit int) (string, error)
+	GetBalanceOperationByID(ctx context.Context, operationID string) (*domain.BalanceOperation, error)
+	ListenBalanceChanges(ctx context.Context, handle func(change *domain.BalanceChange)) error
+	GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error)
+	RefreshLeaderboard(ctx context.Context) error
+	CreateScheduledOperation(ctx context.Context, operation *domain.ScheduledOperation) (*domain.ScheduledOperation, error)
+	GetScheduledOperations(ctx context.Context, maxID string, statuses []domain.ScheduledOperationStatus, limit int, offset int) (*sql.GetScheduledOperationsResponse, error)
+	UpdateScheduledOperation(ctx context.Context, id string, update func(operation *domain.ScheduledOperation) error) (*domain.ScheduledOperation, error)
+	ExecuteDueScheduledOperation(ctx context.Context, now time.Time, next func(operation *domain.ScheduledOperation, after time.Time) *time.Time) (bool, error)
 }
 
 type BalanceService struct {
-	storage storage
-	cfg     *config.Config
-	logger  *zap.Logger
+	storage  storage
+	watchers balanceWatchers
+	cfg      *config.Config
+	logger   *zap.Logger
 }
 
 func NewBalanceService(storage storage, cfg *config.Config, logger *zap.Logger) *BalanceService {
 	return &BalanceService{storage: storage, cfg: cfg, logger: logger}
 }
+
+func (s *BalanceService) holdTTL() time.Duration {
+	if s.cfg.Holds.DefaultTTL > 0 {
+		return s.cfg.Holds.DefaultTTL
+	}
+	return defaultHoldTTL
+}
+
+func (s *BalanceService) holdExpiryInterval() time.Duration {
+	if s.cfg.Holds.ExpiryInterval > 0 {
+		return s.cfg.Holds.ExpiryInterval
+	}
+	return defaultHoldExpiryInterval
+}
+
+func (s *BalanceService) pointsExpiringWindow() time.Duration {
+	if s.cfg.Points.ExpiringWindow > 0 {
+		return s.cfg.Points.ExpiringWindow
+	}
+	return defaultPointsExpiringWindow
+}
+
+func (s *BalanceService) pointsExpiryInterval() time.Duration {
+	if s.cfg.Points.ExpiryInterval > 0 {
+		return s.cfg.Points.ExpiryInterval
+	}
+	return defaultPointsExpiryInterval
+}
+
+func (s *BalanceService) snapshotInterval() time.Duration {
+	if s.cfg.Snapshots.Interval > 0 {
+		return s.cfg.Snapshots.Interval
+	}
+	return defaultSnapshotInterval
+}
+
+func (s *BalanceService) leaderboardRefreshInterval() time.Duration {
+	if s.cfg.Leaderboard.RefreshInterval > 0 {
+		return s.cfg.Leaderboard.RefreshInterval
+	}
+	return defaultLeaderboardRefreshInterval
+}
+
+func (s *BalanceService) schedulerInterval() time.Duration {
+	if s.cfg.Scheduler.Interval > 0 {
+		return s.cfg.Scheduler.Interval
+	}
+	return defaultSchedulerInterval
+}
@@ -0,0 +1,51 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *BalanceService) GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error) {
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, ErrBalanceInvalid
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultLeaderboardLimit
+	}
+	query.Limit = min(query.Limit, maxLeaderboardLimit)
+	if query.Period == "" {
+		query.Period = domain.LeaderboardPeriodAllTime
+	}
+
+	leaderboard, err := s.storage.GetLeaderboard(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to get leaderboard", zap.Error(err), zap.Any("query", query))
+		if errors.Is(err, sql.ErrBalanceInvalid) {
+			return nil, ErrBalanceInvalid
+		}
+		return nil, ErrBalanceInternal
+	}
+
+	return leaderboard, nil
+}
+
+func (s *BalanceService) RunLeaderboardRefresh(ctx context.Context) {
+	ticker := time.NewTicker(s.leaderboardRefreshInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.storage.RefreshLeaderboard(ctx); err != nil {
+				s.logger.Error("failed to refresh leaderboard", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+const (
+	reconciliationBatchSize             = 500
+	reconciliationAdjustmentDescription = "reconciliation adjustment"
+)
+
+func (s *BalanceService) Reconcile(ctx context.Context, fix bool) (*domain.ReconciliationReport, error) {
+	report := &domain.ReconciliationReport{}
+
+	after := ""
+	for {
+		batch, err := s.storage.GetBalanceReconciliationBatch(ctx, after, reconciliationBatchSize)
+		if err != nil {
+			s.logger.Error("failed to get reconciliation batch", zap.Error(err), zap.String("after_balance_id", after))
+			return nil, ErrBalanceInternal
+		}
+
+		for _, row := range batch {
+			report.Scanned++
+			if row.Difference() == 0 {
+				continue
+			}
+
+			s.logger.Warn("balance mismatch",
+				zap.String("balance_id", row.BalanceID),
+				zap.String("max_id", row.UserID),
+				zap.Int("actual", row.Actual),
+				zap.Int("expected", row.Expected),
+			)
+
+			if fix {
+				adjustment, err := s.storage.CreateReconciliationAdjustment(ctx, row.BalanceID, reconciliationAdjustmentDescription)
+				if err != nil {
+					s.logger.Error("failed to write reconciliation adjustment", zap.Error(err), zap.String("balance_id", row.BalanceID))
+					return nil, ErrBalanceInternal
+				}
+				if adjustment != nil {
+					row.AdjustmentOperationID = adjustment.ID
+					report.Adjusted++
+				}
+			}
+
+			report.Mismatches = append(report.Mismatches, row)
+		}
+
+		if len(batch) < reconciliationBatchSize {
+			break
+		}
+		after = batch[len(batch)-1].BalanceID
+	}
+
+	if fix {
+		negative, err := s.storage.ValidateBalanceNonNegative(ctx)
+		if err != nil {
+			s.logger.Error("failed to validate non-negative balances", zap.Error(err))
+			return nil, ErrBalanceInternal
+		}
+		for _, balanceID := range negative {
+			s.logger.Warn("balance is negative", zap.String("balance_id", balanceID))
+		}
+		report.NegativeBalances = negative
+	}
+
+	return report, nil
+}
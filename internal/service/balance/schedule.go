@@ -0,0 +1,189 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+func (s *BalanceService) ScheduleOperation(ctx context.Context, operation *domain.ScheduledOperation) (*domain.ScheduledOperation, error) {
+	if operation.MaxID == "" || operation.Amount <= 0 {
+		return nil, ErrBalanceInvalid
+	}
+	if operation.Type != domain.BalanceOperationTypeDeposit && operation.Type != domain.BalanceOperationTypeWithdraw {
+		return nil, ErrBalanceInvalid
+	}
+	if !isClientReason(operation.ReasonCode) {
+		return nil, ErrBalanceInvalid
+	}
+
+	walletType, err := resolveWalletType(operation.WalletType)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Type == domain.BalanceOperationTypeWithdraw && !walletType.Spendable() {
+		return nil, ErrBalanceInvalid
+	}
+	operation.WalletType = walletType
+
+	now := time.Now().UTC()
+	if operation.Recurring() {
+		if operation.NextRunAt != nil {
+			return nil, ErrBalanceInvalid
+		}
+		schedule, err := cron.ParseStandard(operation.CronExpression)
+		if err != nil {
+			return nil, ErrBalanceInvalid
+		}
+		next := schedule.Next(now)
+		operation.NextRunAt = &next
+	} else if operation.NextRunAt == nil || !operation.NextRunAt.After(now) {
+		return nil, ErrBalanceInvalid
+	}
+
+	if _, err := s.balanceID(ctx, operation.MaxID, walletType); err != nil {
+		return nil, err
+	}
+
+	created, err := s.storage.CreateScheduledOperation(ctx, operation)
+	if err != nil {
+		s.logger.Error("failed to schedule operation", zap.Error(err), zap.String("max_id", operation.MaxID))
+		return nil, convertScheduledOperationError(err)
+	}
+
+	return created, nil
+}
+
+func (s *BalanceService) ListScheduledOperations(ctx context.Context, maxID string, statuses []domain.ScheduledOperationStatus, limit int, offset int) ([]*domain.ScheduledOperation, int32, error) {
+	if limit < 0 || offset < 0 {
+		return nil, 0, ErrBalanceInvalid
+	}
+	if limit == 0 {
+		limit = defaultScheduledOperationLimit
+	}
+	limit = min(limit, maxScheduledOperationLimit)
+
+	response, err := s.storage.GetScheduledOperations(ctx, maxID, statuses, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to list scheduled operations", zap.Error(err), zap.String("max_id", maxID))
+		return nil, 0, ErrBalanceInternal
+	}
+
+	return response.Operations, response.Total, nil
+}
+
+func (s *BalanceService) PauseScheduledOperation(ctx context.Context, id string) (*domain.ScheduledOperation, error) {
+	return s.updateScheduledOperation(ctx, id, func(operation *domain.ScheduledOperation) error {
+		if operation.Status != domain.ScheduledOperationStatusActive {
+			return ErrScheduledOperationStatusConflict
+		}
+		operation.Status = domain.ScheduledOperationStatusPaused
+		return nil
+	})
+}
+
+// Recurring operations skip the occurrences that fell into the pause. A
+// one-off operation whose time has passed runs right away.
+func (s *BalanceService) ResumeScheduledOperation(ctx context.Context, id string) (*domain.ScheduledOperation, error) {
+	return s.updateScheduledOperation(ctx, id, func(operation *domain.ScheduledOperation) error {
+		if operation.Status != domain.ScheduledOperationStatusPaused {
+			return ErrScheduledOperationStatusConflict
+		}
+		if operation.Recurring() {
+			operation.NextRunAt = s.nextScheduledRun(operation, time.Now().UTC())
+			if operation.NextRunAt == nil {
+				return ErrScheduledOperationStatusConflict
+			}
+		}
+		operation.Status = domain.ScheduledOperationStatusActive
+		return nil
+	})
+}
+
+func (s *BalanceService) CancelScheduledOperation(ctx context.Context, id string) (*domain.ScheduledOperation, error) {
+	return s.updateScheduledOperation(ctx, id, func(operation *domain.ScheduledOperation) error {
+		if operation.Status != domain.ScheduledOperationStatusActive && operation.Status != domain.ScheduledOperationStatusPaused {
+			return ErrScheduledOperationStatusConflict
+		}
+		operation.Status = domain.ScheduledOperationStatusCancelled
+		operation.NextRunAt = nil
+		return nil
+	})
+}
+
+func (s *BalanceService) updateScheduledOperation(ctx context.Context, id string, update func(operation *domain.ScheduledOperation) error) (*domain.ScheduledOperation, error) {
+	if id == "" {
+		return nil, ErrBalanceInvalid
+	}
+
+	operation, err := s.storage.UpdateScheduledOperation(ctx, id, update)
+	if err != nil {
+		s.logger.Error("failed to update scheduled operation", zap.Error(err), zap.String("scheduled_operation_id", id))
+		return nil, convertScheduledOperationError(err)
+	}
+
+	return operation, nil
+}
+
+// nextScheduledRun returns nil for one-off operations.
+func (s *BalanceService) nextScheduledRun(operation *domain.ScheduledOperation, after time.Time) *time.Time {
+	if !operation.Recurring() {
+		return nil
+	}
+
+	schedule, err := cron.ParseStandard(operation.CronExpression)
+	if err != nil {
+		s.logger.Error("failed to parse scheduled operation cron expression", zap.Error(err), zap.String("scheduled_operation_id", operation.ID))
+		return nil
+	}
+
+	next := schedule.Next(after)
+	if next.IsZero() {
+		return nil
+	}
+	return &next
+}
+
+// Each occurrence is executed by exactly one replica.
+func (s *BalanceService) RunScheduledOperations(ctx context.Context) {
+	ticker := time.NewTicker(s.schedulerInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for range scheduledOperationsBatchSize {
+				found, err := s.storage.ExecuteDueScheduledOperation(ctx, time.Now().UTC(), s.nextScheduledRun)
+				if err != nil {
+					s.logger.Error("failed to execute scheduled operation", zap.Error(err))
+					break
+				}
+				if !found {
+					break
+				}
+			}
+		}
+	}
+}
+
+func convertScheduledOperationError(err error) error {
+	switch {
+	case errors.Is(err, ErrScheduledOperationStatusConflict):
+		return ErrScheduledOperationStatusConflict
+	case errors.Is(err, sql.ErrScheduledOperationNotFound):
+		return ErrScheduledOperationNotFound
+	case errors.Is(err, sql.ErrBalanceNotFound):
+		return ErrBalanceNotFound
+	case errors.Is(err, sql.ErrBalanceInvalid):
+		return ErrBalanceInvalid
+	default:
+		return ErrBalanceInternal
+	}
+}
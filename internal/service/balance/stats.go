@@ -0,0 +1,38 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (s *BalanceService) GetBalanceStats(ctx context.Context, maxID string, walletType domain.WalletType, from time.Time, to time.Time, groupBy domain.BalanceStatsGroupBy) (*domain.BalanceStats, error) {
+	if !from.Before(to) || to.Sub(from) > maxBalanceStatsRange {
+		return nil, ErrBalanceInvalid
+	}
+	switch groupBy {
+	case "", domain.BalanceStatsGroupByReason, domain.BalanceStatsGroupByDescription:
+	default:
+		return nil, ErrBalanceInvalid
+	}
+
+	walletType, err := resolveWalletType(walletType)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceID, err := s.balanceID(ctx, maxID, walletType)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.storage.GetBalanceStats(ctx, balanceID, from, to, groupBy, maxBalanceStatsGroups)
+	if err != nil {
+		s.logger.Error("failed to get balance stats", zap.Error(err), zap.String("max_id", maxID), zap.String("group_by", string(groupBy)))
+		return nil, ErrBalanceInternal
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,123 @@
+package balance
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	balanceWatcherBuffer        = 16
+	balanceListenerRetryBackoff = 5 * time.Second
+)
+
+// Streams are keyed by max_id; each follows one wallet.
+type balanceWatchers struct {
+	mu       sync.Mutex
+	watchers map[string]map[chan *domain.BalanceChange]domain.WalletType
+}
+
+func (w *balanceWatchers) subscribe(maxID string, walletType domain.WalletType) chan *domain.BalanceChange {
+	ch := make(chan *domain.BalanceChange, balanceWatcherBuffer)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.watchers == nil {
+		w.watchers = make(map[string]map[chan *domain.BalanceChange]domain.WalletType)
+	}
+	if w.watchers[maxID] == nil {
+		w.watchers[maxID] = make(map[chan *domain.BalanceChange]domain.WalletType)
+	}
+	w.watchers[maxID][ch] = walletType
+
+	return ch
+}
+
+func (w *balanceWatchers) unsubscribe(maxID string, ch chan *domain.BalanceChange) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.watchers[maxID], ch)
+	if len(w.watchers[maxID]) == 0 {
+		delete(w.watchers, maxID)
+	}
+}
+
+func (w *balanceWatchers) watched(maxID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.watchers[maxID]) > 0
+}
+
+// Every change carries the full balance, so one skipped by a slow watcher is
+// made up for by the next.
+func (w *balanceWatchers) publish(change *domain.BalanceChange) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	skipped := 0
+	for ch, walletType := range w.watchers[change.MaxID] {
+		if walletType != change.WalletType {
+			continue
+		}
+		select {
+		case ch <- change:
+		default:
+			skipped++
+		}
+	}
+
+	return skipped
+}
+
+// The returned function must be called once the caller stops reading.
+func (s *BalanceService) WatchBalance(ctx context.Context, maxID string, walletType domain.WalletType) (<-chan *domain.BalanceChange, func(), error) {
+	walletType, err := resolveWalletType(walletType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.balanceID(ctx, maxID, walletType); err != nil {
+		return nil, nil, err
+	}
+
+	ch := s.watchers.subscribe(maxID, walletType)
+	return ch, func() { s.watchers.unsubscribe(maxID, ch) }, nil
+}
+
+// The listener reconnects whenever its connection drops.
+func (s *BalanceService) RunBalanceListener(ctx context.Context) {
+	for {
+		err := s.storage.ListenBalanceChanges(ctx, func(change *domain.BalanceChange) {
+			if !s.watchers.watched(change.MaxID) {
+				return
+			}
+
+			if change.OperationID != "" {
+				operation, err := s.storage.GetBalanceOperationByID(ctx, change.OperationID)
+				if err != nil {
+					s.logger.Error("failed to load operation for balance change", zap.Error(err), zap.String("operation_id", change.OperationID))
+				}
+				change.Operation = operation
+			}
+
+			if skipped := s.watchers.publish(change); skipped > 0 {
+				s.logger.Warn("skipped balance change for slow watchers", zap.String("max_id", change.MaxID), zap.Int("skipped", skipped))
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.logger.Error("balance listener stopped, reconnecting", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(balanceListenerRetryBackoff):
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrIdempotencyKeyReused   = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyKeyInternal = errors.New("idempotency key internal error")
+)
@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Do runs fn at most once per (scope, key). The key is reserved in the same
+// transaction as fn, so a failed fn releases it and the caller may retry.
+// A repeated call with the same request hash returns the stored response.
+func (s *IdempotencyService) Do(ctx context.Context, scope string, key string, requestHash string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	var response []byte
+	err := s.storage.Do(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+		stored, reserved, err := s.storage.ReserveIdempotencyKey(txCtx, &domain.IdempotencyKey{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl()),
+		})
+		if err != nil {
+			return ErrIdempotencyKeyInternal
+		}
+
+		if !reserved {
+			if stored.RequestHash != requestHash {
+				s.logger.Warn("idempotency key reused with a different request", zap.String("scope", scope), zap.String("key", key))
+				return ErrIdempotencyKeyReused
+			}
+			response = stored.Response
+			return nil
+		}
+
+		response, err = fn(txCtx)
+		if err != nil {
+			return err
+		}
+
+		if err := s.storage.SaveIdempotencyResponse(txCtx, scope, key, response); err != nil {
+			return ErrIdempotencyKeyInternal
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *IdempotencyService) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(s.cleanupInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.storage.DeleteExpiredIdempotencyKeys(ctx, time.Now().UTC())
+			if err != nil {
+				s.logger.Error("failed to clean up idempotency keys", zap.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Info("expired idempotency keys deleted", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
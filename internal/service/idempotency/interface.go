@@ -0,0 +1,46 @@
+package idempotency
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/utils/config"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTTL             = 24 * time.Hour
+	defaultCleanupInterval = time.Hour
+)
+
+type storage interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+	ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, bool, error)
+	SaveIdempotencyResponse(ctx context.Context, scope string, key string, response []byte) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type IdempotencyService struct {
+	storage storage
+	cfg     *config.Config
+	logger  *zap.Logger
+}
+
+func NewIdempotencyService(storage storage, cfg *config.Config, logger *zap.Logger) *IdempotencyService {
+	return &IdempotencyService{storage: storage, cfg: cfg, logger: logger}
+}
+
+func (s *IdempotencyService) ttl() time.Duration {
+	if s.cfg.Idempotency.TTL > 0 {
+		return s.cfg.Idempotency.TTL
+	}
+	return defaultTTL
+}
+
+func (s *IdempotencyService) cleanupInterval() time.Duration {
+	if s.cfg.Idempotency.CleanupInterval > 0 {
+		return s.cfg.Idempotency.CleanupInterval
+	}
+	return defaultCleanupInterval
+}
@@ -0,0 +1,43 @@
+package reputation
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Users whose score falls below their group's threshold are demoted.
+func (s *ReputationService) RunReputationDecay(ctx context.Context) {
+	rate := s.cfg.Reputation.DecayRate
+	if rate <= 0 {
+		return
+	}
+	rate = min(rate, 1)
+
+	ticker := time.NewTicker(s.decayInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			inactiveSince := now.Add(-s.decayPeriod())
+			for {
+				decayed, err := s.storage.DecayReputation(ctx, now, inactiveSince, rate, reputationDecayBatchSize)
+				if err != nil {
+					s.logger.Error("failed to decay reputation", zap.Error(err))
+					break
+				}
+				if decayed > 0 {
+					s.logger.Info("reputation decayed", zap.Int("users", decayed))
+				}
+				if decayed < reputationDecayBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
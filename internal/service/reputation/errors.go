@@ -0,0 +1,10 @@
+package reputation
+
+import "errors"
+
+var (
+	ErrReputationNotFound           = errors.New("reputation not found")
+	ErrReputationInvalid            = errors.New("reputation invalid")
+	ErrReputationEventAlreadyExists = errors.New("reputation event already exists")
+	ErrReputationInternal           = errors.New("reputation internal error")
+)
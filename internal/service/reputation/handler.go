@@ -0,0 +1,102 @@
+package reputation
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Rewards must be positive and penalties negative.
+func (s *ReputationService) AddReputationEvent(ctx context.Context, event *domain.ReputationEvent) (*domain.ReputationEvent, *domain.Reputation, error) {
+	if event.MaxID == "" || event.Amount == 0 {
+		return nil, nil, ErrReputationInvalid
+	}
+	switch event.Reason {
+	case domain.ReputationEventReasonTaskCompleted, domain.ReputationEventReasonEventParticipation:
+		if event.Amount < 0 {
+			return nil, nil, ErrReputationInvalid
+		}
+	case domain.ReputationEventReasonModerationPenalty:
+		if event.Amount > 0 {
+			return nil, nil, ErrReputationInvalid
+		}
+	case domain.ReputationEventReasonAdjustment, domain.ReputationEventReasonOther:
+	default:
+		return nil, nil, ErrReputationInvalid
+	}
+	event.Description = strings.TrimSpace(event.Description)
+	event.ReferenceID = strings.TrimSpace(event.ReferenceID)
+
+	created, err := s.storage.AddReputationEvent(ctx, event)
+	if err != nil {
+		s.logger.Error("failed to add reputation event", zap.Error(err), zap.String("max_id", event.MaxID))
+		return nil, nil, convertReputationError(err)
+	}
+
+	reputation, err := s.storage.GetReputation(ctx, event.MaxID)
+	if err != nil {
+		s.logger.Error("failed to get reputation", zap.Error(err), zap.String("max_id", event.MaxID))
+		return nil, nil, convertReputationError(err)
+	}
+
+	return created, reputation, nil
+}
+
+func (s *ReputationService) GetReputation(ctx context.Context, maxID string, limit int, offset int) (*domain.Reputation, []*domain.ReputationEvent, int32, error) {
+	if maxID == "" || limit < 0 || offset < 0 {
+		return nil, nil, 0, ErrReputationInvalid
+	}
+	if limit == 0 {
+		limit = defaultReputationEventLimit
+	}
+	limit = min(limit, maxReputationEventLimit)
+
+	reputation, err := s.storage.GetReputation(ctx, maxID)
+	if err != nil {
+		s.logger.Error("failed to get reputation", zap.Error(err), zap.String("max_id", maxID))
+		return nil, nil, 0, convertReputationError(err)
+	}
+
+	events, err := s.storage.GetReputationEvents(ctx, maxID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get reputation events", zap.Error(err), zap.String("max_id", maxID))
+		return nil, nil, 0, convertReputationError(err)
+	}
+
+	return reputation, events.Events, events.Total, nil
+}
+
+func (s *ReputationService) GetReputationHistory(ctx context.Context, maxID string, limit int, offset int) ([]*domain.ReputationGroupChange, int32, error) {
+	if maxID == "" || limit < 0 || offset < 0 {
+		return nil, 0, ErrReputationInvalid
+	}
+	if limit == 0 {
+		limit = defaultReputationEventLimit
+	}
+	limit = min(limit, maxReputationEventLimit)
+
+	history, err := s.storage.GetReputationHistory(ctx, maxID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get reputation history", zap.Error(err), zap.String("max_id", maxID))
+		return nil, 0, convertReputationError(err)
+	}
+
+	return history.Changes, history.Total, nil
+}
+
+func convertReputationError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrUserNotFound):
+		return ErrReputationNotFound
+	case errors.Is(err, sql.ErrReputationInvalid):
+		return ErrReputationInvalid
+	case errors.Is(err, sql.ErrReputationEventAlreadyExists):
+		return ErrReputationEventAlreadyExists
+	default:
+		return ErrReputationInternal
+	}
+}
@@ -0,0 +1,57 @@
+package reputation
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sql"
+	"DobrikaDev/user-service/utils/config"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReputationEventLimit = 20
+	maxReputationEventLimit     = 100
+
+	defaultReputationDecayPeriod   = 30 * 24 * time.Hour
+	defaultReputationDecayInterval = time.Hour
+	reputationDecayBatchSize       = 100
+
+	reputationRecalculationBatchSize    = 500
+	defaultReputationRecalculationLimit = 5000
+	maxReputationRecalculationLimit     = 50000
+)
+
+type storage interface {
+	AddReputationEvent(ctx context.Context, event *domain.ReputationEvent) (*domain.ReputationEvent, error)
+	GetReputation(ctx context.Context, maxID string) (*domain.Reputation, error)
+	GetReputationEvents(ctx context.Context, maxID string, limit int, offset int) (*sql.GetReputationEventsResponse, error)
+	GetReputationHistory(ctx context.Context, maxID string, limit int, offset int) (*sql.GetReputationHistoryResponse, error)
+	RecalculateReputationGroups(ctx context.Context, afterMaxID string, limit int, dryRun bool) (*sql.RecalculateReputationGroupsResponse, error)
+	DecayReputation(ctx context.Context, now time.Time, inactiveSince time.Time, rate float64, limit int) (int, error)
+}
+
+type ReputationService struct {
+	storage storage
+	cfg     *config.Config
+	logger  *zap.Logger
+}
+
+func NewReputationService(storage storage, cfg *config.Config, logger *zap.Logger) *ReputationService {
+	return &ReputationService{storage: storage, cfg: cfg, logger: logger}
+}
+
+func (s *ReputationService) decayPeriod() time.Duration {
+	if s.cfg.Reputation.DecayPeriod > 0 {
+		return s.cfg.Reputation.DecayPeriod
+	}
+	return defaultReputationDecayPeriod
+}
+
+func (s *ReputationService) decayInterval() time.Duration {
+	if s.cfg.Reputation.DecayInterval > 0 {
+		return s.cfg.Reputation.DecayInterval
+	}
+	return defaultReputationDecayInterval
+}
@@ -0,0 +1,61 @@
+package reputation
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Each batch commits on its own. With dryRun nothing is changed.
+func (s *ReputationService) RecalculateReputationGroups(ctx context.Context, dryRun bool, afterMaxID string, limit int) (*domain.ReputationRecalculationReport, error) {
+	if limit < 0 {
+		return nil, ErrReputationInvalid
+	}
+	if limit == 0 {
+		limit = defaultReputationRecalculationLimit
+	}
+	limit = min(limit, maxReputationRecalculationLimit)
+
+	report := &domain.ReputationRecalculationReport{DryRun: dryRun}
+	moves := make(map[[2]int]*domain.ReputationGroupMove)
+
+	after := afterMaxID
+	for report.Scanned < limit {
+		batchSize := min(reputationRecalculationBatchSize, limit-report.Scanned)
+
+		batch, err := s.storage.RecalculateReputationGroups(ctx, after, batchSize, dryRun)
+		if err != nil {
+			s.logger.Error("failed to recalculate reputation groups", zap.Error(err), zap.String("after_max_id", after))
+			return nil, convertReputationError(err)
+		}
+
+		report.Scanned += batch.Scanned
+		for _, change := range batch.Changes {
+			key := [2]int{change.FromGroupID, change.ToGroupID}
+			move, ok := moves[key]
+			if !ok {
+				move = &domain.ReputationGroupMove{
+					FromGroupID:   change.FromGroupID,
+					FromGroupName: change.FromGroupName,
+					ToGroupID:     change.ToGroupID,
+					ToGroupName:   change.ToGroupName,
+					Promoted:      change.Promoted,
+				}
+				moves[key] = move
+				report.Moves = append(report.Moves, move)
+			}
+			move.Count++
+			report.Moved++
+		}
+
+		if batch.Scanned < batchSize {
+			report.NextMaxID = ""
+			return report, nil
+		}
+		after = batch.LastMaxID
+		report.NextMaxID = after
+	}
+
+	return report, nil
+}
@@ -7,4 +7,5 @@ var (
 	ErrReputationGroupAlreadyExists = errors.New("reputation group already exists")
 	ErrReputationGroupInvalid       = errors.New("reputation group invalid")
 	ErrReputationGroupInternal      = errors.New("reputation group internal error")
+	ErrReputationGroupInUse         = errors.New("reputation group has members")
 )
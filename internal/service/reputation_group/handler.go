@@ -5,6 +5,8 @@ import (
 	"DobrikaDev/user-service/internal/storage/sql"
 	"context"
 	"errors"
+	"strings"
+	"unicode/utf8"
 
 	"go.uber.org/zap"
 )
@@ -29,3 +31,132 @@ func (s *ReputationGroupService) GetReputationGroupByID(ctx context.Context, id
 	}
 	return group, nil
 }
+
+func (s *ReputationGroupService) GetReputationGroupLimits(ctx context.Context, groupID int) ([]*domain.ReputationGroupLimit, error) {
+	if _, err := s.GetReputationGroupByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.storage.GetReputationGroupLimits(ctx, groupID)
+	if err != nil {
+		s.logger.Error("failed to get reputation group limits", zap.Error(err), zap.Int("id", groupID))
+		return nil, ErrReputationGroupInternal
+	}
+	return limits, nil
+}
+
+func (s *ReputationGroupService) SetReputationGroupLimits(ctx context.Context, groupID int, limits []*domain.ReputationGroupLimit) ([]*domain.ReputationGroupLimit, error) {
+	type limitKey struct {
+		direction domain.LimitDirection
+		window    int
+	}
+	seen := make(map[limitKey]struct{}, len(limits))
+	for _, limit := range limits {
+		if limit.Direction != domain.LimitDirectionEarn && limit.Direction != domain.LimitDirectionSpend {
+			return nil, ErrReputationGroupInvalid
+		}
+		if limit.WindowSeconds <= 0 || limit.MaxAmount < 0 {
+			return nil, ErrReputationGroupInvalid
+		}
+		key := limitKey{direction: limit.Direction, window: limit.WindowSeconds}
+		if _, ok := seen[key]; ok {
+			return nil, ErrReputationGroupInvalid
+		}
+		seen[key] = struct{}{}
+	}
+
+	updated, err := s.storage.SetReputationGroupLimits(ctx, groupID, limits)
+	if err != nil {
+		s.logger.Error("failed to set reputation group limits", zap.Error(err), zap.Int("id", groupID))
+		switch {
+		case errors.Is(err, sql.ErrReputationGroupNotFound):
+			return nil, ErrReputationGroupNotFound
+		case errors.Is(err, sql.ErrReputationGroupInvalid):
+			return nil, ErrReputationGroupInvalid
+		default:
+			return nil, ErrReputationGroupInternal
+		}
+	}
+	return updated, nil
+}
+
+// Names and thresholds are unique, so the groups are strictly ordered.
+func (s *ReputationGroupService) CreateReputationGroup(ctx context.Context, group *domain.ReputationGroup) (*domain.ReputationGroup, error) {
+	group.ID = 0
+	if err := validateReputationGroup(group); err != nil {
+		return nil, err
+	}
+
+	created, err := s.storage.CreateReputationGroup(ctx, group)
+	if err != nil {
+		s.logger.Error("failed to create reputation group", zap.Error(err), zap.String("name", group.Name))
+		return nil, convertReputationGroupError(err)
+	}
+	return created, nil
+}
+
+// Members stay where they are until their reputation changes.
+func (s *ReputationGroupService) UpdateReputationGroup(ctx context.Context, group *domain.ReputationGroup) (*domain.ReputationGroup, error) {
+	if group.ID <= 0 {
+		return nil, ErrReputationGroupInvalid
+	}
+	if err := validateReputationGroup(group); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.storage.UpdateReputationGroup(ctx, group)
+	if err != nil {
+		s.logger.Error("failed to update reputation group", zap.Error(err), zap.Int("id", group.ID))
+		return nil, convertReputationGroupError(err)
+	}
+	return updated, nil
+}
+
+func (s *ReputationGroupService) DeleteReputationGroup(ctx context.Context, id int, moveMembersToID int) (int, error) {
+	if id <= 0 || moveMembersToID < 0 || moveMembersToID == id {
+		return 0, ErrReputationGroupInvalid
+	}
+	if id == domain.DefaultReputationGroupID {
+		return 0, ErrReputationGroupInvalid
+	}
+
+	moved, err := s.storage.DeleteReputationGroup(ctx, id, moveMembersToID)
+	if err != nil {
+		s.logger.Error("failed to delete reputation group", zap.Error(err), zap.Int("id", id), zap.Int("move_members_to_id", moveMembersToID))
+		return 0, convertReputationGroupError(err)
+	}
+	return moved, nil
+}
+
+func validateReputationGroup(group *domain.ReputationGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" || utf8.RuneCountInString(group.Name) > maxReputationGroupNameLength {
+		return ErrReputationGroupInvalid
+	}
+	if group.Coefficient <= 0 || group.Coefficient > maxReputationGroupCoefficient {
+		return ErrReputationGroupInvalid
+	}
+	if group.ReputationNeed < 0 {
+		return ErrReputationGroupInvalid
+	}
+	// The default group is the floor everyone falls back to.
+	if (group.ID == domain.DefaultReputationGroupID) != (group.ReputationNeed == 0) {
+		return ErrReputationGroupInvalid
+	}
+	return nil
+}
+
+func convertReputationGroupError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrReputationGroupNotFound):
+		return ErrReputationGroupNotFound
+	case errors.Is(err, sql.ErrReputationGroupAlreadyExists):
+		return ErrReputationGroupAlreadyExists
+	case errors.Is(err, sql.ErrReputationGroupInvalid):
+		return ErrReputationGroupInvalid
+	case errors.Is(err, sql.ErrReputationGroupInUse):
+		return ErrReputationGroupInUse
+	default:
+		return ErrReputationGroupInternal
+	}
+}
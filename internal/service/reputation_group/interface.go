@@ -8,9 +8,21 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	maxReputationGroupNameLength = 255
+	// maxReputationGroupCoefficient is the largest value reputation_groups
+	// can store in NUMERIC(4,2).
+	maxReputationGroupCoefficient = 99.99
+)
+
 type storage interface {
 	GetReputationGroups(ctx context.Context) ([]*domain.ReputationGroup, error)
 	GetReputationGroupByID(ctx context.Context, id int) (*domain.ReputationGroup, error)
+	GetReputationGroupLimits(ctx context.Context, groupID int) ([]*domain.ReputationGroupLimit, error)
+	SetReputationGroupLimits(ctx context.Context, groupID int, limits []*domain.ReputationGroupLimit) ([]*domain.ReputationGroupLimit, error)
+	CreateReputationGroup(ctx context.Context, group *domain.ReputationGroup) (*domain.ReputationGroup, error)
+	UpdateReputationGroup(ctx context.Context, group *domain.ReputationGroup) (*domain.ReputationGroup, error)
+	DeleteReputationGroup(ctx context.Context, id int, moveMembersToID int) (int, error)
 }
 
 type ReputationGroupService struct {
@@ -5,6 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -13,15 +15,20 @@ import (
 	"go.uber.org/zap"
 )
 
-func (s *SqlStorage) GetBalance(ctx context.Context, maxID string) (*domain.Balance, error) {
+// signedAmountSQL is the effect an operation aliased as bo had on its balance.
+const signedAmountSQL = "CASE WHEN bo.type IN ('withdraw', 'expire') THEN -bo.amount ELSE bo.amount END"
+
+func (s *SqlStorage) GetBalance(ctx context.Context, maxID string, walletType domain.WalletType) (*domain.Balance, error) {
 	query, args := sq.Select(
 		"b.id",
 		"b.user_id",
+		"b.wallet_type",
 		"b.balance",
+		"b.held",
 	).
 		From("balances b").
 		Join("users u ON u.max_id = b.user_id").
-		Where(sq.Eq{"u.max_id": maxID}).
+		Where(sq.Eq{"u.max_id": maxID, "b.wallet_type": walletType}).
 		PlaceholderFormat(sq.Dollar).
 		MustSql()
 
@@ -31,59 +38,232 @@ func (s *SqlStorage) GetBalance(ctx context.Context, maxID string) (*domain.Bala
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrBalanceNotFound
 		}
-		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", maxID))
+		s.logger.Error("failed to get balance", zap.Error(err), zap.String("max_id", maxID), zap.String("wallet_type", walletType.String()))
 		return nil, ErrBalanceInternal
 	}
 
 	return &balance, nil
 }
 
-func (s *SqlStorage) GetBalanceOperations(ctx context.Context, maxID string, limit int, offset int) ([]*domain.BalanceOperation, int32, error) {
-	sb := sq.Select(
+type GetBalanceOperationsResponse struct {
+	Operations []*domain.BalanceOperation     `json:"operations"`
+	Total      int32                          `json:"total"`
+	NextCursor *domain.BalanceOperationCursor `json:"next_cursor"`
+}
+
+type BalanceOperationsPage struct {
+	Limit     int
+	Offset    int
+	Cursor    *domain.BalanceOperationCursor
+	SkipTotal bool
+}
+
+type ListBalanceOperationsOpts func(sq.SelectBuilder) sq.SelectBuilder
+
+func ListBalanceOperationsWithTypes(types []domain.BalanceOperationType) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		if len(types) == 0 {
+			return sb
+		}
+
+		values := make([]string, 0, len(types))
+		for _, t := range types {
+			values = append(values, string(t))
+		}
+
+		return sb.Where(sq.Eq{"bo.type": values})
+	}
+}
+
+func ListBalanceOperationsWithWalletType(walletType domain.WalletType) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		return sb.Where(sq.Eq{"bo.wallet_type": walletType})
+	}
+}
+
+func ListBalanceOperationsWithReasonCodes(reasons []domain.BalanceOperationReason) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		if len(reasons) == 0 {
+			return sb
+		}
+
+		values := make([]string, 0, len(reasons))
+		for _, reason := range reasons {
+			values = append(values, string(reason))
+		}
+
+		return sb.Where(sq.Eq{"bo.reason_code": values})
+	}
+}
+
+func ListBalanceOperationsWithCreatedFrom(from time.Time) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		return sb.Where(sq.GtOrEq{"bo.created_at": from})
+	}
+}
+
+func ListBalanceOperationsWithCreatedTo(to time.Time) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		return sb.Where(sq.Lt{"bo.created_at": to})
+	}
+}
+
+func ListBalanceOperationsWithAmountMin(amount int) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		return sb.Where(sq.GtOrEq{"bo.amount": amount})
+	}
+}
+
+func ListBalanceOperationsWithAmountMax(amount int) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		return sb.Where(sq.LtOrEq{"bo.amount": amount})
+	}
+}
+
+func ListBalanceOperationsWithDescription(text string) ListBalanceOperationsOpts {
+	return func(sb sq.SelectBuilder) sq.SelectBuilder {
+		if text == "" {
+			return sb
+		}
+		return sb.Where(sq.ILike{"bo.description": "%" + likeEscaper.Replace(text) + "%"})
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// selectBalanceOperations selects operations aliased as bo.
+func selectBalanceOperations() sq.SelectBuilder {
+	return sq.Select(
 		"bo.id",
 		"bo.balance_id",
+		"bo.wallet_type",
 		"bo.amount",
 		"bo.type",
 		"bo.description",
+		"COALESCE(bo.transfer_id, '') AS transfer_id",
 		"bo.created_at",
+		"COALESCE(bo.reverses_operation_id, '') AS reverses_operation_id",
+		"COALESCE(r.id, '') AS reversed_by_operation_id",
+		"bo.reputation_amount",
+		"bo.reason_code",
+		"bo.metadata",
+		"COALESCE(bo.base_amount, 0) AS base_amount",
+		"COALESCE(bo.coefficient, 0) AS coefficient",
+		"COALESCE(bo.actor_max_id, '') AS actor_max_id",
+		"COALESCE(bo.justification, '') AS justification",
 	).
 		From("balance_operations bo").
+		LeftJoin("balance_operations r ON r.reverses_operation_id = bo.id").
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *SqlStorage) GetBalanceOperationByID(ctx context.Context, operationID string) (*domain.BalanceOperation, error) {
+	query, args := selectBalanceOperations().Where(sq.Eq{"bo.id": operationID}).MustSql()
+
+	var operation domain.BalanceOperation
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &operation, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBalanceOperationNotFound
+		}
+		s.logger.Error("failed to get balance operation", zap.Error(err), zap.String("operation_id", operationID))
+		return nil, ErrBalanceInternal
+	}
+
+	return &operation, nil
+}
+
+func (s *SqlStorage) GetBalanceOperations(ctx context.Context, maxID string, page BalanceOperationsPage, opts ...ListBalanceOperationsOpts) (*GetBalanceOperationsResponse, error) {
+	sb := selectBalanceOperations().
 		Join("balances b ON b.id = bo.balance_id").
 		Where(sq.Eq{"b.user_id": maxID}).
-		OrderBy("bo.created_at DESC").
+		OrderBy("bo.created_at DESC", "bo.id DESC").
 		PlaceholderFormat(sq.Dollar)
 
-	if limit > 0 {
-		sb = sb.Limit(uint64(limit))
+	for _, opt := range opts {
+		sb = opt(sb)
 	}
-	if offset > 0 {
-		sb = sb.Offset(uint64(offset))
+
+	if page.Cursor != nil {
+		sb = sb.Where(sq.Expr("(bo.created_at, bo.id) < (?, ?)", page.Cursor.CreatedAt, page.Cursor.ID))
+	}
+	if page.Limit > 0 {
+		sb = sb.Limit(uint64(page.Limit))
+	}
+	if page.Offset > 0 {
+		sb = sb.Offset(uint64(page.Offset))
 	}
 
 	query, args := sb.MustSql()
 
-	operations := make([]*domain.BalanceOperation, 0, limit)
+	operations := make([]*domain.BalanceOperation, 0, page.Limit)
 	err := s.trf.Transaction(ctx).SelectContext(ctx, &operations, query, args...)
 	if err != nil {
 		s.logger.Error("failed to get balance operations", zap.Error(err), zap.String("max_id", maxID))
-		return nil, 0, ErrBalanceInternal
+		return nil, ErrBalanceInternal
 	}
 
-	countQuery, countArgs := sq.Select("COUNT(*)").
+	response := &GetBalanceOperationsResponse{Operations: operations}
+	if page.Limit > 0 && len(operations) == page.Limit {
+		last := operations[len(operations)-1]
+		response.NextCursor = &domain.BalanceOperationCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	if page.SkipTotal {
+		return response, nil
+	}
+
+	cb := sq.Select("COUNT(*)").
 		From("balance_operations bo").
 		Join("balances b ON b.id = bo.balance_id").
 		Where(sq.Eq{"b.user_id": maxID}).
-		PlaceholderFormat(sq.Dollar).
-		MustSql()
+		PlaceholderFormat(sq.Dollar)
 
-	var total int32
-	err = s.trf.Transaction(ctx).GetContext(ctx, &total, countQuery, countArgs...)
+	for _, opt := range opts {
+		cb = opt(cb)
+	}
+
+	countQuery, countArgs := cb.MustSql()
+
+	err = s.trf.Transaction(ctx).GetContext(ctx, &response.Total, countQuery, countArgs...)
 	if err != nil {
 		s.logger.Error("failed to count balance operations", zap.Error(err), zap.String("max_id", maxID))
-		return nil, 0, ErrBalanceInternal
+		return nil, ErrBalanceInternal
 	}
 
-	return operations, total, nil
+	return response, nil
+}
+
+// An empty maxID aggregates over every balance.
+
+func (s *SqlStorage) GetBalanceOperationTotals(ctx context.Context, maxID string, opts ...ListBalanceOperationsOpts) ([]*domain.BalanceOperationReasonTotals, error) {
+	sb := sq.Select(
+		"bo.reason_code",
+		"COUNT(*) AS count",
+		"COALESCE(SUM("+signedAmountSQL+") FILTER (WHERE "+signedAmountSQL+" > 0), 0) AS credited",
+		"COALESCE(-SUM("+signedAmountSQL+") FILTER (WHERE "+signedAmountSQL+" < 0), 0) AS debited",
+	).
+		From("balance_operations bo").
+		GroupBy("bo.reason_code").
+		OrderBy("bo.reason_code").
+		PlaceholderFormat(sq.Dollar)
+
+	if maxID != "" {
+		sb = sb.Join("balances b ON b.id = bo.balance_id").Where(sq.Eq{"b.user_id": maxID})
+	}
+
+	for _, opt := range opts {
+		sb = opt(sb)
+	}
+
+	query, args := sb.MustSql()
+
+	totals := make([]*domain.BalanceOperationReasonTotals, 0, 8)
+	if err := s.trf.Transaction(ctx).SelectContext(ctx, &totals, query, args...); err != nil {
+		s.logger.Error("failed to get balance operation totals", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrBalanceInternal
+	}
+
+	return totals, nil
 }
 
 func (s *SqlStorage) CreateBalanceOperation(ctx context.Context, operation *domain.BalanceOperation) (*domain.BalanceOperation, error) {
@@ -92,136 +272,411 @@ func (s *SqlStorage) CreateBalanceOperation(ctx context.Context, operation *doma
 	}
 
 	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
-		db := s.trf.Transaction(txCtx)
-
-		var balance domain.Balance
-		err := db.GetContext(txCtx, &balance, "SELECT id, user_id, balance FROM balances WHERE id = $1", operation.BalanceID)
+		balance, err := s.lockBalance(txCtx, operation.BalanceID)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return ErrBalanceNotFound
-			}
-			s.logger.Error("failed to get balance for operation", zap.Error(err), zap.String("balance_id", operation.BalanceID))
-			return ErrBalanceInternal
+			return err
+		}
+
+		return s.createBalanceOperation(txCtx, balance, operation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// Every check runs before the first write, so a rejected operation leaves
+// the transaction usable.
+func (s *SqlStorage) createBalanceOperation(ctx context.Context, balance *domain.Balance, operation *domain.BalanceOperation) error {
+	if operation.Type == domain.BalanceOperationTypeWithdraw && !balance.WalletType.Spendable() {
+		return ErrBalanceInvalid
+	}
+
+	if operation.Type == domain.BalanceOperationTypeAdjustment {
+		if err := s.checkAdjustmentActor(ctx, operation.ActorMaxID); err != nil {
+			return err
 		}
+	}
+
+	if operation.BaseAmount > 0 {
+		if err := s.applyGroupCoefficient(ctx, balance, operation); err != nil {
+			return err
+		}
+	}
 
+	if err := s.checkBalanceLimits(ctx, balance, operation, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	operation.ReputationAmount = 0
+	if balance.WalletType.CountsTowardsReputation() {
 		switch operation.Type {
-		case domain.BalanceOperationTypeWithdraw:
-			if balance.Balance < operation.Amount {
-				return ErrBalanceNotEnough
-			}
-			balance.Balance -= operation.Amount
 		case domain.BalanceOperationTypeDeposit:
-			balance.Balance += operation.Amount
-		default:
-			return ErrBalanceInvalid
+			operation.ReputationAmount = operation.Amount
+		case domain.BalanceOperationTypeAdjustment:
+			if operation.CountsTowardsReputation {
+				operation.ReputationAmount = operation.Amount
+			}
 		}
+	}
+
+	if err := s.applyBalanceOperation(ctx, balance, operation); err != nil {
+		return err
+	}
+
+	if event := reputationEventFor(balance, operation); event != nil {
+		return s.addReputationEvent(ctx, event)
+	}
 
-		opID := operation.ID
-		if opID == "" {
-			opID = uuid.NewString()
+	return nil
+}
+
+// The actor row is share-locked so the role cannot change before the
+// adjustment commits.
+func (s *SqlStorage) checkAdjustmentActor(ctx context.Context, actorMaxID string) error {
+	if actorMaxID == "" {
+		return ErrBalanceForbidden
+	}
+
+	var role domain.UserRole
+	err := s.trf.Transaction(ctx).GetContext(ctx, &role, "SELECT role FROM users WHERE max_id = $1 FOR SHARE", actorMaxID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBalanceForbidden
+		}
+		s.logger.Error("failed to get adjustment actor", zap.Error(err), zap.String("actor_max_id", actorMaxID))
+		return ErrBalanceInternal
+	}
+	if role != domain.UserRoleAdmin {
+		return ErrBalanceForbidden
+	}
+
+	return nil
+}
+
+// Reading the group under the balance lock keeps it consistent with the
+// reputation update that may follow.
+func (s *SqlStorage) applyGroupCoefficient(ctx context.Context, balance *domain.Balance, operation *domain.BalanceOperation) error {
+	if operation.Type != domain.BalanceOperationTypeDeposit {
+		return ErrBalanceInvalid
+	}
+
+	var coefficient float64
+	err := s.trf.Transaction(ctx).GetContext(ctx, &coefficient,
+		`SELECT rg.coefficient
+		 FROM users u
+		 JOIN reputation_groups rg ON rg.id = u.reputation_group_id
+		 WHERE u.max_id = $1`,
+		balance.UserID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBalanceNotFound
+		}
+		s.logger.Error("failed to get reputation group coefficient", zap.Error(err), zap.String("user_id", balance.UserID))
+		return ErrBalanceInternal
+	}
+
+	operation.Coefficient = coefficient
+	operation.Amount = domain.ApplyCoefficient(operation.BaseAmount, coefficient)
+	if operation.Amount <= 0 {
+		return ErrBalanceInvalid
+	}
+
+	return nil
+}
+
+func (s *SqlStorage) CreateTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+	if transfer == nil || transfer.FromBalance == nil || transfer.ToBalance == nil || transfer.FromBalance.ID == transfer.ToBalance.ID {
+		return nil, ErrBalanceInvalid
+	}
+
+	if transfer.ID == "" {
+		transfer.ID = uuid.NewString()
+	}
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		// Both rows are locked in id order so that two opposite transfers
+		// between the same users cannot deadlock each other.
+		ids := []string{transfer.FromBalance.ID, transfer.ToBalance.ID}
+		slices.Sort(ids)
+
+		locked := make(map[string]*domain.Balance, len(ids))
+		for _, id := range ids {
+			balance, err := s.lockBalance(txCtx, id)
+			if err != nil {
+				return err
+			}
+			locked[id] = balance
+		}
+
+		from := locked[transfer.FromBalance.ID]
+		to := locked[transfer.ToBalance.ID]
+
+		fromOperation := &domain.BalanceOperation{
+			Amount:      transfer.Amount,
+			Type:        domain.BalanceOperationTypeWithdraw,
+			Description: transfer.Description,
+			TransferID:  transfer.ID,
+			ReasonCode:  domain.BalanceOperationReasonTransfer,
+		}
+		toOperation := &domain.BalanceOperation{
+			Amount:      transfer.Amount,
+			Type:        domain.BalanceOperationTypeDeposit,
+			Description: transfer.Description,
+			TransferID:  transfer.ID,
+			ReasonCode:  domain.BalanceOperationReasonTransfer,
 		}
 
 		now := time.Now().UTC()
-		_, err = db.ExecContext(txCtx,
-			"INSERT INTO balance_operations (id, balance_id, amount, type, description, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
-			opID,
-			operation.BalanceID,
-			operation.Amount,
-			operation.Type,
-			operation.Description,
-			now,
+		if err := s.checkBalanceLimits(txCtx, from, fromOperation, now); err != nil {
+			return err
+		}
+		if err := s.checkBalanceLimits(txCtx, to, toOperation, now); err != nil {
+			return err
+		}
+
+		if _, err := s.moveBalance(txCtx, from, fromOperation); err != nil {
+			return err
+		}
+		consumed, err := s.consumeLots(txCtx, from.ID, transfer.Amount)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.moveBalance(txCtx, to, toOperation); err != nil {
+			return err
+		}
+		if to.WalletType.Expires() {
+			if err := s.createCarriedLots(txCtx, toOperation, consumed); err != nil {
+				return err
+			}
+		}
+
+		transfer.FromBalance = from
+		transfer.ToBalance = to
+		transfer.FromOperation = fromOperation
+		transfer.ToOperation = toOperation
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (s *SqlStorage) ReverseBalanceOperation(ctx context.Context, operationID string, reason string) (*domain.BalanceOperation, error) {
+	var reversal *domain.BalanceOperation
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		var original domain.BalanceOperation
+		err := db.GetContext(txCtx, &original,
+			`SELECT id, balance_id, amount, type, description, COALESCE(transfer_id, '') AS transfer_id,
+				COALESCE(reverses_operation_id, '') AS reverses_operation_id, reputation_amount, reason_code, metadata, created_at
+			 FROM balance_operations
+			 WHERE id = $1
+			 FOR UPDATE`,
+			operationID,
 		)
 		if err != nil {
-			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr) && pgErr.Code == pgErrForeignKeyViolation {
-				return ErrBalanceNotFound
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBalanceOperationNotFound
 			}
-			s.logger.Error("failed to insert balance operation", zap.Error(err), zap.String("balance_id", operation.BalanceID))
+			s.logger.Error("failed to get balance operation", zap.Error(err), zap.String("operation_id", operationID))
 			return ErrBalanceInternal
 		}
 
-		_, err = db.ExecContext(txCtx,
-			"UPDATE balances SET balance = $1, updated_at = $2 WHERE id = $3",
-			balance.Balance,
-			now,
-			balance.ID,
-		)
+		if original.ReversesOperationID != "" || original.TransferID != "" {
+			return ErrBalanceOperationNotReversible
+		}
+
+		var reversed bool
+		err = db.GetContext(txCtx, &reversed, "SELECT EXISTS (SELECT 1 FROM balance_operations WHERE reverses_operation_id = $1)", original.ID)
 		if err != nil {
-			s.logger.Error("failed to update balance", zap.Error(err), zap.String("balance_id", balance.ID))
+			s.logger.Error("failed to check balance operation reversal", zap.Error(err), zap.String("operation_id", operationID))
 			return ErrBalanceInternal
 		}
+		if reversed {
+			return ErrBalanceOperationAlreadyReversed
+		}
+
+		var reversalType domain.BalanceOperationType
+		switch original.Type {
+		case domain.BalanceOperationTypeDeposit:
+			reversalType = domain.BalanceOperationTypeWithdraw
+		case domain.BalanceOperationTypeWithdraw:
+			reversalType = domain.BalanceOperationTypeDeposit
+		default:
+			return ErrBalanceOperationNotReversible
+		}
 
-		operation.ID = opID
-		operation.CreatedAt = now
+		balance, err := s.lockBalance(txCtx, original.BalanceID)
+		if err != nil {
+			return err
+		}
 
-		if operation.Type == domain.BalanceOperationTypeDeposit {
-			var totalDeposits int
-			err = db.GetContext(
-				txCtx,
-				&totalDeposits,
-				"SELECT COALESCE(SUM(amount), 0) FROM balance_operations WHERE balance_id = $1 AND type = $2",
-				operation.BalanceID,
-				domain.BalanceOperationTypeDeposit,
-			)
-			if err != nil {
-				s.logger.Error("failed to sum deposit operations", zap.Error(err), zap.String("balance_id", operation.BalanceID))
-				return ErrBalanceInternal
-			}
+		reversal = &domain.BalanceOperation{
+			Amount:              original.Amount,
+			Type:                reversalType,
+			Description:         reason,
+			ReversesOperationID: original.ID,
+			ReputationAmount:    -original.ReputationAmount,
+			ReasonCode:          domain.BalanceOperationReasonReversal,
+			Metadata:            original.Metadata,
+		}
+		if err := s.applyBalanceOperation(txCtx, balance, reversal); err != nil {
+			return err
+		}
+		return s.reverseReputationEvent(txCtx, balance.UserID, original.ID, reversal)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			var groupID int
-			err = db.GetContext(
-				txCtx,
-				&groupID,
-				`SELECT id
-				 FROM reputation_groups
-				 WHERE reputation_need <= $1
-				 ORDER BY reputation_need DESC
-				 LIMIT 1`,
-				totalDeposits,
-			)
-			if err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					groupID = 1
-				} else {
-					s.logger.Error("failed to select reputation group", zap.Error(err), zap.Int("total_deposits", totalDeposits))
-					return ErrBalanceInternal
-				}
-			}
+	return reversal, nil
+}
 
-			if groupID > 0 {
-				var currentGroupID int
-				err = db.GetContext(
-					txCtx,
-					&currentGroupID,
-					"SELECT reputation_group_id FROM users WHERE max_id = $1",
-					balance.UserID,
-				)
-				if err != nil {
-					s.logger.Error("failed to get current reputation group", zap.Error(err), zap.String("user_id", balance.UserID))
-					return ErrBalanceInternal
-				}
+func (s *SqlStorage) lockBalance(ctx context.Context, balanceID string) (*domain.Balance, error) {
+	var balance domain.Balance
+	err := s.trf.Transaction(ctx).GetContext(ctx, &balance, "SELECT id, user_id, wallet_type, balance, held FROM balances WHERE id = $1 FOR UPDATE", balanceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBalanceNotFound
+		}
+		s.logger.Error("failed to lock balance", zap.Error(err), zap.String("balance_id", balanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	return &balance, nil
+}
+
+// applyBalanceOperation must run inside a transaction that holds the row lock
+// taken by lockBalance, so the check and the update see the same balance.
+func (s *SqlStorage) applyBalanceOperation(ctx context.Context, balance *domain.Balance, operation *domain.BalanceOperation) error {
+	delta, err := s.moveBalance(ctx, balance, operation)
+	if err != nil {
+		return err
+	}
+
+	if delta > 0 {
+		if !balance.WalletType.Expires() {
+			return nil
+		}
+		return s.createLot(ctx, operation)
+	}
+	_, err = s.consumeLots(ctx, balance.ID, -delta)
+	return err
+}
+
+// moveBalance is applyBalanceOperation without the lots.
+func (s *SqlStorage) moveBalance(ctx context.Context, balance *domain.Balance, operation *domain.BalanceOperation) (int, error) {
+	var delta int
+	switch operation.Type {
+	case domain.BalanceOperationTypeWithdraw, domain.BalanceOperationTypeExpire:
+		if balance.Available() < operation.Amount {
+			return 0, ErrBalanceNotEnough
+		}
+		delta = -operation.Amount
+	case domain.BalanceOperationTypeDeposit:
+		delta = operation.Amount
+	case domain.BalanceOperationTypeAdjustment:
+		if operation.Amount < 0 && balance.Available() < -operation.Amount {
+			return 0, ErrBalanceNotEnough
+		}
+		delta = operation.Amount
+	default:
+		return 0, ErrBalanceInvalid
+	}
+
+	operation.BalanceID = balance.ID
+	operation.WalletType = balance.WalletType
+	if err := s.insertBalanceOperation(ctx, operation); err != nil {
+		return 0, err
+	}
+
+	err := s.trf.Transaction(ctx).GetContext(ctx,
+		&balance.Balance,
+		"UPDATE balances SET balance = balance + $1, updated_at = $2 WHERE id = $3 RETURNING balance",
+		delta,
+		operation.CreatedAt,
+		balance.ID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgErrCheckViolation {
+			return 0, ErrBalanceNotEnough
+		}
+		s.logger.Error("failed to update balance", zap.Error(err), zap.String("balance_id", balance.ID))
+		return 0, ErrBalanceInternal
+	}
+
+	if err := s.notifyBalanceChange(ctx, balance, operation); err != nil {
+		return 0, err
+	}
+
+	return delta, nil
+}
 
-				if currentGroupID != groupID {
-					_, err = db.ExecContext(
-						txCtx,
-						"UPDATE users SET reputation_group_id = $1, updated_at = $2 WHERE max_id = $3",
-						groupID,
-						now,
-						balance.UserID,
-					)
-					if err != nil {
-						s.logger.Error("failed to update reputation group", zap.Error(err), zap.String("user_id", balance.UserID))
-						return ErrBalanceInternal
-					}
+// insertBalanceOperation leaves the balance itself untouched.
+
+func (s *SqlStorage) insertBalanceOperation(ctx context.Context, operation *domain.BalanceOperation) error {
+	debitAccount, creditAccount, err := ledgerAccountsFor(operation)
+	if err != nil {
+		return err
+	}
+
+	if operation.ID == "" {
+		operation.ID = uuid.NewString()
+	}
+	if operation.ReasonCode == "" {
+		operation.ReasonCode = domain.BalanceOperationReasonOther
+	}
+	operation.CreatedAt = time.Now().UTC()
+
+	_, err = s.trf.Transaction(ctx).ExecContext(ctx,
+		`INSERT INTO balance_operations (id, balance_id, wallet_type, amount, type, description, transfer_id, reverses_operation_id, reputation_amount, reason_code, metadata, base_amount, coefficient, actor_max_id, justification, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10, $11, NULLIF($12, 0), NULLIF($13, 0), NULLIF($14, ''), NULLIF($15, ''), $16)`,
+		operation.ID,
+		operation.BalanceID,
+		operation.WalletType,
+		operation.Amount,
+		operation.Type,
+		operation.Description,
+		operation.TransferID,
+		operation.ReversesOperationID,
+		operation.ReputationAmount,
+		operation.ReasonCode,
+		operation.Metadata,
+		operation.BaseAmount,
+		operation.Coefficient,
+		operation.ActorMaxID,
+		operation.Justification,
+		operation.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgErrForeignKeyViolation:
+				return ErrBalanceNotFound
+			case pgErrUniqueViolation:
+				if pgErr.ConstraintName == "balance_operations_reverses_operation_id_key" {
+					return ErrBalanceOperationAlreadyReversed
 				}
 			}
 		}
+		s.logger.Error("failed to insert balance operation", zap.Error(err), zap.String("balance_id", operation.BalanceID))
+		return ErrBalanceInternal
+	}
 
-		return nil
-	})
-	if err != nil {
-		return nil, err
+	if err := s.postJournalEntry(ctx, operation, debitAccount, creditAccount); err != nil {
+		return ErrBalanceInternal
 	}
 
-	return operation, nil
+	return nil
 }
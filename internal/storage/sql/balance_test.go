@@ -0,0 +1,786 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/internal/storage/sqlxtrm"
+	"DobrikaDev/user-service/utils/config"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+)
+
+// newIntegrationStorage connects to the Postgres named by the POSTGRES_*
+// environment variables and migrates it. The test is skipped without one.
+func newIntegrationStorage(t *testing.T) *SqlStorage {
+	t.Helper()
+
+	if os.Getenv("POSTGRES_HOST") == "" {
+		t.Skip("POSTGRES_HOST is not set, skipping integration test")
+	}
+
+	cfg, err := config.LoadConfigFromEnv()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	db, err := NewPostgresDB(cfg)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		t.Fatalf("failed to set goose dialect: %v", err)
+	}
+	if err := goose.Up(db.DB, "../../../migrations/postgres"); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	trm, err := sqlxtrm.NewSqlxTransactionManager(db)
+	if err != nil {
+		t.Fatalf("failed to create transaction manager: %v", err)
+	}
+
+	return NewStorage(sqlxtrm.NewSqlxTransactionFactory(db), trm, cfg, zap.NewNop())
+}
+
+// newTestBalance creates a user with a random max_id and returns its balance.
+func newTestBalance(t *testing.T, s *SqlStorage) *domain.Balance {
+	t.Helper()
+	ctx := context.Background()
+
+	maxID := "test-" + uuid.NewString()
+	_, err := s.CreateUser(ctx, &domain.User{
+		MaxID:  maxID,
+		Name:   t.Name(),
+		Sex:    domain.SexUnknown,
+		Role:   domain.UserRoleUser,
+		Status: domain.UserStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	balance, err := s.GetBalance(ctx, maxID, domain.WalletTypePoints)
+	if err != nil {
+		t.Fatalf("failed to get balance: %v", err)
+	}
+
+	return balance
+}
+
+func storedBalance(t *testing.T, s *SqlStorage, balanceID string) int {
+	t.Helper()
+	ctx := context.Background()
+
+	var stored int
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &stored, "SELECT balance FROM balances WHERE id = $1", balanceID); err != nil {
+		t.Fatalf("failed to get stored balance: %v", err)
+	}
+
+	return stored
+}
+
+func deposit(t *testing.T, s *SqlStorage, balance *domain.Balance, amount int) *domain.BalanceOperation {
+	t.Helper()
+
+	operation, err := s.CreateBalanceOperation(context.Background(), &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		Amount:      amount,
+		Type:        domain.BalanceOperationTypeDeposit,
+		Description: t.Name(),
+	})
+	if err != nil {
+		t.Fatalf("failed to deposit %d: %v", amount, err)
+	}
+
+	return operation
+}
+
+func TestCreateBalanceOperationConcurrent(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	const (
+		workers             = 32
+		operationsPerWorker = 25
+	)
+
+	var (
+		wg          sync.WaitGroup
+		deposits    atomic.Int64
+		withdrawals atomic.Int64
+		rejected    atomic.Int64
+		applied     atomic.Int64
+		failures    = make(chan error, workers*operationsPerWorker)
+		stop        = make(chan struct{})
+		negative    = make(chan int, 1)
+	)
+
+	// Watch the stored balance while the workers run: it must never be seen
+	// below zero, not only at the end.
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(time.Millisecond):
+			}
+			var current int
+			if err := s.trf.Transaction(ctx).GetContext(ctx, &current, "SELECT balance FROM balances WHERE id = $1", balance.ID); err == nil && current < 0 {
+				select {
+				case negative <- current:
+				default:
+				}
+			}
+		}
+	}()
+
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(w), 0))
+			for range operationsPerWorker {
+				operation := &domain.BalanceOperation{
+					BalanceID:   balance.ID,
+					Amount:      1 + rng.IntN(50),
+					Type:        domain.BalanceOperationTypeDeposit,
+					Description: "concurrency test",
+				}
+				if rng.IntN(2) == 0 {
+					operation.Type = domain.BalanceOperationTypeWithdraw
+				}
+
+				_, err := s.CreateBalanceOperation(ctx, operation)
+				switch {
+				case err == nil && operation.Type == domain.BalanceOperationTypeDeposit:
+					deposits.Add(1)
+					applied.Add(int64(operation.Amount))
+				case err == nil:
+					withdrawals.Add(1)
+					applied.Add(-int64(operation.Amount))
+				case operation.Type == domain.BalanceOperationTypeWithdraw && errors.Is(err, ErrBalanceNotEnough):
+					rejected.Add(1)
+				default:
+					failures <- err
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(stop)
+	<-watched
+	close(failures)
+
+	for err := range failures {
+		t.Errorf("unexpected operation error: %v", err)
+	}
+	select {
+	case current := <-negative:
+		t.Errorf("balance was observed at %d", current)
+	default:
+	}
+
+	// About half of the operations are deposits and every one of them must
+	// apply; withdrawals only fail while the balance is too low.
+	if got := deposits.Load() + withdrawals.Load(); got < workers*operationsPerWorker/3 {
+		t.Errorf("only %d of %d operations applied", got, workers*operationsPerWorker)
+	}
+	if withdrawals.Load() == 0 {
+		t.Errorf("no withdrawal applied, %d rejected", rejected.Load())
+	}
+
+	stored := storedBalance(t, s, balance.ID)
+	var expected int
+	err := s.trf.Transaction(ctx).GetContext(ctx, &expected,
+		"SELECT COALESCE(SUM(CASE WHEN type = 'withdraw' THEN -amount ELSE amount END), 0) FROM balance_operations WHERE balance_id = $1",
+		balance.ID,
+	)
+	if err != nil {
+		t.Fatalf("failed to sum balance operations: %v", err)
+	}
+
+	if stored != expected {
+		t.Errorf("stored balance %d does not match the sum of operations %d", stored, expected)
+	}
+	if stored != int(applied.Load()) {
+		t.Errorf("stored balance %d does not match the applied operations %d", stored, applied.Load())
+	}
+	if stored < 0 {
+		t.Errorf("final balance is negative: %d", stored)
+	}
+}
+
+func TestCreateTransferOppositeDirections(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	a := newTestBalance(t, s)
+	b := newTestBalance(t, s)
+	deposit(t, s, a, 1000)
+	deposit(t, s, b, 1000)
+
+	const transfers = 50
+
+	// Half of the transfers go each way. Both rows are locked in id order, so
+	// none of them may fail with a deadlock.
+	var wg sync.WaitGroup
+	for i := range transfers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			from, to := a, b
+			if i%2 == 1 {
+				from, to = b, a
+			}
+			_, err := s.CreateTransfer(ctx, &domain.Transfer{
+				Amount:      i + 1,
+				Description: "transfer test",
+				FromBalance: &domain.Balance{ID: from.ID},
+				ToBalance:   &domain.Balance{ID: to.ID},
+			})
+			if err != nil {
+				t.Errorf("transfer %d failed: %v", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Transfer i moves i+1: a sends the odd amounts and receives the even ones.
+	var net int
+	for i := range transfers {
+		if i%2 == 0 {
+			net -= i + 1
+		} else {
+			net += i + 1
+		}
+	}
+	if got := storedBalance(t, s, a.ID); got != 1000+net {
+		t.Errorf("first balance is %d, want %d", got, 1000+net)
+	}
+	if got := storedBalance(t, s, b.ID); got != 1000-net {
+		t.Errorf("second balance is %d, want %d", got, 1000-net)
+	}
+}
+
+func TestCreateTransferNotEnough(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	from := newTestBalance(t, s)
+	to := newTestBalance(t, s)
+	deposit(t, s, from, 10)
+
+	transfer := &domain.Transfer{
+		Amount:      11,
+		Description: "transfer test",
+		FromBalance: &domain.Balance{ID: from.ID},
+		ToBalance:   &domain.Balance{ID: to.ID},
+	}
+	if _, err := s.CreateTransfer(ctx, transfer); !errors.Is(err, ErrBalanceNotEnough) {
+		t.Fatalf("expected ErrBalanceNotEnough, got %v", err)
+	}
+
+	var operations int
+	err := s.trf.Transaction(ctx).GetContext(ctx, &operations, "SELECT COUNT(*) FROM balance_operations WHERE transfer_id = $1", transfer.ID)
+	if err != nil {
+		t.Fatalf("failed to count transfer operations: %v", err)
+	}
+	if operations != 0 {
+		t.Errorf("failed transfer left %d operations", operations)
+	}
+	if got := storedBalance(t, s, from.ID); got != 10 {
+		t.Errorf("sender balance is %d after a failed transfer", got)
+	}
+	if got := storedBalance(t, s, to.ID); got != 0 {
+		t.Errorf("recipient balance is %d after a failed transfer", got)
+	}
+}
+
+func TestReverseBalanceOperationTwice(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	original := deposit(t, s, balance, 100)
+
+	const attempts = 8
+
+	var (
+		wg        sync.WaitGroup
+		reversed  atomic.Int64
+		conflicts atomic.Int64
+	)
+	for range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.ReverseBalanceOperation(ctx, original.ID, "reversal test")
+			switch {
+			case err == nil:
+				reversed.Add(1)
+			case errors.Is(err, ErrBalanceOperationAlreadyReversed):
+				conflicts.Add(1)
+			default:
+				t.Errorf("unexpected reversal error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reversed.Load() != 1 || conflicts.Load() != attempts-1 {
+		t.Errorf("%d reversals applied and %d conflicted", reversed.Load(), conflicts.Load())
+	}
+	if got := storedBalance(t, s, balance.ID); got != 0 {
+		t.Errorf("balance is %d after reversing the only deposit", got)
+	}
+
+	var reversalID string
+	err := s.trf.Transaction(ctx).GetContext(ctx, &reversalID, "SELECT id FROM balance_operations WHERE reverses_operation_id = $1", original.ID)
+	if err != nil {
+		t.Fatalf("failed to get reversal: %v", err)
+	}
+	if _, err := s.ReverseBalanceOperation(ctx, reversalID, "reversal test"); !errors.Is(err, ErrBalanceOperationNotReversible) {
+		t.Errorf("reversing a reversal: expected ErrBalanceOperationNotReversible, got %v", err)
+	}
+}
+
+func TestReverseBalanceOperationRejected(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	from := newTestBalance(t, s)
+	to := newTestBalance(t, s)
+	original := deposit(t, s, from, 100)
+
+	transfer, err := s.CreateTransfer(ctx, &domain.Transfer{
+		Amount:      80,
+		Description: "reversal test",
+		FromBalance: &domain.Balance{ID: from.ID},
+		ToBalance:   &domain.Balance{ID: to.ID},
+	})
+	if err != nil {
+		t.Fatalf("failed to transfer: %v", err)
+	}
+
+	if _, err := s.ReverseBalanceOperation(ctx, transfer.ToOperation.ID, "reversal test"); !errors.Is(err, ErrBalanceOperationNotReversible) {
+		t.Errorf("reversing a transfer leg: expected ErrBalanceOperationNotReversible, got %v", err)
+	}
+
+	// The deposit has mostly been sent away, so taking it back would leave the
+	// balance negative.
+	if _, err := s.ReverseBalanceOperation(ctx, original.ID, "reversal test"); !errors.Is(err, ErrBalanceNotEnough) {
+		t.Errorf("reversing a spent deposit: expected ErrBalanceNotEnough, got %v", err)
+	}
+	if got := storedBalance(t, s, from.ID); got != 20 {
+		t.Errorf("balance is %d after a rejected reversal", got)
+	}
+}
+
+func TestGetBalanceOperationsKeyset(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	const operations = 25
+	for i := range operations {
+		deposit(t, s, balance, i+1)
+	}
+
+	// Give most operations the same timestamp so that pages have to break
+	// ties on the id.
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		"UPDATE balance_operations SET created_at = $1 WHERE balance_id = $2 AND amount > 5",
+		time.Now().UTC().Truncate(time.Second),
+		balance.ID,
+	)
+	if err != nil {
+		t.Fatalf("failed to align timestamps: %v", err)
+	}
+
+	var (
+		seen   []*domain.BalanceOperation
+		cursor *domain.BalanceOperationCursor
+	)
+	for page := 0; ; page++ {
+		if page > operations {
+			t.Fatal("pagination does not terminate")
+		}
+
+		response, err := s.GetBalanceOperations(ctx, balance.UserID, BalanceOperationsPage{Limit: 4, Cursor: cursor, SkipTotal: page > 0})
+		if err != nil {
+			t.Fatalf("failed to get page %d: %v", page, err)
+		}
+		if page == 0 && response.Total != operations {
+			t.Errorf("total is %d, want %d", response.Total, operations)
+		}
+		seen = append(seen, response.Operations...)
+
+		// An operation written between pages is newer than the cursor and
+		// must not shift the later pages.
+		if page == 1 {
+			deposit(t, s, balance, 1000)
+		}
+
+		if response.NextCursor == nil {
+			break
+		}
+		cursor = response.NextCursor
+	}
+
+	if len(seen) != operations {
+		t.Fatalf("pages returned %d operations, want %d", len(seen), operations)
+	}
+	ids := make(map[string]bool, len(seen))
+	for i, operation := range seen {
+		if ids[operation.ID] {
+			t.Errorf("operation %s returned twice", operation.ID)
+		}
+		ids[operation.ID] = true
+
+		if i == 0 {
+			continue
+		}
+		previous := seen[i-1]
+		if operation.CreatedAt.After(previous.CreatedAt) || operation.CreatedAt.Equal(previous.CreatedAt) && operation.ID > previous.ID {
+			t.Errorf("operation %d is out of order", i)
+		}
+	}
+}
+
+func TestGetBalanceOperationsFilters(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	for _, description := range []string{"50% off", "500 off", "under_score", "underscore"} {
+		_, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+			BalanceID:   balance.ID,
+			Amount:      10 * (len(description)),
+			Type:        domain.BalanceOperationTypeDeposit,
+			Description: description,
+		})
+		if err != nil {
+			t.Fatalf("failed to deposit: %v", err)
+		}
+	}
+	_, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		Amount:      5,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "50% off",
+	})
+	if err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	descriptions := func(t *testing.T, opts ...ListBalanceOperationsOpts) []string {
+		t.Helper()
+		response, err := s.GetBalanceOperations(ctx, balance.UserID, BalanceOperationsPage{}, opts...)
+		if err != nil {
+			t.Fatalf("failed to get operations: %v", err)
+		}
+		if int(response.Total) != len(response.Operations) {
+			t.Errorf("total %d does not match %d filtered operations", response.Total, len(response.Operations))
+		}
+		result := make([]string, 0, len(response.Operations))
+		for _, operation := range response.Operations {
+			result = append(result, string(operation.Type)+" "+operation.Description)
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	tests := []struct {
+		name string
+		opts []ListBalanceOperationsOpts
+		want []string
+	}{
+		{
+			name: "percent is literal",
+			opts: []ListBalanceOperationsOpts{ListBalanceOperationsWithDescription("50%")},
+			want: []string{"deposit 50% off", "withdraw 50% off"},
+		},
+		{
+			name: "underscore is literal",
+			opts: []ListBalanceOperationsOpts{ListBalanceOperationsWithDescription("under_")},
+			want: []string{"deposit under_score"},
+		},
+		{
+			name: "type and amount",
+			opts: []ListBalanceOperationsOpts{
+				ListBalanceOperationsWithTypes([]domain.BalanceOperationType{domain.BalanceOperationTypeDeposit}),
+				ListBalanceOperationsWithAmountMin(70),
+				ListBalanceOperationsWithAmountMax(100),
+			},
+			want: []string{"deposit 50% off", "deposit 500 off", "deposit underscore"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptions(t, tt.opts...); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBalanceOperationReasons(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	metadata := domain.BalanceOperationMetadata{
+		SourceService: "tasks",
+		TaskID:        "task-1",
+		Extra:         map[string]string{"campaign": "spring"},
+	}
+	operations := []*domain.BalanceOperation{
+		{Amount: 30, Type: domain.BalanceOperationTypeDeposit, ReasonCode: domain.BalanceOperationReasonTaskReward, Metadata: metadata},
+		{Amount: 20, Type: domain.BalanceOperationTypeDeposit, ReasonCode: domain.BalanceOperationReasonTaskReward},
+		{Amount: 15, Type: domain.BalanceOperationTypeWithdraw, ReasonCode: domain.BalanceOperationReasonShopPurchase},
+		{Amount: 5, Type: domain.BalanceOperationTypeDeposit},
+	}
+	for _, operation := range operations {
+		operation.BalanceID = balance.ID
+		operation.Description = "reason test"
+		if _, err := s.CreateBalanceOperation(ctx, operation); err != nil {
+			t.Fatalf("failed to create operation: %v", err)
+		}
+	}
+
+	response, err := s.GetBalanceOperations(ctx, balance.UserID, BalanceOperationsPage{},
+		ListBalanceOperationsWithReasonCodes([]domain.BalanceOperationReason{domain.BalanceOperationReasonTaskReward}),
+	)
+	if err != nil {
+		t.Fatalf("failed to get operations: %v", err)
+	}
+	if len(response.Operations) != 2 {
+		t.Fatalf("got %d task rewards, want 2", len(response.Operations))
+	}
+	for _, operation := range response.Operations {
+		if operation.ID != operations[0].ID {
+			continue
+		}
+		if operation.Metadata.TaskID != "task-1" || operation.Metadata.Extra["campaign"] != "spring" {
+			t.Errorf("metadata did not round-trip: %+v", operation.Metadata)
+		}
+	}
+
+	totals, err := s.GetBalanceOperationTotals(ctx, balance.UserID)
+	if err != nil {
+		t.Fatalf("failed to get totals: %v", err)
+	}
+	got := make(map[domain.BalanceOperationReason]domain.BalanceOperationReasonTotals, len(totals))
+	for _, total := range totals {
+		got[total.ReasonCode] = *total
+	}
+	want := map[domain.BalanceOperationReason]domain.BalanceOperationReasonTotals{
+		domain.BalanceOperationReasonTaskReward:   {ReasonCode: domain.BalanceOperationReasonTaskReward, Count: 2, Credited: 50},
+		domain.BalanceOperationReasonShopPurchase: {ReasonCode: domain.BalanceOperationReasonShopPurchase, Count: 1, Debited: 15},
+		domain.BalanceOperationReasonOther:        {ReasonCode: domain.BalanceOperationReasonOther, Count: 1, Credited: 5},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got totals for %d reasons, want %d", len(got), len(want))
+	}
+	for reason, total := range want {
+		if got[reason] != total {
+			t.Errorf("totals for %s: got %+v, want %+v", reason, got[reason], total)
+		}
+	}
+}
+
+func TestCreateBalanceOperationCoefficient(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	groupID := newTestReputationGroup(t, s)
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE reputation_groups SET coefficient = 1.15 WHERE id = $1", groupID)
+	if err != nil {
+		t.Fatalf("failed to set coefficient: %v", err)
+	}
+
+	for _, tt := range []struct{ base, want int }{{10, 12}, {3, 3}, {20, 23}} {
+		// The deposit recomputes the group from reputation, so put the user
+		// back before each one.
+		moveToReputationGroup(t, s, balance.UserID, groupID)
+
+		operation, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+			BalanceID:   balance.ID,
+			BaseAmount:  tt.base,
+			Type:        domain.BalanceOperationTypeDeposit,
+			Description: "coefficient test",
+		})
+		if err != nil {
+			t.Fatalf("failed to deposit base %d: %v", tt.base, err)
+		}
+		if operation.Amount != tt.want || operation.Coefficient != 1.15 {
+			t.Errorf("base %d credited %d at %v, want %d at 1.15", tt.base, operation.Amount, operation.Coefficient, tt.want)
+		}
+	}
+
+	response, err := s.GetBalanceOperations(ctx, balance.UserID, BalanceOperationsPage{})
+	if err != nil {
+		t.Fatalf("failed to get operations: %v", err)
+	}
+	for _, operation := range response.Operations {
+		if operation.BaseAmount == 0 || operation.Coefficient != 1.15 {
+			t.Errorf("operation %s stored base %d and coefficient %v", operation.ID, operation.BaseAmount, operation.Coefficient)
+		}
+	}
+	if got := storedBalance(t, s, balance.ID); got != 38 {
+		t.Errorf("balance is %d, want 38", got)
+	}
+
+	_, err = s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		BaseAmount:  10,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "coefficient test",
+	})
+	if !errors.Is(err, ErrBalanceInvalid) {
+		t.Errorf("withdrawal in coefficient mode: expected ErrBalanceInvalid, got %v", err)
+	}
+}
+
+func TestWallets(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	points := newTestBalance(t, s)
+
+	karma, err := s.GetBalance(ctx, points.UserID, domain.WalletTypeKarma)
+	if err != nil {
+		t.Fatalf("failed to get karma wallet: %v", err)
+	}
+	tokens, err := s.GetBalance(ctx, points.UserID, domain.WalletTypeEventTokens)
+	if err != nil {
+		t.Fatalf("failed to get event token wallet: %v", err)
+	}
+	if karma.ID == points.ID || tokens.ID == points.ID || karma.ID == tokens.ID {
+		t.Fatal("wallets share a balance")
+	}
+
+	deposit(t, s, points, 60)
+	deposit(t, s, karma, 50)
+	deposit(t, s, tokens, 30)
+
+	if _, err := withdraw(ctx, s, karma, 10); !errors.Is(err, ErrBalanceInvalid) {
+		t.Errorf("spending karma: expected ErrBalanceInvalid, got %v", err)
+	}
+	if _, err := withdraw(ctx, s, tokens, 10); err != nil {
+		t.Errorf("failed to spend event tokens: %v", err)
+	}
+
+	for _, tt := range []struct {
+		balance *domain.Balance
+		want    int
+		lots    int
+	}{
+		{points, 60, 1},
+		{karma, 50, 0},
+		{tokens, 20, 1},
+	} {
+		if got := storedBalance(t, s, tt.balance.ID); got != tt.want {
+			t.Errorf("%s wallet holds %d, want %d", tt.balance.WalletType, got, tt.want)
+		}
+		if lots := lotsOf(t, s, tt.balance.ID); len(lots) != tt.lots {
+			t.Errorf("%s wallet has %d lots, want %d", tt.balance.WalletType, len(lots), tt.lots)
+		}
+	}
+
+	// Event tokens are not earned reputation, karma and points are.
+	response, err := s.GetBalanceOperations(ctx, points.UserID, BalanceOperationsPage{},
+		ListBalanceOperationsWithTypes([]domain.BalanceOperationType{domain.BalanceOperationTypeDeposit}),
+	)
+	if err != nil {
+		t.Fatalf("failed to get operations: %v", err)
+	}
+	if len(response.Operations) != 3 {
+		t.Fatalf("got %d deposits across wallets, want 3", len(response.Operations))
+	}
+	for _, operation := range response.Operations {
+		want := operation.Amount
+		if operation.WalletType == domain.WalletTypeEventTokens {
+			want = 0
+		}
+		if operation.ReputationAmount != want {
+			t.Errorf("%s deposit counts %d towards reputation, want %d", operation.WalletType, operation.ReputationAmount, want)
+		}
+	}
+}
+
+func TestCreateBalanceOperationAdjustment(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	other := newTestBalance(t, s)
+	admin := newTestBalance(t, s)
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE users SET role = $1 WHERE max_id = $2", domain.UserRoleAdmin, admin.UserID); err != nil {
+		t.Fatalf("failed to make user an admin: %v", err)
+	}
+
+	adjust := func(actor string, amount int, countsTowardsReputation bool) (*domain.BalanceOperation, error) {
+		return s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+			BalanceID:               balance.ID,
+			Amount:                  amount,
+			Type:                    domain.BalanceOperationTypeAdjustment,
+			Description:             "adjustment test",
+			ActorMaxID:              actor,
+			Justification:           "support ticket",
+			CountsTowardsReputation: countsTowardsReputation,
+		})
+	}
+
+	for _, actor := range []string{"", other.UserID, "test-missing"} {
+		if _, err := adjust(actor, 10, false); !errors.Is(err, ErrBalanceForbidden) {
+			t.Errorf("adjustment by %q: expected ErrBalanceForbidden, got %v", actor, err)
+		}
+	}
+
+	plain, err := adjust(admin.UserID, 30, false)
+	if err != nil {
+		t.Fatalf("failed to adjust: %v", err)
+	}
+	flagged, err := adjust(admin.UserID, 20, true)
+	if err != nil {
+		t.Fatalf("failed to adjust: %v", err)
+	}
+	if plain.ReputationAmount != 0 || flagged.ReputationAmount != 20 {
+		t.Errorf("adjustments count %d and %d towards reputation, want 0 and 20", plain.ReputationAmount, flagged.ReputationAmount)
+	}
+
+	if _, err := adjust(admin.UserID, -60, false); !errors.Is(err, ErrBalanceNotEnough) {
+		t.Errorf("adjustment below zero: expected ErrBalanceNotEnough, got %v", err)
+	}
+	if _, err := adjust(admin.UserID, -50, false); err != nil {
+		t.Fatalf("failed to adjust down: %v", err)
+	}
+	if got := storedBalance(t, s, balance.ID); got != 0 {
+		t.Errorf("balance is %d after adjustments, want 0", got)
+	}
+
+	response, err := s.GetBalanceOperations(ctx, balance.UserID, BalanceOperationsPage{})
+	if err != nil {
+		t.Fatalf("failed to get operations: %v", err)
+	}
+	if len(response.Operations) != 3 {
+		t.Fatalf("got %d operations, want 3", len(response.Operations))
+	}
+	for _, operation := range response.Operations {
+		if operation.ActorMaxID != admin.UserID || operation.Justification != "support ticket" {
+			t.Errorf("operation %s records actor %q and justification %q", operation.ID, operation.ActorMaxID, operation.Justification)
+		}
+	}
+}
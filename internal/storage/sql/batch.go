@@ -0,0 +1,338 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type limitedBalanceOperation struct {
+	BalanceID string                      `db:"balance_id"`
+	Type      domain.BalanceOperationType `db:"type"`
+	limitedOperation
+}
+
+// Items are checked in order, taking earlier items of the batch into account.
+// Item failures are reported in the results, not in the returned error.
+func (s *SqlStorage) CreateBalanceOperationsBatch(ctx context.Context, items []*domain.BalanceOperationBatchItem, atomic bool) ([]*domain.BalanceOperationBatchResult, error) {
+	results := make([]*domain.BalanceOperationBatchResult, len(items))
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+		now := time.Now().UTC()
+
+		maxIDs := make([]string, 0, len(items))
+		walletTypes := make([]string, 0, len(items))
+		for _, item := range items {
+			maxIDs = append(maxIDs, item.MaxID)
+			walletTypes = append(walletTypes, item.Operation.WalletType.String())
+		}
+
+		balances := make([]*domain.Balance, 0, len(items))
+		err := db.SelectContext(txCtx, &balances,
+			`SELECT id, user_id, wallet_type, balance, held
+			 FROM balances
+			 WHERE (user_id, wallet_type) IN (SELECT * FROM unnest($1::text[], $2::text[]))
+			 ORDER BY id
+			 FOR UPDATE`,
+			maxIDs,
+			walletTypes,
+		)
+		if err != nil {
+			s.logger.Error("failed to lock batch balances", zap.Error(err))
+			return ErrBalanceInternal
+		}
+
+		type walletKey struct {
+			maxID      string
+			walletType domain.WalletType
+		}
+
+		balancesByWallet := make(map[walletKey]*domain.Balance, len(balances))
+		balanceIDs := make([]string, 0, len(balances))
+		for _, balance := range balances {
+			balancesByWallet[walletKey{balance.UserID, balance.WalletType}] = balance
+			balanceIDs = append(balanceIDs, balance.ID)
+		}
+
+		limits, windowOperations, err := s.getBatchLimits(txCtx, balanceIDs, now)
+		if err != nil {
+			return err
+		}
+
+		accepted := make([]*domain.BalanceOperation, 0, len(items))
+		changes := make([]*domain.BalanceChange, 0, len(items))
+		events := make([]*domain.ReputationEvent, 0)
+		failed := false
+
+		for i, item := range items {
+			operation := item.Operation
+			results[i] = &domain.BalanceOperationBatchResult{}
+
+			balance, ok := balancesByWallet[walletKey{item.MaxID, operation.WalletType}]
+			if !ok {
+				results[i].Err = ErrBalanceNotFound
+				failed = true
+				continue
+			}
+
+			if err := checkBatchItem(balance, operation, limits[balance.ID], windowOperations[balance.ID], now); err != nil {
+				results[i].Err = err
+				failed = true
+				continue
+			}
+
+			operation.ID = uuid.NewString()
+			operation.BalanceID = balance.ID
+			operation.WalletType = balance.WalletType
+			operation.CreatedAt = now
+			if operation.ReasonCode == "" {
+				operation.ReasonCode = domain.BalanceOperationReasonOther
+			}
+			operation.ReputationAmount = 0
+			if operation.Type == domain.BalanceOperationTypeDeposit {
+				if balance.WalletType.CountsTowardsReputation() {
+					operation.ReputationAmount = operation.Amount
+				}
+				balance.Balance += operation.Amount
+			} else {
+				balance.Balance -= operation.Amount
+			}
+
+			windowOperations[balance.ID] = append(windowOperations[balance.ID], &limitedBalanceOperation{
+				BalanceID:        balance.ID,
+				Type:             operation.Type,
+				limitedOperation: limitedOperation{Amount: operation.Amount, CreatedAt: now},
+			})
+
+			results[i].Operation = operation
+			accepted = append(accepted, operation)
+			if event := reputationEventFor(balance, operation); event != nil {
+				events = append(events, event)
+			}
+			changes = append(changes, &domain.BalanceChange{
+				MaxID:       balance.UserID,
+				BalanceID:   balance.ID,
+				WalletType:  balance.WalletType,
+				Balance:     balance.Balance,
+				Held:        balance.Held,
+				OperationID: operation.ID,
+			})
+		}
+
+		if atomic && failed {
+			for _, result := range results {
+				result.Operation = nil
+			}
+			return nil
+		}
+
+		if err := s.writeBatchOperations(txCtx, accepted, changes); err != nil {
+			return err
+		}
+		// Lock users in max_id order like RecalculateReputationGroups does.
+		// The sort is stable to keep each user's events in item order.
+		slices.SortStableFunc(events, func(a, b *domain.ReputationEvent) int {
+			return strings.Compare(a.MaxID, b.MaxID)
+		})
+		for _, event := range events {
+			if err := s.addReputationEvent(txCtx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func checkBatchItem(balance *domain.Balance, operation *domain.BalanceOperation, limits []*domain.ReputationGroupLimit, windowOperations []*limitedBalanceOperation, now time.Time) error {
+	switch operation.Type {
+	case domain.BalanceOperationTypeDeposit:
+	case domain.BalanceOperationTypeWithdraw:
+		if !balance.WalletType.Spendable() {
+			return ErrBalanceInvalid
+		}
+		if balance.Available() < operation.Amount {
+			return ErrBalanceNotEnough
+		}
+	default:
+		return ErrBalanceInvalid
+	}
+	if operation.Amount <= 0 {
+		return ErrBalanceInvalid
+	}
+
+	direction, _ := limitDirectionFor(operation.Type)
+	for _, limit := range limits {
+		if limit.Direction != direction {
+			continue
+		}
+
+		from := now.Add(-limit.Window())
+		operations := make([]*limitedOperation, 0, len(windowOperations))
+		for _, op := range windowOperations {
+			if op.Type == operation.Type && op.CreatedAt.After(from) {
+				operations = append(operations, &op.limitedOperation)
+			}
+		}
+
+		if err := checkLimit(limit, operations, operation.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Window operations are returned oldest first.
+func (s *SqlStorage) getBatchLimits(ctx context.Context, balanceIDs []string, now time.Time) (map[string][]*domain.ReputationGroupLimit, map[string][]*limitedBalanceOperation, error) {
+	db := s.trf.Transaction(ctx)
+
+	type balanceLimit struct {
+		BalanceID string `db:"balance_id"`
+		domain.ReputationGroupLimit
+	}
+
+	rows := make([]*balanceLimit, 0, len(balanceIDs))
+	err := db.SelectContext(ctx, &rows,
+		`SELECT b.id AS balance_id, l.reputation_group_id, l.direction, l.window_seconds, l.max_amount
+		 FROM balances b
+		 JOIN users u ON u.max_id = b.user_id
+		 JOIN reputation_group_limits l ON l.reputation_group_id = u.reputation_group_id
+		 WHERE b.id = ANY($1) AND b.wallet_type = 'points'
+		 ORDER BY l.window_seconds`,
+		balanceIDs,
+	)
+	if err != nil {
+		s.logger.Error("failed to get batch limits", zap.Error(err))
+		return nil, nil, ErrBalanceInternal
+	}
+
+	limits := make(map[string][]*domain.ReputationGroupLimit, len(rows))
+	windowOperations := make(map[string][]*limitedBalanceOperation, len(balanceIDs))
+	if len(rows) == 0 {
+		return limits, windowOperations, nil
+	}
+
+	longest := time.Duration(0)
+	for _, row := range rows {
+		limit := row.ReputationGroupLimit
+		limits[row.BalanceID] = append(limits[row.BalanceID], &limit)
+		longest = max(longest, limit.Window())
+	}
+
+	operations := make([]*limitedBalanceOperation, 0, len(balanceIDs))
+	err = db.SelectContext(ctx, &operations,
+		`SELECT bo.balance_id, bo.type, bo.amount, bo.created_at
+		 FROM balance_operations bo
+		 WHERE bo.balance_id = ANY($1)
+		   AND bo.type IN ('deposit', 'withdraw')
+		   AND bo.reverses_operation_id IS NULL
+		   AND bo.created_at > $2
+		   AND NOT EXISTS (SELECT 1 FROM balance_operations r WHERE r.reverses_operation_id = bo.id)
+		 ORDER BY bo.created_at`,
+		balanceIDs,
+		now.Add(-longest),
+	)
+	if err != nil {
+		s.logger.Error("failed to get batch operations within limit windows", zap.Error(err))
+		return nil, nil, ErrBalanceInternal
+	}
+
+	for _, op := range operations {
+		windowOperations[op.BalanceID] = append(windowOperations[op.BalanceID], op)
+	}
+
+	return limits, windowOperations, nil
+}
+
+func (s *SqlStorage) writeBatchOperations(ctx context.Context, operations []*domain.BalanceOperation, changes []*domain.BalanceChange) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	db := s.trf.Transaction(ctx)
+
+	ib := sq.Insert("balance_operations").
+		Columns("id", "balance_id", "wallet_type", "amount", "type", "description", "reputation_amount", "reason_code", "metadata", "created_at").
+		PlaceholderFormat(sq.Dollar)
+	for _, operation := range operations {
+		ib = ib.Values(operation.ID, operation.BalanceID, operation.WalletType, operation.Amount, operation.Type, operation.Description, operation.ReputationAmount, operation.ReasonCode, operation.Metadata, operation.CreatedAt)
+	}
+
+	query, args := ib.MustSql()
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		s.logger.Error("failed to insert batch operations", zap.Error(err), zap.Int("count", len(operations)))
+		return ErrBalanceInternal
+	}
+
+	if err := s.postJournalEntries(ctx, operations); err != nil {
+		return ErrBalanceInternal
+	}
+
+	// Net every balance's items into one update, and its debits into one
+	// pass over its lots.
+	deltas := make(map[string]int64, len(operations))
+	debits := make(map[string]int64, len(operations))
+	order := make([]string, 0, len(operations))
+	credits := make([]*domain.BalanceOperation, 0, len(operations))
+	for _, operation := range operations {
+		if _, ok := deltas[operation.BalanceID]; !ok {
+			order = append(order, operation.BalanceID)
+		}
+		if operation.Type == domain.BalanceOperationTypeDeposit {
+			deltas[operation.BalanceID] += int64(operation.Amount)
+			if operation.WalletType.Expires() {
+				credits = append(credits, operation)
+			}
+		} else {
+			deltas[operation.BalanceID] -= int64(operation.Amount)
+			debits[operation.BalanceID] += int64(operation.Amount)
+		}
+	}
+
+	balanceIDs := make([]string, 0, len(order))
+	balanceDeltas := make([]int64, 0, len(order))
+	debitIDs := make([]string, 0, len(debits))
+	debitAmounts := make([]int64, 0, len(debits))
+	for _, id := range order {
+		balanceIDs = append(balanceIDs, id)
+		balanceDeltas = append(balanceDeltas, deltas[id])
+		if debit, ok := debits[id]; ok {
+			debitIDs = append(debitIDs, id)
+			debitAmounts = append(debitAmounts, debit)
+		}
+	}
+
+	_, err := db.ExecContext(ctx,
+		`UPDATE balances b
+		 SET balance = b.balance + d.delta, updated_at = $3
+		 FROM unnest($1::text[], $2::bigint[]) AS d(id, delta)
+		 WHERE b.id = d.id`,
+		balanceIDs,
+		balanceDeltas,
+		operations[0].CreatedAt,
+	)
+	if err != nil {
+		s.logger.Error("failed to update batch balances", zap.Error(err))
+		return ErrBalanceInternal
+	}
+
+	if err := s.notifyBalanceChanges(ctx, changes); err != nil {
+		return err
+	}
+	if err := s.createLots(ctx, credits); err != nil {
+		return err
+	}
+	return s.consumeLotsOf(ctx, debitIDs, debitAmounts)
+}
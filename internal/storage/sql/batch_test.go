@@ -0,0 +1,169 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestBatch(a *domain.Balance, b *domain.Balance) []*domain.BalanceOperationBatchItem {
+	item := func(maxID string, operationType domain.BalanceOperationType, amount int) *domain.BalanceOperationBatchItem {
+		return &domain.BalanceOperationBatchItem{
+			MaxID: maxID,
+			Operation: &domain.BalanceOperation{
+				WalletType:  domain.WalletTypePoints,
+				Amount:      amount,
+				Type:        operationType,
+				Description: "batch test",
+			},
+		}
+	}
+
+	// The last withdrawal only fits because of the deposit before it.
+	return []*domain.BalanceOperationBatchItem{
+		item(a.UserID, domain.BalanceOperationTypeDeposit, 5),
+		item(b.UserID, domain.BalanceOperationTypeWithdraw, 50),
+		item("test-missing", domain.BalanceOperationTypeDeposit, 5),
+		item(a.UserID, domain.BalanceOperationTypeWithdraw, 12),
+	}
+}
+
+func TestCreateBalanceOperationsBatchAtomic(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	a := newTestBalance(t, s)
+	b := newTestBalance(t, s)
+	deposit(t, s, a, 10)
+
+	results, err := s.CreateBalanceOperationsBatch(ctx, newTestBatch(a, b), true)
+	if err != nil {
+		t.Fatalf("failed to apply batch: %v", err)
+	}
+
+	if !errors.Is(results[1].Err, ErrBalanceNotEnough) || !errors.Is(results[2].Err, ErrBalanceNotFound) {
+		t.Errorf("failed items report %v and %v", results[1].Err, results[2].Err)
+	}
+	for i, result := range results {
+		if result.Operation != nil {
+			t.Errorf("item %d of a failed atomic batch returned an operation", i)
+		}
+	}
+	if got := storedBalance(t, s, a.ID); got != 10 {
+		t.Errorf("balance is %d after a failed atomic batch", got)
+	}
+	if got := storedBalance(t, s, b.ID); got != 0 {
+		t.Errorf("balance is %d after a failed atomic batch", got)
+	}
+}
+
+func TestCreateBalanceOperationsBatchBestEffort(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	a := newTestBalance(t, s)
+	b := newTestBalance(t, s)
+	deposit(t, s, a, 10)
+
+	results, err := s.CreateBalanceOperationsBatch(ctx, newTestBatch(a, b), false)
+	if err != nil {
+		t.Fatalf("failed to apply batch: %v", err)
+	}
+
+	for i, wantErr := range []error{nil, ErrBalanceNotEnough, ErrBalanceNotFound, nil} {
+		if !errors.Is(results[i].Err, wantErr) {
+			t.Errorf("item %d: got error %v, want %v", i, results[i].Err, wantErr)
+		}
+		if (results[i].Operation != nil) != (wantErr == nil) {
+			t.Errorf("item %d: operation %+v with error %v", i, results[i].Operation, results[i].Err)
+		}
+	}
+	if got := storedBalance(t, s, a.ID); got != 3 {
+		t.Errorf("balance is %d, want 3", got)
+	}
+	if got := storedBalance(t, s, b.ID); got != 0 {
+		t.Errorf("balance of the failed item is %d", got)
+	}
+
+	// The set-based writes keep the ledger and the lots in step with the
+	// balance, just like single operations do.
+	var account, lots int
+	err = s.trf.Transaction(ctx).GetContext(ctx, &account,
+		"SELECT COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0) FROM ledger_entries WHERE account_id = $1",
+		domain.WalletLedgerAccountID(a.ID),
+	)
+	if err != nil {
+		t.Fatalf("failed to sum wallet account: %v", err)
+	}
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &lots, "SELECT COALESCE(SUM(remaining), 0) FROM balance_lots WHERE balance_id = $1", a.ID); err != nil {
+		t.Fatalf("failed to sum lots: %v", err)
+	}
+	if account != 3 || lots != 3 {
+		t.Errorf("wallet account holds %d and lots %d, want 3", account, lots)
+	}
+}
+
+func TestCreateBalanceOperationsBatchLockOrder(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+
+	// A shared prefix lets RecalculateReputationGroups lock exactly these users.
+	prefix := "test-" + uuid.NewString()
+	maxIDs := make([]string, 0, 3)
+	for i := range 3 {
+		maxID := prefix + "-" + string(rune('a'+i))
+		_, err := s.CreateUser(ctx, &domain.User{
+			MaxID:  maxID,
+			Name:   t.Name(),
+			Sex:    domain.SexUnknown,
+			Role:   domain.UserRoleUser,
+			Status: domain.UserStatusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		maxIDs = append(maxIDs, maxID)
+	}
+
+	// Karma deposits against max_id order would lock the users backwards.
+	const rounds = 20
+	var wg sync.WaitGroup
+	for range rounds {
+		items := make([]*domain.BalanceOperationBatchItem, 0, len(maxIDs))
+		for _, maxID := range slices.Backward(maxIDs) {
+			items = append(items, &domain.BalanceOperationBatchItem{
+				MaxID: maxID,
+				Operation: &domain.BalanceOperation{
+					WalletType:  domain.WalletTypeKarma,
+					Amount:      1,
+					Type:        domain.BalanceOperationTypeDeposit,
+					Description: "batch test",
+				},
+			})
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := s.CreateBalanceOperationsBatch(ctx, items, true); err != nil {
+				t.Errorf("failed to apply batch: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := s.RecalculateReputationGroups(ctx, prefix, len(maxIDs), false); err != nil {
+				t.Errorf("failed to recalculate reputation groups: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, maxID := range maxIDs {
+		if got := reputationScore(t, s, maxID); got != rounds {
+			t.Errorf("reputation of %s is %d, want %d", maxID, got, rounds)
+		}
+	}
+}
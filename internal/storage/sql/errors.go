@@ -12,10 +12,29 @@ var (
 	ErrReputationGroupAlreadyExists = errors.New("reputation group already exists")
 	ErrReputationGroupInvalid       = errors.New("reputation group invalid")
 	ErrReputationGroupInternal      = errors.New("reputation group internal error")
+	ErrReputationGroupInUse         = errors.New("reputation group has members")
 
 	ErrBalanceNotFound      = errors.New("balance not found")
 	ErrBalanceAlreadyExists = errors.New("balance already exists")
 	ErrBalanceNotEnough     = errors.New("balance not enough")
 	ErrBalanceInternal      = errors.New("balance internal error")
 	ErrBalanceInvalid       = errors.New("balance invalid")
+	ErrBalanceForbidden     = errors.New("balance operation not allowed for actor")
+
+	ErrBalanceOperationNotFound        = errors.New("balance operation not found")
+	ErrBalanceOperationAlreadyReversed = errors.New("balance operation already reversed")
+	ErrBalanceOperationNotReversible   = errors.New("balance operation not reversible")
+
+	ErrHoldNotFound  = errors.New("hold not found")
+	ErrHoldNotActive = errors.New("hold not active")
+
+	ErrScheduledOperationNotFound = errors.New("scheduled operation not found")
+
+	ErrReputationInvalid            = errors.New("reputation invalid")
+	ErrReputationEventAlreadyExists = errors.New("reputation event already exists")
+	ErrReputationInternal           = errors.New("reputation internal error")
+
+	ErrLedgerInternal = errors.New("ledger internal error")
+
+	ErrIdempotencyKeyInternal = errors.New("idempotency key internal error")
 )
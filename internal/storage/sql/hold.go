@@ -0,0 +1,255 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+const holdColumns = "id, balance_id, amount, captured_amount, status, description, COALESCE(operation_id, '') AS operation_id, expires_at, created_at, updated_at"
+
+func (s *SqlStorage) CreateHold(ctx context.Context, hold *domain.Hold) (*domain.Hold, error) {
+	if hold == nil || hold.Amount <= 0 {
+		return nil, ErrBalanceInvalid
+	}
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		balance, err := s.lockBalance(txCtx, hold.BalanceID)
+		if err != nil {
+			return err
+		}
+
+		if balance.Available() < hold.Amount {
+			return ErrBalanceNotEnough
+		}
+
+		if hold.ID == "" {
+			hold.ID = uuid.NewString()
+		}
+
+		now := time.Now().UTC()
+		hold.Status = domain.HoldStatusActive
+		hold.CreatedAt = now
+		hold.UpdatedAt = now
+
+		_, err = db.ExecContext(txCtx,
+			"INSERT INTO balance_holds (id, balance_id, amount, captured_amount, status, description, expires_at, created_at, updated_at) VALUES ($1, $2, $3, 0, $4, $5, $6, $7, $7)",
+			hold.ID,
+			hold.BalanceID,
+			hold.Amount,
+			hold.Status,
+			hold.Description,
+			hold.ExpiresAt,
+			now,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgErrForeignKeyViolation {
+				return ErrBalanceNotFound
+			}
+			s.logger.Error("failed to insert hold", zap.Error(err), zap.String("balance_id", hold.BalanceID))
+			return ErrBalanceInternal
+		}
+
+		if err := s.changeHeldAmount(txCtx, balance, hold.Amount); err != nil {
+			return err
+		}
+		return s.notifyHoldChange(txCtx, balance, hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Whatever is not captured goes back to the available balance. Spend limits
+// apply when the hold is captured, not when it is placed.
+
+func (s *SqlStorage) CaptureHold(ctx context.Context, holdID string, amount int) (*domain.Hold, *domain.BalanceOperation, error) {
+	var (
+		hold      *domain.Hold
+		operation *domain.BalanceOperation
+	)
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		var err error
+		hold, err = s.lockActiveHold(txCtx, holdID)
+		if err != nil {
+			return err
+		}
+
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount < 0 || amount > hold.Amount {
+			return ErrBalanceInvalid
+		}
+
+		balance, err := s.lockBalance(txCtx, hold.BalanceID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.changeHeldAmount(txCtx, balance, -hold.Amount); err != nil {
+			return err
+		}
+
+		operation = &domain.BalanceOperation{
+			Amount:      amount,
+			Type:        domain.BalanceOperationTypeWithdraw,
+			Description: hold.Description,
+		}
+		if err := s.checkBalanceLimits(txCtx, balance, operation, time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.applyBalanceOperation(txCtx, balance, operation); err != nil {
+			return err
+		}
+
+		hold.CapturedAmount = amount
+		hold.OperationID = operation.ID
+		return s.finishHold(txCtx, hold, domain.HoldStatusCaptured)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hold, operation, nil
+}
+
+func (s *SqlStorage) ReleaseHold(ctx context.Context, holdID string) (*domain.Hold, error) {
+	var hold *domain.Hold
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		var err error
+		hold, err = s.lockActiveHold(txCtx, holdID)
+		if err != nil {
+			return err
+		}
+
+		return s.releaseHold(txCtx, hold, domain.HoldStatusReleased)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Holds locked by another replica are skipped.
+
+func (s *SqlStorage) ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error) {
+	expired := 0
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		holds := make([]*domain.Hold, 0, limit)
+		err := s.trf.Transaction(txCtx).SelectContext(txCtx, &holds,
+			"SELECT "+holdColumns+" FROM balance_holds WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at LIMIT $3 FOR UPDATE SKIP LOCKED",
+			domain.HoldStatusActive,
+			now,
+			limit,
+		)
+		if err != nil {
+			s.logger.Error("failed to select expired holds", zap.Error(err))
+			return ErrBalanceInternal
+		}
+
+		for _, hold := range holds {
+			if err := s.releaseHold(txCtx, hold, domain.HoldStatusExpired); err != nil {
+				return err
+			}
+		}
+
+		expired = len(holds)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
+
+func (s *SqlStorage) lockActiveHold(ctx context.Context, holdID string) (*domain.Hold, error) {
+	var hold domain.Hold
+	err := s.trf.Transaction(ctx).GetContext(ctx, &hold, "SELECT "+holdColumns+" FROM balance_holds WHERE id = $1 FOR UPDATE", holdID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrHoldNotFound
+		}
+		s.logger.Error("failed to lock hold", zap.Error(err), zap.String("hold_id", holdID))
+		return nil, ErrBalanceInternal
+	}
+
+	if hold.Status != domain.HoldStatusActive || !hold.ExpiresAt.After(time.Now().UTC()) {
+		return nil, ErrHoldNotActive
+	}
+
+	return &hold, nil
+}
+
+func (s *SqlStorage) releaseHold(ctx context.Context, hold *domain.Hold, status domain.HoldStatus) error {
+	balance, err := s.lockBalance(ctx, hold.BalanceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.changeHeldAmount(ctx, balance, -hold.Amount); err != nil {
+		return err
+	}
+
+	if err := s.finishHold(ctx, hold, status); err != nil {
+		return err
+	}
+	return s.notifyHoldChange(ctx, balance, hold)
+}
+
+func (s *SqlStorage) finishHold(ctx context.Context, hold *domain.Hold, status domain.HoldStatus) error {
+	now := time.Now().UTC()
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		"UPDATE balance_holds SET status = $1, captured_amount = $2, operation_id = NULLIF($3, ''), updated_at = $4 WHERE id = $5",
+		status,
+		hold.CapturedAmount,
+		hold.OperationID,
+		now,
+		hold.ID,
+	)
+	if err != nil {
+		s.logger.Error("failed to update hold", zap.Error(err), zap.String("hold_id", hold.ID))
+		return ErrBalanceInternal
+	}
+
+	hold.Status = status
+	hold.UpdatedAt = now
+
+	return nil
+}
+
+func (s *SqlStorage) changeHeldAmount(ctx context.Context, balance *domain.Balance, delta int) error {
+	err := s.trf.Transaction(ctx).GetContext(ctx,
+		&balance.Held,
+		"UPDATE balances SET held = held + $1, updated_at = $2 WHERE id = $3 RETURNING held",
+		delta,
+		time.Now().UTC(),
+		balance.ID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgErrCheckViolation {
+			return ErrBalanceNotEnough
+		}
+		s.logger.Error("failed to update held amount", zap.Error(err), zap.String("balance_id", balance.ID))
+		return ErrBalanceInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func createTestHold(t *testing.T, s *SqlStorage, balance *domain.Balance, amount int, expiresAt time.Time) *domain.Hold {
+	t.Helper()
+
+	hold, err := s.CreateHold(context.Background(), &domain.Hold{
+		BalanceID:   balance.ID,
+		Amount:      amount,
+		Description: t.Name(),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to create hold: %v", err)
+	}
+
+	return hold
+}
+
+func storedHold(t *testing.T, s *SqlStorage, holdID string) *domain.Hold {
+	t.Helper()
+	ctx := context.Background()
+
+	var hold domain.Hold
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &hold, "SELECT "+holdColumns+" FROM balance_holds WHERE id = $1", holdID); err != nil {
+		t.Fatalf("failed to get hold: %v", err)
+	}
+
+	return &hold
+}
+
+func storedHeld(t *testing.T, s *SqlStorage, balanceID string) int {
+	t.Helper()
+	ctx := context.Background()
+
+	var held int
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &held, "SELECT held FROM balances WHERE id = $1", balanceID); err != nil {
+		t.Fatalf("failed to get held amount: %v", err)
+	}
+
+	return held
+}
+
+func TestCaptureHold(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	deposit(t, s, balance, 100)
+
+	hold := createTestHold(t, s, balance, 60, time.Now().Add(time.Hour))
+	if got := storedHeld(t, s, balance.ID); got != 60 {
+		t.Fatalf("held amount is %d after a hold of 60", got)
+	}
+
+	// Only the 40 points outside the hold can be withdrawn or held again.
+	_, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		Amount:      50,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "hold test",
+	})
+	if !errors.Is(err, ErrBalanceNotEnough) {
+		t.Errorf("withdrawing held points: expected ErrBalanceNotEnough, got %v", err)
+	}
+	_, err = s.CreateHold(ctx, &domain.Hold{BalanceID: balance.ID, Amount: 41, ExpiresAt: time.Now().Add(time.Hour)})
+	if !errors.Is(err, ErrBalanceNotEnough) {
+		t.Errorf("holding held points: expected ErrBalanceNotEnough, got %v", err)
+	}
+
+	if _, _, err := s.CaptureHold(ctx, hold.ID, 61); !errors.Is(err, ErrBalanceInvalid) {
+		t.Errorf("capturing more than held: expected ErrBalanceInvalid, got %v", err)
+	}
+
+	captured, operation, err := s.CaptureHold(ctx, hold.ID, 45)
+	if err != nil {
+		t.Fatalf("failed to capture hold: %v", err)
+	}
+	if captured.Status != domain.HoldStatusCaptured || captured.CapturedAmount != 45 {
+		t.Errorf("captured hold has status %s and captured amount %d", captured.Status, captured.CapturedAmount)
+	}
+	if operation.Amount != 45 || operation.Type != domain.BalanceOperationTypeWithdraw {
+		t.Errorf("capture created a %s of %d", operation.Type, operation.Amount)
+	}
+	if got := storedBalance(t, s, balance.ID); got != 55 {
+		t.Errorf("balance is %d after capturing 45 of 100", got)
+	}
+	if got := storedHeld(t, s, balance.ID); got != 0 {
+		t.Errorf("held amount is %d after the hold was captured", got)
+	}
+	if got := storedHold(t, s, hold.ID); got.OperationID != operation.ID {
+		t.Errorf("hold references operation %q, want %q", got.OperationID, operation.ID)
+	}
+
+	if _, _, err := s.CaptureHold(ctx, hold.ID, 0); !errors.Is(err, ErrHoldNotActive) {
+		t.Errorf("capturing twice: expected ErrHoldNotActive, got %v", err)
+	}
+	if _, err := s.ReleaseHold(ctx, hold.ID); !errors.Is(err, ErrHoldNotActive) {
+		t.Errorf("releasing a captured hold: expected ErrHoldNotActive, got %v", err)
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	deposit(t, s, balance, 100)
+
+	hold := createTestHold(t, s, balance, 60, time.Now().Add(time.Hour))
+
+	released, err := s.ReleaseHold(ctx, hold.ID)
+	if err != nil {
+		t.Fatalf("failed to release hold: %v", err)
+	}
+	if released.Status != domain.HoldStatusReleased {
+		t.Errorf("released hold has status %s", released.Status)
+	}
+	if got := storedBalance(t, s, balance.ID); got != 100 {
+		t.Errorf("balance is %d after a release", got)
+	}
+	if got := storedHeld(t, s, balance.ID); got != 0 {
+		t.Errorf("held amount is %d after a release", got)
+	}
+
+	if _, err := s.ReleaseHold(ctx, hold.ID); !errors.Is(err, ErrHoldNotActive) {
+		t.Errorf("releasing twice: expected ErrHoldNotActive, got %v", err)
+	}
+	if _, _, err := s.CaptureHold(ctx, hold.ID, 0); !errors.Is(err, ErrHoldNotActive) {
+		t.Errorf("capturing a released hold: expected ErrHoldNotActive, got %v", err)
+	}
+}
+
+func TestExpireHolds(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	deposit(t, s, balance, 100)
+
+	expired := createTestHold(t, s, balance, 30, time.Now().Add(-time.Second))
+	live := createTestHold(t, s, balance, 20, time.Now().Add(time.Hour))
+
+	// A hold past its expiry can no longer be captured, even before the
+	// expiry job has released it.
+	if _, _, err := s.CaptureHold(ctx, expired.ID, 0); !errors.Is(err, ErrHoldNotActive) {
+		t.Errorf("capturing an expired hold: expected ErrHoldNotActive, got %v", err)
+	}
+
+	for {
+		n, err := s.ExpireHolds(ctx, time.Now().UTC(), 100)
+		if err != nil {
+			t.Fatalf("failed to expire holds: %v", err)
+		}
+		if n < 100 {
+			break
+		}
+	}
+
+	if got := storedHold(t, s, expired.ID); got.Status != domain.HoldStatusExpired {
+		t.Errorf("expired hold has status %s", got.Status)
+	}
+	if got := storedHold(t, s, live.ID); got.Status != domain.HoldStatusActive {
+		t.Errorf("live hold has status %s", got.Status)
+	}
+	if got := storedHeld(t, s, balance.ID); got != 20 {
+		t.Errorf("held amount is %d, want the 20 of the live hold", got)
+	}
+	if got := storedBalance(t, s, balance.ID); got != 100 {
+		t.Errorf("balance is %d after an expiry", got)
+	}
+}
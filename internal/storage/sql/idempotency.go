@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReserveIdempotencyKey claims the key for the current transaction. When the
+// key is already taken by a live record, that record is returned locked and
+// reserved is false. Expired records are taken over as if they did not exist.
+func (s *SqlStorage) ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, bool, error) {
+	db := s.trf.Transaction(ctx)
+
+	var reserved domain.IdempotencyKey
+	err := db.GetContext(ctx, &reserved,
+		`INSERT INTO idempotency_keys (scope, key, request_hash, created_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (scope, key) DO UPDATE
+		 SET request_hash = EXCLUDED.request_hash,
+			 response = NULL,
+			 created_at = EXCLUDED.created_at,
+			 expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		 RETURNING scope, key, request_hash, response, created_at, expires_at`,
+		key.Scope,
+		key.Key,
+		key.RequestHash,
+		key.CreatedAt,
+		key.ExpiresAt,
+	)
+	if err == nil {
+		return &reserved, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Error("failed to reserve idempotency key", zap.Error(err), zap.String("scope", key.Scope), zap.String("key", key.Key))
+		return nil, false, ErrIdempotencyKeyInternal
+	}
+
+	var existing domain.IdempotencyKey
+	err = db.GetContext(ctx, &existing,
+		"SELECT scope, key, request_hash, response, created_at, expires_at FROM idempotency_keys WHERE scope = $1 AND key = $2 FOR UPDATE",
+		key.Scope,
+		key.Key,
+	)
+	if err != nil {
+		s.logger.Error("failed to get idempotency key", zap.Error(err), zap.String("scope", key.Scope), zap.String("key", key.Key))
+		return nil, false, ErrIdempotencyKeyInternal
+	}
+
+	return &existing, false, nil
+}
+
+func (s *SqlStorage) SaveIdempotencyResponse(ctx context.Context, scope string, key string, response []byte) error {
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		"UPDATE idempotency_keys SET response = $1 WHERE scope = $2 AND key = $3",
+		response,
+		scope,
+		key,
+	)
+	if err != nil {
+		s.logger.Error("failed to save idempotency response", zap.Error(err), zap.String("scope", scope), zap.String("key", key))
+		return ErrIdempotencyKeyInternal
+	}
+
+	return nil
+}
+
+func (s *SqlStorage) DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.trf.Transaction(ctx).ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", before)
+	if err != nil {
+		s.logger.Error("failed to delete expired idempotency keys", zap.Error(err))
+		return 0, ErrIdempotencyKeyInternal
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("failed to get rows affected on idempotency cleanup", zap.Error(err))
+		return 0, ErrIdempotencyKeyInternal
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,146 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestIdempotencyKey(hash string, ttl time.Duration) *domain.IdempotencyKey {
+	now := time.Now().UTC()
+	return &domain.IdempotencyKey{
+		Scope:       "test",
+		Key:         uuid.NewString(),
+		RequestHash: hash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func TestReserveIdempotencyKeyReplay(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	key := newTestIdempotencyKey("hash-1", time.Hour)
+
+	_, reserved, err := s.ReserveIdempotencyKey(ctx, key)
+	if err != nil || !reserved {
+		t.Fatalf("first reservation: reserved %v, err %v", reserved, err)
+	}
+	if err := s.SaveIdempotencyResponse(ctx, key.Scope, key.Key, []byte("response")); err != nil {
+		t.Fatalf("failed to save response: %v", err)
+	}
+
+	replay := *key
+	stored, reserved, err := s.ReserveIdempotencyKey(ctx, &replay)
+	if err != nil {
+		t.Fatalf("failed to replay key: %v", err)
+	}
+	if reserved {
+		t.Fatal("replayed key was reserved again")
+	}
+	if !bytes.Equal(stored.Response, []byte("response")) {
+		t.Errorf("replay returned response %q", stored.Response)
+	}
+
+	// Reusing the key for another request must not overwrite the record: the
+	// caller compares the stored hash and rejects the request.
+	reuse := *key
+	reuse.RequestHash = "hash-2"
+	stored, reserved, err = s.ReserveIdempotencyKey(ctx, &reuse)
+	if err != nil {
+		t.Fatalf("failed to reuse key: %v", err)
+	}
+	if reserved || stored.RequestHash != "hash-1" {
+		t.Errorf("reused key: reserved %v, stored hash %q", reserved, stored.RequestHash)
+	}
+}
+
+func TestReserveIdempotencyKeyTakesOverExpired(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	key := newTestIdempotencyKey("hash-1", -time.Minute)
+	key.CreatedAt = key.ExpiresAt.Add(-time.Hour)
+
+	if _, _, err := s.ReserveIdempotencyKey(ctx, key); err != nil {
+		t.Fatalf("failed to reserve key: %v", err)
+	}
+	if err := s.SaveIdempotencyResponse(ctx, key.Scope, key.Key, []byte("stale")); err != nil {
+		t.Fatalf("failed to save response: %v", err)
+	}
+
+	again := newTestIdempotencyKey("hash-2", time.Hour)
+	again.Key = key.Key
+	stored, reserved, err := s.ReserveIdempotencyKey(ctx, again)
+	if err != nil {
+		t.Fatalf("failed to reserve expired key: %v", err)
+	}
+	if !reserved {
+		t.Fatal("expired key was not taken over")
+	}
+	if stored.Response != nil || stored.RequestHash != "hash-2" {
+		t.Errorf("taken over key kept response %q and hash %q", stored.Response, stored.RequestHash)
+	}
+}
+
+func TestReserveIdempotencyKeyConcurrent(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	key := newTestIdempotencyKey("hash-1", time.Hour)
+
+	const requests = 16
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int64
+		replayed atomic.Int64
+	)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.Do(ctx, func(txCtx context.Context) error {
+				request := *key
+				stored, ok, err := s.ReserveIdempotencyKey(txCtx, &request)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					if bytes.Equal(stored.Response, []byte("done")) {
+						replayed.Add(1)
+					}
+					return nil
+				}
+				reserved.Add(1)
+
+				_, err = s.CreateBalanceOperation(txCtx, &domain.BalanceOperation{
+					BalanceID:   balance.ID,
+					Amount:      10,
+					Type:        domain.BalanceOperationTypeDeposit,
+					Description: "idempotency test",
+				})
+				if err != nil {
+					return err
+				}
+				return s.SaveIdempotencyResponse(txCtx, key.Scope, key.Key, []byte("done"))
+			})
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved.Load() != 1 || replayed.Load() != requests-1 {
+		t.Errorf("%d requests reserved the key and %d replayed it", reserved.Load(), replayed.Load())
+	}
+	if stored := storedBalance(t, s, balance.ID); stored != 10 {
+		t.Errorf("balance is %d after %d identical requests", stored, requests)
+	}
+}
@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.uber.org/zap"
+)
+
+// The current balance does not depend on the period.
+func leaderboardScoreColumn(metric domain.LeaderboardMetric, period domain.LeaderboardPeriod) (string, error) {
+	switch metric {
+	case domain.LeaderboardMetricBalance:
+		return "balance", nil
+	case domain.LeaderboardMetricEarned:
+		switch period {
+		case domain.LeaderboardPeriodAllTime:
+			return "earned_all_time", nil
+		case domain.LeaderboardPeriodMonth:
+			return "earned_month", nil
+		case domain.LeaderboardPeriodWeek:
+			return "earned_week", nil
+		}
+	}
+	return "", ErrBalanceInvalid
+}
+
+func (s *SqlStorage) GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error) {
+	column, err := leaderboardScoreColumn(query.Metric, query.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := sq.Select("max_id", column+" AS score", "RANK() OVER (ORDER BY "+column+" DESC) AS rank").
+		From("leaderboard_stats")
+	if query.Geolocation != "" {
+		ranked = ranked.Where(sq.Eq{"geolocation": query.Geolocation})
+	}
+	if query.ReputationGroupID > 0 {
+		ranked = ranked.Where(sq.Eq{"reputation_group_id": query.ReputationGroupID})
+	}
+
+	db := s.trf.Transaction(ctx)
+	leaderboard := &domain.Leaderboard{}
+
+	pb := sq.Select("r.rank", "r.max_id", "u.name", "r.score").
+		FromSelect(ranked, "r").
+		Join("users u ON u.max_id = r.max_id").
+		OrderBy("r.rank", "r.max_id").
+		PlaceholderFormat(sq.Dollar)
+	if query.Limit > 0 {
+		pb = pb.Limit(uint64(query.Limit))
+	}
+	if query.Offset > 0 {
+		pb = pb.Offset(uint64(query.Offset))
+	}
+
+	pageQuery, pageArgs := pb.MustSql()
+	leaderboard.Entries = make([]*domain.LeaderboardEntry, 0, query.Limit)
+	if err := db.SelectContext(ctx, &leaderboard.Entries, pageQuery, pageArgs...); err != nil {
+		s.logger.Error("failed to get leaderboard page", zap.Error(err), zap.Any("query", query))
+		return nil, ErrBalanceInternal
+	}
+
+	countQuery, countArgs := sq.Select("COUNT(*)").FromSelect(ranked, "r").PlaceholderFormat(sq.Dollar).MustSql()
+	if err := db.GetContext(ctx, &leaderboard.Total, countQuery, countArgs...); err != nil {
+		s.logger.Error("failed to count leaderboard", zap.Error(err), zap.Any("query", query))
+		return nil, ErrBalanceInternal
+	}
+
+	if query.CallerMaxID != "" {
+		callerQuery, callerArgs := sq.Select("r.rank", "r.max_id", "u.name", "r.score").
+			FromSelect(ranked, "r").
+			Join("users u ON u.max_id = r.max_id").
+			Where(sq.Eq{"r.max_id": query.CallerMaxID}).
+			PlaceholderFormat(sq.Dollar).
+			MustSql()
+
+		var caller domain.LeaderboardEntry
+		err := db.GetContext(ctx, &caller, callerQuery, callerArgs...)
+		switch {
+		case err == nil:
+			leaderboard.Caller = &caller
+		case !errors.Is(err, sql.ErrNoRows):
+			s.logger.Error("failed to get caller leaderboard rank", zap.Error(err), zap.String("max_id", query.CallerMaxID))
+			return nil, ErrBalanceInternal
+		}
+	}
+
+	var refreshedAt sql.NullTime
+	if err := db.GetContext(ctx, &refreshedAt, "SELECT MAX(refreshed_at) FROM leaderboard_stats"); err != nil {
+		s.logger.Error("failed to get leaderboard refresh time", zap.Error(err))
+		return nil, ErrBalanceInternal
+	}
+	leaderboard.RefreshedAt = refreshedAt.Time
+
+	return leaderboard, nil
+}
+
+func (s *SqlStorage) RefreshLeaderboard(ctx context.Context) error {
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY leaderboard_stats"); err != nil {
+		s.logger.Error("failed to refresh leaderboard", zap.Error(err))
+		return ErrBalanceInternal
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGetLeaderboard(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+
+	a := newTestBalance(t, s)
+	b := newTestBalance(t, s)
+	c := newTestBalance(t, s)
+	d := newTestBalance(t, s)
+
+	deposit(t, s, a, 50)
+	deposit(t, s, b, 100)
+	deposit(t, s, c, 30)
+	deposit(t, s, d, 40)
+	if _, err := withdraw(ctx, s, c, 30); err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+	// Received transfers raise the balance but were not earned.
+	_, err := s.CreateTransfer(ctx, &domain.Transfer{
+		Amount:      60,
+		Description: "leaderboard test",
+		FromBalance: &domain.Balance{ID: b.ID},
+		ToBalance:   &domain.Balance{ID: a.ID},
+	})
+	if err != nil {
+		t.Fatalf("failed to transfer: %v", err)
+	}
+
+	// A geolocation of their own keeps other users off this leaderboard.
+	geolocation := "test-" + uuid.NewString()
+	_, err = s.trf.Transaction(ctx).ExecContext(ctx,
+		"UPDATE users SET geolocation = $1 WHERE max_id = ANY($2)",
+		geolocation,
+		[]string{a.UserID, b.UserID, c.UserID, d.UserID},
+	)
+	if err != nil {
+		t.Fatalf("failed to set geolocation: %v", err)
+	}
+
+	if err := s.RefreshLeaderboard(ctx); err != nil {
+		t.Fatalf("failed to refresh leaderboard: %v", err)
+	}
+
+	tests := []struct {
+		metric domain.LeaderboardMetric
+		want   []domain.LeaderboardEntry
+	}{
+		{
+			metric: domain.LeaderboardMetricBalance,
+			want: []domain.LeaderboardEntry{
+				{Rank: 1, MaxID: a.UserID, Score: 110},
+				{Rank: 2, MaxID: min(b.UserID, d.UserID), Score: 40},
+				{Rank: 2, MaxID: max(b.UserID, d.UserID), Score: 40},
+				{Rank: 4, MaxID: c.UserID, Score: 0},
+			},
+		},
+		{
+			metric: domain.LeaderboardMetricEarned,
+			want: []domain.LeaderboardEntry{
+				{Rank: 1, MaxID: b.UserID, Score: 100},
+				{Rank: 2, MaxID: a.UserID, Score: 50},
+				{Rank: 3, MaxID: d.UserID, Score: 40},
+				{Rank: 4, MaxID: c.UserID, Score: 30},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			leaderboard, err := s.GetLeaderboard(ctx, domain.LeaderboardQuery{
+				Period:      domain.LeaderboardPeriodAllTime,
+				Metric:      tt.metric,
+				Geolocation: geolocation,
+				CallerMaxID: c.UserID,
+				Limit:       2,
+				Offset:      1,
+			})
+			if err != nil {
+				t.Fatalf("failed to get leaderboard: %v", err)
+			}
+
+			if leaderboard.Total != len(tt.want) {
+				t.Errorf("total is %d, want %d", leaderboard.Total, len(tt.want))
+			}
+			page := tt.want[1:3]
+			if len(leaderboard.Entries) != len(page) {
+				t.Fatalf("page has %d entries, want %d", len(leaderboard.Entries), len(page))
+			}
+			for i, entry := range leaderboard.Entries {
+				if entry.Rank != page[i].Rank || entry.MaxID != page[i].MaxID || entry.Score != page[i].Score {
+					t.Errorf("entry %d is %+v, want %+v", i, entry, page[i])
+				}
+			}
+			if caller := leaderboard.Caller; caller == nil || caller.Rank != 4 || caller.Score != tt.want[3].Score {
+				t.Errorf("caller entry is %+v", caller)
+			}
+		})
+	}
+}
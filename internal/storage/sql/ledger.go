@@ -0,0 +1,171 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// User wallets are credited when points arrive and debited when they leave.
+func ledgerAccountsFor(operation *domain.BalanceOperation) (debit string, credit string, err error) {
+	wallet := domain.WalletLedgerAccountID(operation.BalanceID)
+
+	// Only deposits and withdrawals can be reversed, so the type alone tells
+	// which entry to mirror.
+
+	if operation.ReversesOperationID != "" {
+		switch operation.Type {
+		case domain.BalanceOperationTypeDeposit:
+			return domain.LedgerAccountRedemptions, wallet, nil
+		case domain.BalanceOperationTypeWithdraw:
+			return wallet, domain.LedgerAccountRewardsIssued, nil
+		default:
+			return "", "", ErrBalanceInvalid
+		}
+	}
+
+	switch operation.Type {
+	case domain.BalanceOperationTypeAdjustment:
+		if operation.Amount < 0 {
+			return wallet, domain.LedgerAccountAdjustments, nil
+		}
+		return domain.LedgerAccountAdjustments, wallet, nil
+	case domain.BalanceOperationTypeDeposit:
+		if operation.TransferID != "" {
+			return domain.LedgerAccountTransfers, wallet, nil
+		}
+		return domain.LedgerAccountRewardsIssued, wallet, nil
+	case domain.BalanceOperationTypeWithdraw:
+		if operation.TransferID != "" {
+			return wallet, domain.LedgerAccountTransfers, nil
+		}
+		return wallet, domain.LedgerAccountRedemptions, nil
+	case domain.BalanceOperationTypeExpire:
+		return wallet, domain.LedgerAccountExpired, nil
+	default:
+		return "", "", ErrBalanceInvalid
+	}
+}
+
+func (s *SqlStorage) createWalletLedgerAccount(ctx context.Context, balanceID string) error {
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		"INSERT INTO ledger_accounts (id, kind, balance_id) VALUES ($1, $2, $3) ON CONFLICT (id) DO NOTHING",
+		domain.WalletLedgerAccountID(balanceID),
+		domain.LedgerAccountKindWallet,
+		balanceID,
+	)
+	if err != nil {
+		s.logger.Error("failed to create wallet ledger account", zap.Error(err), zap.String("balance_id", balanceID))
+		return ErrLedgerInternal
+	}
+
+	return nil
+}
+
+func (s *SqlStorage) postJournalEntry(ctx context.Context, operation *domain.BalanceOperation, debit string, credit string) error {
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		`INSERT INTO ledger_entries (id, operation_id, account_id, direction, amount, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6), ($7, $2, $8, $9, $5, $6)`,
+		uuid.NewString(),
+		operation.ID,
+		debit,
+		domain.LedgerEntryDirectionDebit,
+		max(operation.Amount, -operation.Amount),
+		operation.CreatedAt,
+		uuid.NewString(),
+		credit,
+		domain.LedgerEntryDirectionCredit,
+	)
+	if err != nil {
+		s.logger.Error("failed to post journal entry", zap.Error(err), zap.String("operation_id", operation.ID))
+		return ErrLedgerInternal
+	}
+
+	return nil
+}
+
+func (s *SqlStorage) postJournalEntries(ctx context.Context, operations []*domain.BalanceOperation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	ib := sq.Insert("ledger_entries").
+		Columns("id", "operation_id", "account_id", "direction", "amount", "created_at").
+		PlaceholderFormat(sq.Dollar)
+	for _, operation := range operations {
+		debit, credit, err := ledgerAccountsFor(operation)
+		if err != nil {
+			return err
+		}
+		amount := max(operation.Amount, -operation.Amount)
+		ib = ib.
+			Values(uuid.NewString(), operation.ID, debit, domain.LedgerEntryDirectionDebit, amount, operation.CreatedAt).
+			Values(uuid.NewString(), operation.ID, credit, domain.LedgerEntryDirectionCredit, amount, operation.CreatedAt)
+	}
+
+	query, args := ib.MustSql()
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, query, args...); err != nil {
+		s.logger.Error("failed to post journal entries", zap.Error(err), zap.Int("count", len(operations)))
+		return ErrLedgerInternal
+	}
+
+	return nil
+}
+
+// A balance without a wallet account counts as a ledger balance of zero.
+
+func (s *SqlStorage) GetTrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	db := s.trf.Transaction(ctx)
+
+	lines := make([]*domain.TrialBalanceLine, 0, 5)
+	err := db.SelectContext(ctx, &lines,
+		`SELECT
+			CASE WHEN la.kind = $1 THEN 'wallets' ELSE la.id END AS account,
+			la.kind,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.direction = $2), 0) AS debit,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.direction = $3), 0) AS credit
+		 FROM ledger_accounts la
+		 LEFT JOIN ledger_entries le ON le.account_id = la.id
+		 GROUP BY 1, 2
+		 ORDER BY 2, 1`,
+		domain.LedgerAccountKindWallet,
+		domain.LedgerEntryDirectionDebit,
+		domain.LedgerEntryDirectionCredit,
+	)
+	if err != nil {
+		s.logger.Error("failed to get trial balance", zap.Error(err))
+		return nil, ErrLedgerInternal
+	}
+
+	trialBalance := &domain.TrialBalance{Lines: lines}
+	for _, line := range lines {
+		trialBalance.TotalDebit += line.Debit
+		trialBalance.TotalCredit += line.Credit
+	}
+
+	trialBalance.WalletMismatches = make([]*domain.LedgerWalletMismatch, 0)
+	err = db.SelectContext(ctx, &trialBalance.WalletMismatches,
+		`SELECT
+			COALESCE(la.id, $1::text || b.id) AS account,
+			b.id AS balance_id,
+			COALESCE(SUM(CASE WHEN le.direction = $2 THEN le.amount ELSE -le.amount END), 0) AS ledger_balance,
+			b.balance
+		 FROM balances b
+		 LEFT JOIN ledger_accounts la ON la.balance_id = b.id
+		 LEFT JOIN ledger_entries le ON le.account_id = la.id
+		 GROUP BY la.id, b.id, b.balance
+		 HAVING COALESCE(SUM(CASE WHEN le.direction = $2 THEN le.amount ELSE -le.amount END), 0) <> b.balance
+		 ORDER BY b.id`,
+		domain.WalletLedgerAccountID(""),
+		domain.LedgerEntryDirectionCredit,
+	)
+	if err != nil {
+		s.logger.Error("failed to get ledger wallet mismatches", zap.Error(err))
+		return nil, ErrLedgerInternal
+	}
+
+	return trialBalance, nil
+}
@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"testing"
+)
+
+func TestTrialBalance(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	from := newTestBalance(t, s)
+	to := newTestBalance(t, s)
+
+	deposit(t, s, from, 100)
+	_, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   from.ID,
+		Amount:      30,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "ledger test",
+	})
+	if err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+	_, err = s.CreateTransfer(ctx, &domain.Transfer{
+		Amount:      20,
+		Description: "ledger test",
+		FromBalance: &domain.Balance{ID: from.ID},
+		ToBalance:   &domain.Balance{ID: to.ID},
+	})
+	if err != nil {
+		t.Fatalf("failed to transfer: %v", err)
+	}
+
+	trialBalance, err := s.GetTrialBalance(ctx)
+	if err != nil {
+		t.Fatalf("failed to get trial balance: %v", err)
+	}
+	if !trialBalance.Balanced() {
+		t.Errorf("trial balance is off: debit %d, credit %d", trialBalance.TotalDebit, trialBalance.TotalCredit)
+	}
+
+	// Every operation posts exactly one debit and one credit of its amount.
+	var unbalanced int
+	err = s.trf.Transaction(ctx).GetContext(ctx, &unbalanced,
+		`SELECT COUNT(*) FROM (
+			SELECT le.operation_id
+			FROM ledger_entries le
+			JOIN balance_operations bo ON bo.id = le.operation_id
+			WHERE bo.balance_id IN ($1, $2)
+			GROUP BY le.operation_id
+			HAVING COUNT(*) FILTER (WHERE le.direction = 'debit') <> 1
+				OR COUNT(*) FILTER (WHERE le.direction = 'credit') <> 1
+				OR SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) <> 0
+		) unbalanced`,
+		from.ID,
+		to.ID,
+	)
+	if err != nil {
+		t.Fatalf("failed to check journal entries: %v", err)
+	}
+	if unbalanced != 0 {
+		t.Errorf("%d operations have unbalanced journal entries", unbalanced)
+	}
+
+	for _, balance := range []*domain.Balance{from, to} {
+		var account int
+		err := s.trf.Transaction(ctx).GetContext(ctx, &account,
+			"SELECT COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0) FROM ledger_entries WHERE account_id = $1",
+			domain.WalletLedgerAccountID(balance.ID),
+		)
+		if err != nil {
+			t.Fatalf("failed to sum wallet account: %v", err)
+		}
+		if stored := storedBalance(t, s, balance.ID); account != stored {
+			t.Errorf("wallet account of %s holds %d, balance is %d", balance.ID, account, stored)
+		}
+	}
+}
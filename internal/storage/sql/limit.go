@@ -0,0 +1,105 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type limitedOperation struct {
+	Amount    int       `db:"amount"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func limitDirectionFor(operationType domain.BalanceOperationType) (domain.LimitDirection, bool) {
+	switch operationType {
+	case domain.BalanceOperationTypeDeposit:
+		return domain.LimitDirectionEarn, true
+	case domain.BalanceOperationTypeWithdraw:
+		return domain.LimitDirectionSpend, true
+	default:
+		return "", false
+	}
+}
+
+// Must run under the balance row lock so concurrent operations cannot both
+// squeeze under the same limit. Reversals and reversed operations do not count.
+func (s *SqlStorage) checkBalanceLimits(ctx context.Context, balance *domain.Balance, operation *domain.BalanceOperation, now time.Time) error {
+	direction, ok := limitDirectionFor(operation.Type)
+	if !ok || balance.WalletType != domain.WalletTypePoints {
+		return nil
+	}
+
+	db := s.trf.Transaction(ctx)
+
+	limits := make([]*domain.ReputationGroupLimit, 0, 2)
+	err := db.SelectContext(ctx, &limits,
+		`SELECT l.reputation_group_id, l.direction, l.window_seconds, l.max_amount
+		 FROM reputation_group_limits l
+		 JOIN users u ON u.reputation_group_id = l.reputation_group_id
+		 WHERE u.max_id = $1 AND l.direction = $2
+		 ORDER BY l.window_seconds`,
+		balance.UserID,
+		direction,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance limits", zap.Error(err), zap.String("balance_id", balance.ID))
+		return ErrBalanceInternal
+	}
+
+	for _, limit := range limits {
+		operations := make([]*limitedOperation, 0, 8)
+		err := db.SelectContext(ctx, &operations,
+			`SELECT bo.amount, bo.created_at
+			 FROM balance_operations bo
+			 WHERE bo.balance_id = $1
+			   AND bo.type = $2
+			   AND bo.reverses_operation_id IS NULL
+			   AND bo.created_at > $3
+			   AND NOT EXISTS (SELECT 1 FROM balance_operations r WHERE r.reverses_operation_id = bo.id)
+			 ORDER BY bo.created_at`,
+			balance.ID,
+			operation.Type,
+			now.Add(-limit.Window()),
+		)
+		if err != nil {
+			s.logger.Error("failed to get operations within limit window", zap.Error(err), zap.String("balance_id", balance.ID))
+			return ErrBalanceInternal
+		}
+
+		if err := checkLimit(limit, operations, operation.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// operations must be oldest first.
+func checkLimit(limit *domain.ReputationGroupLimit, operations []*limitedOperation, amount int) error {
+	used := 0
+	for _, op := range operations {
+		used += op.Amount
+	}
+	if used+amount <= limit.MaxAmount {
+		return nil
+	}
+
+	limitErr := &domain.LimitExceededError{Limit: *limit, Used: used}
+	if amount <= limit.MaxAmount {
+		// The window rolls forward: the operation fits once enough of the
+		// oldest operations have dropped out of it.
+		excess := used + amount - limit.MaxAmount
+		for _, op := range operations {
+			excess -= op.Amount
+			if excess <= 0 {
+				limitErr.ResetsAt = op.CreatedAt.Add(limit.Window())
+				break
+			}
+		}
+	}
+
+	return limitErr
+}
@@ -0,0 +1,199 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newTestReputationGroup creates a group nobody reaches by reputation and
+// removes it again when the test ends.
+func newTestReputationGroup(t *testing.T, s *SqlStorage) int {
+	t.Helper()
+	ctx := context.Background()
+
+	var need int
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &need, "SELECT GREATEST(MAX(reputation_need) + 1, 1000000000) FROM reputation_groups"); err != nil {
+		t.Fatalf("failed to get reputation threshold: %v", err)
+	}
+	group, err := s.CreateReputationGroup(ctx, &domain.ReputationGroup{
+		Name:           "test-" + uuid.NewString(),
+		Description:    "test group",
+		Coefficient:    1,
+		ReputationNeed: need,
+	})
+	if err != nil {
+		t.Fatalf("failed to create reputation group: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_, err := s.DeleteReputationGroup(ctx, group.ID, domain.DefaultReputationGroupID)
+		if err != nil && !errors.Is(err, ErrReputationGroupNotFound) {
+			t.Errorf("failed to delete reputation group: %v", err)
+		}
+	})
+
+	return group.ID
+}
+
+func moveToReputationGroup(t *testing.T, s *SqlStorage, maxID string, groupID int) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE users SET reputation_group_id = $1 WHERE max_id = $2", groupID, maxID); err != nil {
+		t.Fatalf("failed to move user to reputation group: %v", err)
+	}
+}
+
+func withdraw(ctx context.Context, s *SqlStorage, balance *domain.Balance, amount int) (*domain.BalanceOperation, error) {
+	return s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		Amount:      amount,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "limit test",
+	})
+}
+
+func TestBalanceLimitWindows(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	deposit(t, s, balance, 500)
+
+	groupID := newTestReputationGroup(t, s)
+	moveToReputationGroup(t, s, balance.UserID, groupID)
+	_, err := s.SetReputationGroupLimits(ctx, groupID, []*domain.ReputationGroupLimit{
+		{Direction: domain.LimitDirectionEarn, WindowSeconds: 3600, MaxAmount: 500},
+		{Direction: domain.LimitDirectionSpend, WindowSeconds: 3600, MaxAmount: 100},
+		{Direction: domain.LimitDirectionSpend, WindowSeconds: 86400, MaxAmount: 150},
+	})
+	if err != nil {
+		t.Fatalf("failed to set limits: %v", err)
+	}
+
+	var limitErr *domain.LimitExceededError
+	_, err = s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		Amount:      1,
+		Type:        domain.BalanceOperationTypeDeposit,
+		Description: "limit test",
+	})
+	if !errors.As(err, &limitErr) || limitErr.Limit.Direction != domain.LimitDirectionEarn || limitErr.Used != 500 {
+		t.Errorf("deposit over the earn limit: got %v", err)
+	}
+
+	first, err := withdraw(ctx, s, balance, 60)
+	if err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	_, err = withdraw(ctx, s, balance, 50)
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("withdrawal over the hourly limit: expected LimitExceededError, got %v", err)
+	}
+	if limitErr.Limit.WindowSeconds != 3600 || limitErr.Used != 60 {
+		t.Errorf("hourly limit error reports window %d and used %d", limitErr.Limit.WindowSeconds, limitErr.Used)
+	}
+	if resetsAt := first.CreatedAt.Add(time.Hour); limitErr.ResetsAt.Sub(resetsAt).Abs() > time.Millisecond {
+		t.Errorf("limit resets at %s, want %s", limitErr.ResetsAt, resetsAt)
+	}
+
+	if _, err := withdraw(ctx, s, balance, 40); err != nil {
+		t.Fatalf("withdrawal up to the limit failed: %v", err)
+	}
+
+	_, err = withdraw(ctx, s, balance, 101)
+	if !errors.As(err, &limitErr) || !limitErr.ResetsAt.IsZero() {
+		t.Errorf("withdrawal larger than the limit: got %v", err)
+	}
+
+	// A reversed operation no longer counts against the limit.
+	if _, err := s.ReverseBalanceOperation(ctx, first.ID, "limit test"); err != nil {
+		t.Fatalf("failed to reverse withdrawal: %v", err)
+	}
+	if _, err := withdraw(ctx, s, balance, 60); err != nil {
+		t.Fatalf("withdrawal after a reversal failed: %v", err)
+	}
+
+	// Two hours later the hourly window is empty again, but the daily one
+	// still holds the 100 points spent.
+	_, err = s.trf.Transaction(ctx).ExecContext(ctx,
+		"UPDATE balance_operations SET created_at = created_at - INTERVAL '2 hours' WHERE balance_id = $1",
+		balance.ID,
+	)
+	if err != nil {
+		t.Fatalf("failed to age operations: %v", err)
+	}
+	_, err = withdraw(ctx, s, balance, 60)
+	if !errors.As(err, &limitErr) || limitErr.Limit.WindowSeconds != 86400 || limitErr.Used != 100 {
+		t.Errorf("withdrawal over the daily limit: got %v", err)
+	}
+	if _, err := withdraw(ctx, s, balance, 50); err != nil {
+		t.Errorf("withdrawal within both windows failed: %v", err)
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	from := newTestBalance(t, s)
+	limited := newTestBalance(t, s)
+	unlimited := newTestBalance(t, s)
+	deposit(t, s, from, 200)
+
+	for _, tt := range []struct {
+		balance *domain.Balance
+		limit   *domain.ReputationGroupLimit
+	}{
+		{from, &domain.ReputationGroupLimit{Direction: domain.LimitDirectionSpend, WindowSeconds: 3600, MaxAmount: 50}},
+		{limited, &domain.ReputationGroupLimit{Direction: domain.LimitDirectionEarn, WindowSeconds: 3600, MaxAmount: 30}},
+	} {
+		groupID := newTestReputationGroup(t, s)
+		moveToReputationGroup(t, s, tt.balance.UserID, groupID)
+		if _, err := s.SetReputationGroupLimits(ctx, groupID, []*domain.ReputationGroupLimit{tt.limit}); err != nil {
+			t.Fatalf("failed to set limits: %v", err)
+		}
+	}
+
+	transfer := func(to *domain.Balance, amount int) error {
+		_, err := s.CreateTransfer(ctx, &domain.Transfer{
+			Amount:      amount,
+			Description: "limit test",
+			FromBalance: &domain.Balance{ID: from.ID},
+			ToBalance:   &domain.Balance{ID: to.ID},
+		})
+		return err
+	}
+
+	var limitErr *domain.LimitExceededError
+	if err := transfer(limited, 40); !errors.As(err, &limitErr) || limitErr.Limit.Direction != domain.LimitDirectionEarn {
+		t.Errorf("transfer over the recipient's earn limit: got %v", err)
+	}
+	if err := transfer(limited, 25); err != nil {
+		t.Fatalf("transfer within both limits failed: %v", err)
+	}
+	if err := transfer(unlimited, 30); !errors.As(err, &limitErr) || limitErr.Limit.Direction != domain.LimitDirectionSpend || limitErr.Used != 25 {
+		t.Errorf("transfer over the sender's spend limit: got %v", err)
+	}
+	if err := transfer(unlimited, 25); err != nil {
+		t.Fatalf("transfer up to the spend limit failed: %v", err)
+	}
+
+	// Transfers use up the same limit as plain withdrawals.
+	if _, err := withdraw(ctx, s, from, 1); !errors.As(err, &limitErr) || limitErr.Used != 50 {
+		t.Errorf("withdrawal after transfers used the limit: got %v", err)
+	}
+
+	for _, tt := range []struct {
+		balance *domain.Balance
+		want    int
+	}{{from, 150}, {limited, 25}, {unlimited, 25}} {
+		if got := storedBalance(t, s, tt.balance.ID); got != tt.want {
+			t.Errorf("balance is %d, want %d", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPointsLifetimeMonths = 12
+	defaultEventTokensLifetime  = 30 * 24 * time.Hour
+)
+
+func (s *SqlStorage) lotExpiresAt(walletType domain.WalletType, createdAt time.Time) time.Time {
+	if walletType == domain.WalletTypeEventTokens {
+		if s.cfg != nil && s.cfg.Points.EventTokensLifetime > 0 {
+			return createdAt.Add(s.cfg.Points.EventTokensLifetime)
+		}
+		return createdAt.Add(defaultEventTokensLifetime)
+	}
+	if s.cfg != nil && s.cfg.Points.Lifetime > 0 {
+		return createdAt.Add(s.cfg.Points.Lifetime)
+	}
+	return createdAt.AddDate(0, defaultPointsLifetimeMonths, 0)
+}
+
+// The balance row lock held by the caller serialises changes to its lots.
+func (s *SqlStorage) createLot(ctx context.Context, operation *domain.BalanceOperation) error {
+	return s.createLots(ctx, []*domain.BalanceOperation{operation})
+}
+
+func (s *SqlStorage) createLots(ctx context.Context, operations []*domain.BalanceOperation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	ib := sq.Insert("balance_lots").
+		Columns("id", "balance_id", "operation_id", "amount", "remaining", "expires_at", "created_at").
+		PlaceholderFormat(sq.Dollar)
+	for _, operation := range operations {
+		ib = ib.Values(uuid.NewString(), operation.BalanceID, operation.ID, operation.Amount, operation.Amount, s.lotExpiresAt(operation.WalletType, operation.CreatedAt), operation.CreatedAt)
+	}
+
+	query, args := ib.MustSql()
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, query, args...); err != nil {
+		s.logger.Error("failed to create balance lots", zap.Error(err), zap.Int("count", len(operations)))
+		return ErrBalanceInternal
+	}
+
+	return nil
+}
+
+// Transferred points keep the expiry of the lots they came from.
+func (s *SqlStorage) createCarriedLots(ctx context.Context, operation *domain.BalanceOperation, consumed []*consumedLot) error {
+	ib := sq.Insert("balance_lots").
+		Columns("id", "balance_id", "operation_id", "amount", "remaining", "expires_at", "created_at").
+		PlaceholderFormat(sq.Dollar)
+
+	left := operation.Amount
+	for _, lot := range consumed {
+		amount := min(lot.Amount, left)
+		if amount <= 0 {
+			break
+		}
+		ib = ib.Values(uuid.NewString(), operation.BalanceID, operation.ID, amount, amount, lot.ExpiresAt, operation.CreatedAt)
+		left -= amount
+	}
+	if left > 0 {
+		ib = ib.Values(uuid.NewString(), operation.BalanceID, operation.ID, left, left, s.lotExpiresAt(operation.WalletType, operation.CreatedAt), operation.CreatedAt)
+	}
+
+	query, args := ib.MustSql()
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, query, args...); err != nil {
+		s.logger.Error("failed to create carried balance lots", zap.Error(err), zap.String("operation_id", operation.ID))
+		return ErrBalanceInternal
+	}
+
+	return nil
+}
+
+type consumedLot struct {
+	Amount    int       `db:"amount"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+const consumeLotsSQL = `WITH debits AS (
+		SELECT * FROM unnest($1::text[], $2::bigint[]) AS d(balance_id, amount)
+	), ordered AS (
+		SELECT l.id, l.remaining, d.amount,
+			SUM(l.remaining) OVER (PARTITION BY l.balance_id ORDER BY l.expires_at, l.created_at, l.id) - l.remaining AS consumed_before
+		FROM balance_lots l
+		JOIN debits d ON d.balance_id = l.balance_id
+		WHERE l.remaining > 0
+	)
+	UPDATE balance_lots l
+	SET remaining = l.remaining - LEAST(o.remaining, o.amount - o.consumed_before)
+	FROM ordered o
+	WHERE l.id = o.id AND o.consumed_before < o.amount
+	RETURNING LEAST(o.remaining, o.amount - o.consumed_before) AS amount, l.expires_at`
+
+// Lots closest to expiry are consumed first. If they do not cover the amount
+// they are left empty; the balance is the source of truth for what can be spent.
+func (s *SqlStorage) consumeLots(ctx context.Context, balanceID string, amount int) ([]*consumedLot, error) {
+	consumed := make([]*consumedLot, 0, 2)
+	if err := s.trf.Transaction(ctx).SelectContext(ctx, &consumed, consumeLotsSQL, []string{balanceID}, []int64{int64(amount)}); err != nil {
+		s.logger.Error("failed to consume balance lots", zap.Error(err), zap.String("balance_id", balanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	slices.SortFunc(consumed, func(a, b *consumedLot) int {
+		return a.ExpiresAt.Compare(b.ExpiresAt)
+	})
+
+	return consumed, nil
+}
+
+// amounts[i] is taken from balanceIDs[i], which must be distinct.
+func (s *SqlStorage) consumeLotsOf(ctx context.Context, balanceIDs []string, amounts []int64) error {
+	if len(balanceIDs) == 0 {
+		return nil
+	}
+
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, consumeLotsSQL, balanceIDs, amounts); err != nil {
+		s.logger.Error("failed to consume balance lots", zap.Error(err), zap.Strings("balance_ids", balanceIDs))
+		return ErrBalanceInternal
+	}
+
+	return nil
+}
+
+func (s *SqlStorage) GetExpiringPoints(ctx context.Context, balanceID string, until time.Time) ([]*domain.ExpiringPoints, error) {
+	expiring := make([]*domain.ExpiringPoints, 0, 4)
+	err := s.trf.Transaction(ctx).SelectContext(ctx, &expiring,
+		`SELECT SUM(remaining) AS amount, MIN(expires_at) AS expires_at
+		 FROM balance_lots
+		 WHERE balance_id = $1 AND remaining > 0 AND expires_at <= $2
+		 GROUP BY date_trunc('day', expires_at)
+		 ORDER BY 2`,
+		balanceID,
+		until,
+	)
+	if err != nil {
+		s.logger.Error("failed to get expiring points", zap.Error(err), zap.String("balance_id", balanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	return expiring, nil
+}
+
+// Points reserved by active holds do not expire until the hold is captured or
+// released.
+func (s *SqlStorage) ExpirePoints(ctx context.Context, now time.Time, limit int) (int, error) {
+	expired := 0
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		balances := make([]*domain.Balance, 0, limit)
+		err := db.SelectContext(txCtx, &balances,
+			`SELECT b.id, b.user_id, b.wallet_type, b.balance, b.held
+			 FROM balances b
+			 WHERE b.balance > b.held
+			   AND EXISTS (
+				SELECT 1 FROM balance_lots l
+				WHERE l.balance_id = b.id AND l.remaining > 0 AND l.expires_at <= $1
+			   )
+			 ORDER BY b.id
+			 LIMIT $2
+			 FOR UPDATE OF b SKIP LOCKED`,
+			now,
+			limit,
+		)
+		if err != nil {
+			s.logger.Error("failed to select balances with expired lots", zap.Error(err))
+			return ErrBalanceInternal
+		}
+
+		for _, balance := range balances {
+			var amount int
+			err := db.GetContext(txCtx, &amount,
+				"SELECT COALESCE(SUM(remaining), 0) FROM balance_lots WHERE balance_id = $1 AND remaining > 0 AND expires_at <= $2",
+				balance.ID,
+				now,
+			)
+			if err != nil {
+				s.logger.Error("failed to sum expired lots", zap.Error(err), zap.String("balance_id", balance.ID))
+				return ErrBalanceInternal
+			}
+
+			amount = min(amount, balance.Available())
+			if amount <= 0 {
+				continue
+			}
+
+			operation := &domain.BalanceOperation{
+				Amount:      amount,
+				Type:        domain.BalanceOperationTypeExpire,
+				Description: "points expired",
+				ReasonCode:  domain.BalanceOperationReasonExpiry,
+			}
+			if err := s.applyBalanceOperation(txCtx, balance, operation); err != nil {
+				return err
+			}
+		}
+
+		expired = len(balances)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
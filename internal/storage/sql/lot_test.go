@@ -0,0 +1,197 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"testing"
+	"time"
+)
+
+// lotsOf returns the remaining amount of every lot of a balance by the
+// operation that opened it.
+func lotsOf(t *testing.T, s *SqlStorage, balanceID string) map[string]int {
+	t.Helper()
+	ctx := context.Background()
+
+	rows := make([]struct {
+		OperationID string `db:"operation_id"`
+		Remaining   int    `db:"remaining"`
+	}, 0, 4)
+	err := s.trf.Transaction(ctx).SelectContext(ctx, &rows, "SELECT COALESCE(operation_id, '') AS operation_id, remaining FROM balance_lots WHERE balance_id = $1", balanceID)
+	if err != nil {
+		t.Fatalf("failed to get lots: %v", err)
+	}
+
+	lots := make(map[string]int, len(rows))
+	for _, row := range rows {
+		lots[row.OperationID] = row.Remaining
+	}
+
+	return lots
+}
+
+func setLotExpiry(t *testing.T, s *SqlStorage, operationID string, expiresAt time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE balance_lots SET expires_at = $1 WHERE operation_id = $2", expiresAt, operationID); err != nil {
+		t.Fatalf("failed to set lot expiry: %v", err)
+	}
+}
+
+func expireAllPoints(t *testing.T, s *SqlStorage) {
+	t.Helper()
+
+	for range 100 {
+		n, err := s.ExpirePoints(context.Background(), time.Now().UTC(), 100)
+		if err != nil {
+			t.Fatalf("failed to expire points: %v", err)
+		}
+		if n < 100 {
+			return
+		}
+	}
+	t.Fatal("expiry does not terminate")
+}
+
+func TestConsumeLotsFIFO(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	first := deposit(t, s, balance, 30)
+	second := deposit(t, s, balance, 40)
+	third := deposit(t, s, balance, 50)
+
+	// Lots are consumed in expiry order, not in the order they were opened.
+	now := time.Now().UTC()
+	setLotExpiry(t, s, first.ID, now.AddDate(0, 3, 0))
+	setLotExpiry(t, s, second.ID, now.AddDate(0, 1, 0))
+	setLotExpiry(t, s, third.ID, now.AddDate(0, 2, 0))
+
+	_, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+		BalanceID:   balance.ID,
+		Amount:      60,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "lot test",
+	})
+	if err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	lots := lotsOf(t, s, balance.ID)
+	want := map[string]int{first.ID: 30, second.ID: 0, third.ID: 30}
+	for id, remaining := range want {
+		if lots[id] != remaining {
+			t.Errorf("lot of %s has %d left, want %d", id, lots[id], remaining)
+		}
+	}
+
+	expiring, err := s.GetExpiringPoints(ctx, balance.ID, now.AddDate(0, 2, 1))
+	if err != nil {
+		t.Fatalf("failed to get expiring points: %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].Amount != 30 {
+		t.Errorf("expiring points: %+v", expiring)
+	}
+}
+
+func TestTransferCarriesLotExpiry(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	from := newTestBalance(t, s)
+	to := newTestBalance(t, s)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	soon, later := now.AddDate(0, 0, 5), now.AddDate(0, 3, 0)
+	setLotExpiry(t, s, deposit(t, s, from, 30).ID, soon)
+	setLotExpiry(t, s, deposit(t, s, from, 50).ID, later)
+
+	transfer, err := s.CreateTransfer(ctx, &domain.Transfer{
+		Amount:      40,
+		Description: "lot test",
+		FromBalance: &domain.Balance{ID: from.ID},
+		ToBalance:   &domain.Balance{ID: to.ID},
+	})
+	if err != nil {
+		t.Fatalf("failed to transfer: %v", err)
+	}
+
+	// The recipient's points expire when they would have for the sender.
+	lots := make([]struct {
+		OperationID string    `db:"operation_id"`
+		Remaining   int       `db:"remaining"`
+		ExpiresAt   time.Time `db:"expires_at"`
+	}, 0, 2)
+	err = s.trf.Transaction(ctx).SelectContext(ctx, &lots, "SELECT operation_id, remaining, expires_at FROM balance_lots WHERE balance_id = $1 ORDER BY expires_at", to.ID)
+	if err != nil {
+		t.Fatalf("failed to get lots: %v", err)
+	}
+	want := []struct {
+		remaining int
+		expiresAt time.Time
+	}{{30, soon}, {10, later}}
+	if len(lots) != len(want) {
+		t.Fatalf("recipient has %d lots, want %d", len(lots), len(want))
+	}
+	for i, lot := range lots {
+		if lot.OperationID != transfer.ToOperation.ID || lot.Remaining != want[i].remaining || !lot.ExpiresAt.Equal(want[i].expiresAt) {
+			t.Errorf("lot %d is %+v, want %d expiring at %s", i, lot, want[i].remaining, want[i].expiresAt)
+		}
+	}
+
+	remaining := 0
+	for _, lot := range lotsOf(t, s, from.ID) {
+		remaining += lot
+	}
+	if remaining != 40 {
+		t.Errorf("sender lots hold %d, want 40", remaining)
+	}
+}
+
+func TestExpirePoints(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	old := deposit(t, s, balance, 50)
+	recent := deposit(t, s, balance, 30)
+	setLotExpiry(t, s, old.ID, time.Now().UTC().Add(-time.Minute))
+
+	// Held points are not expired while the hold is active, so only the 20
+	// available points of the expired lot go now.
+	hold := createTestHold(t, s, balance, 60, time.Now().Add(time.Hour))
+	expireAllPoints(t, s)
+
+	if got := storedBalance(t, s, balance.ID); got != 60 {
+		t.Errorf("balance is %d after expiring around a hold, want 60", got)
+	}
+	if lots := lotsOf(t, s, balance.ID); lots[old.ID] != 30 || lots[recent.ID] != 30 {
+		t.Errorf("lots after the first expiry: %v", lots)
+	}
+
+	if _, err := s.ReleaseHold(ctx, hold.ID); err != nil {
+		t.Fatalf("failed to release hold: %v", err)
+	}
+	expireAllPoints(t, s)
+
+	if got := storedBalance(t, s, balance.ID); got != 30 {
+		t.Errorf("balance is %d after the expired lot is gone, want 30", got)
+	}
+	if lots := lotsOf(t, s, balance.ID); lots[old.ID] != 0 || lots[recent.ID] != 30 {
+		t.Errorf("lots after the second expiry: %v", lots)
+	}
+
+	var expired int
+	err := s.trf.Transaction(ctx).GetContext(ctx, &expired,
+		"SELECT COALESCE(SUM(amount), 0) FROM balance_operations WHERE balance_id = $1 AND type = $2",
+		balance.ID,
+		domain.BalanceOperationTypeExpire,
+	)
+	if err != nil {
+		t.Fatalf("failed to sum expire operations: %v", err)
+	}
+	if expired != 50 {
+		t.Errorf("expire operations took %d points, want 50", expired)
+	}
+}
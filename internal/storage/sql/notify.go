@@ -0,0 +1,115 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	balanceChangesChannel         = "balance_changes"
+	reputationGroupChangesChannel = "reputation_group_changes"
+)
+
+// Postgres delivers the notification only if the transaction commits.
+func (s *SqlStorage) notifyBalanceChange(ctx context.Context, balance *domain.Balance, operation *domain.BalanceOperation) error {
+	return s.notifyBalanceChanges(ctx, []*domain.BalanceChange{{
+		MaxID:       balance.UserID,
+		BalanceID:   balance.ID,
+		WalletType:  balance.WalletType,
+		Balance:     balance.Balance,
+		Held:        balance.Held,
+		OperationID: operation.ID,
+	}})
+}
+
+func (s *SqlStorage) notifyHoldChange(ctx context.Context, balance *domain.Balance, hold *domain.Hold) error {
+	return s.notifyBalanceChanges(ctx, []*domain.BalanceChange{{
+		MaxID:      balance.UserID,
+		BalanceID:  balance.ID,
+		WalletType: balance.WalletType,
+		Balance:    balance.Balance,
+		Held:       balance.Held,
+		HoldID:     hold.ID,
+	}})
+}
+
+func (s *SqlStorage) notifyBalanceChanges(ctx context.Context, changes []*domain.BalanceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	payloads := make([]string, 0, len(changes))
+	for _, change := range changes {
+		payload, err := json.Marshal(change)
+		if err != nil {
+			s.logger.Error("failed to encode balance change", zap.Error(err), zap.String("operation_id", change.OperationID))
+			return ErrBalanceInternal
+		}
+		payloads = append(payloads, string(payload))
+	}
+
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx, "SELECT pg_notify($1, p) FROM unnest($2::text[]) WITH ORDINALITY AS n(p, i) ORDER BY i", balanceChangesChannel, payloads)
+	if err != nil {
+		s.logger.Error("failed to notify balance changes", zap.Error(err), zap.Int("count", len(changes)))
+		return ErrBalanceInternal
+	}
+
+	return nil
+}
+
+// Changes stay in the history for anyone who was not listening.
+func (s *SqlStorage) notifyReputationGroupChanges(ctx context.Context, changes []*domain.ReputationGroupChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	payloads := make([]string, 0, len(changes))
+	for _, change := range changes {
+		payload, err := json.Marshal(change)
+		if err != nil {
+			s.logger.Error("failed to encode reputation group change", zap.Error(err), zap.String("change_id", change.ID))
+			return ErrReputationInternal
+		}
+		payloads = append(payloads, string(payload))
+	}
+
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx, "SELECT pg_notify($1, p) FROM unnest($2::text[]) WITH ORDINALITY AS n(p, i) ORDER BY i", reputationGroupChangesChannel, payloads)
+	if err != nil {
+		s.logger.Error("failed to notify reputation group changes", zap.Error(err), zap.Int("count", len(changes)))
+		return ErrReputationInternal
+	}
+
+	return nil
+}
+
+// Changes committed while no listener is connected are lost.
+func (s *SqlStorage) ListenBalanceChanges(ctx context.Context, handle func(change *domain.BalanceChange)) error {
+	conn, err := pgx.Connect(ctx, buildDSN(s.cfg))
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+balanceChangesChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var change domain.BalanceChange
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			s.logger.Warn("failed to decode balance change", zap.Error(err), zap.String("payload", notification.Payload))
+			continue
+		}
+
+		handle(&change)
+	}
+}
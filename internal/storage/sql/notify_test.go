@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// listenTestBalance listens for changes of one balance. It returns once the
+// listener is known to receive them.
+func listenTestBalance(t *testing.T, s *SqlStorage, balance *domain.Balance) <-chan *domain.BalanceChange {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changes := make(chan *domain.BalanceChange, 64)
+	go func() {
+		_ = s.ListenBalanceChanges(ctx, func(change *domain.BalanceChange) {
+			if change.BalanceID == balance.ID {
+				changes <- change
+			}
+		})
+	}()
+
+	// Changes committed before LISTEN took effect are lost, so keep
+	// depositing until one arrives and then let the rest drain.
+	for attempt := 0; ; attempt++ {
+		if attempt == 50 {
+			t.Fatal("listener did not receive any balance change")
+		}
+		deposit(t, s, balance, 1)
+		select {
+		case <-changes:
+		case <-time.After(100 * time.Millisecond):
+			continue
+		}
+		break
+	}
+	for {
+		select {
+		case <-changes:
+		case <-time.After(200 * time.Millisecond):
+			return changes
+		}
+	}
+}
+
+func nextBalanceChange(t *testing.T, changes <-chan *domain.BalanceChange) *domain.BalanceChange {
+	t.Helper()
+
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(5 * time.Second):
+		t.Fatal("no balance change received")
+		return nil
+	}
+}
+
+func TestListenBalanceChanges(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	changes := listenTestBalance(t, s, balance)
+
+	credited := deposit(t, s, balance, 10)
+	change := nextBalanceChange(t, changes)
+	if change.OperationID != credited.ID || change.Balance != storedBalance(t, s, balance.ID) {
+		t.Errorf("deposit notified %+v", change)
+	}
+
+	// A rolled back operation notifies nobody.
+	if _, err := withdraw(ctx, s, balance, 1000); !errors.Is(err, ErrBalanceNotEnough) {
+		t.Fatalf("expected ErrBalanceNotEnough, got %v", err)
+	}
+	debited, err := withdraw(ctx, s, balance, 5)
+	if err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+	change = nextBalanceChange(t, changes)
+	if change.OperationID != debited.ID || change.Balance != storedBalance(t, s, balance.ID) {
+		t.Errorf("withdrawal notified %+v", change)
+	}
+}
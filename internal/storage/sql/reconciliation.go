@@ -0,0 +1,108 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Matching balances are returned too, so the caller can page on the id.
+func (s *SqlStorage) GetBalanceReconciliationBatch(ctx context.Context, afterBalanceID string, limit int) ([]*domain.BalanceMismatch, error) {
+	rows := make([]*domain.BalanceMismatch, 0, limit)
+	err := s.trf.Transaction(ctx).SelectContext(ctx, &rows,
+		`SELECT b.id AS balance_id, b.user_id, b.balance AS actual, COALESCE(SUM(`+signedAmountSQL+`), 0) AS expected
+		 FROM (SELECT id, user_id, balance FROM balances WHERE id > $1 ORDER BY id LIMIT $2) b
+		 LEFT JOIN balance_operations bo ON bo.balance_id = b.id
+		 GROUP BY b.id, b.user_id, b.balance
+		 ORDER BY b.id`,
+		afterBalanceID,
+		limit,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance reconciliation batch", zap.Error(err), zap.String("after_balance_id", afterBalanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	return rows, nil
+}
+
+// The balance is what the user has been shown, so it stays as is and the
+// adjustment brings the log and the lots in line with it instead. The drift
+// is measured again under the row lock.
+
+func (s *SqlStorage) CreateReconciliationAdjustment(ctx context.Context, balanceID string, description string) (*domain.BalanceOperation, error) {
+	var adjustment *domain.BalanceOperation
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		balance, err := s.lockBalance(txCtx, balanceID)
+		if err != nil {
+			return err
+		}
+
+		var expected int
+		err = s.trf.Transaction(txCtx).GetContext(txCtx, &expected,
+			"SELECT COALESCE(SUM("+signedAmountSQL+"), 0) FROM balance_operations bo WHERE bo.balance_id = $1",
+			balanceID,
+		)
+		if err != nil {
+			s.logger.Error("failed to sum balance operations", zap.Error(err), zap.String("balance_id", balanceID))
+			return ErrBalanceInternal
+		}
+
+		if balance.Balance == expected {
+			return nil
+		}
+
+		adjustment = &domain.BalanceOperation{
+			BalanceID:   balance.ID,
+			WalletType:  balance.WalletType,
+			Amount:      balance.Balance - expected,
+			Type:        domain.BalanceOperationTypeAdjustment,
+			Description: description,
+			ReasonCode:  domain.BalanceOperationReasonAdjustment,
+		}
+		if err := s.insertBalanceOperation(txCtx, adjustment); err != nil {
+			return err
+		}
+
+		if !balance.WalletType.Expires() {
+			return nil
+		}
+		if adjustment.Amount > 0 {
+			return s.createLot(txCtx, adjustment)
+		}
+		_, err = s.consumeLots(txCtx, balance.ID, -adjustment.Amount)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// The constraints are added NOT VALID so drifted balances do not block
+// migrations. Balances still violating them are returned instead.
+
+func (s *SqlStorage) ValidateBalanceNonNegative(ctx context.Context) ([]string, error) {
+	db := s.trf.Transaction(ctx)
+
+	negative := make([]string, 0)
+	if err := db.SelectContext(ctx, &negative, "SELECT id FROM balances WHERE balance < 0 OR held < 0 OR held > balance ORDER BY id"); err != nil {
+		s.logger.Error("failed to get negative balances", zap.Error(err))
+		return nil, ErrBalanceInternal
+	}
+	if len(negative) > 0 {
+		return negative, nil
+	}
+
+	for _, constraint := range []string{"balances_balance_non_negative", "balances_held_within_balance"} {
+		if _, err := db.ExecContext(ctx, "ALTER TABLE balances VALIDATE CONSTRAINT "+constraint); err != nil {
+			s.logger.Error("failed to validate balance constraint", zap.Error(err), zap.String("constraint", constraint))
+			return nil, ErrBalanceInternal
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"testing"
+)
+
+// reconcileTestBalance returns the reconciliation row of a single balance.
+func reconcileTestBalance(t *testing.T, s *SqlStorage, balanceID string) *domain.BalanceMismatch {
+	t.Helper()
+	ctx := context.Background()
+
+	var after string
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &after, "SELECT COALESCE(MAX(id), '') FROM balances WHERE id < $1", balanceID); err != nil {
+		t.Fatalf("failed to get preceding balance id: %v", err)
+	}
+
+	rows, err := s.GetBalanceReconciliationBatch(ctx, after, 1)
+	if err != nil {
+		t.Fatalf("failed to get reconciliation batch: %v", err)
+	}
+	if len(rows) != 1 || rows[0].BalanceID != balanceID {
+		t.Fatalf("reconciliation batch after %q does not start with %s", after, balanceID)
+	}
+
+	return rows[0]
+}
+
+func TestCreateReconciliationAdjustment(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+
+	for _, drift := range []int{7, -7} {
+		balance := newTestBalance(t, s)
+		deposit(t, s, balance, 100)
+
+		_, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE balances SET balance = balance + $1 WHERE id = $2", drift, balance.ID)
+		if err != nil {
+			t.Fatalf("failed to make the balance drift: %v", err)
+		}
+
+		row := reconcileTestBalance(t, s, balance.ID)
+		if row.Actual != 100+drift || row.Expected != 100 {
+			t.Errorf("drift %d: reconciliation reports actual %d, expected %d", drift, row.Actual, row.Expected)
+		}
+
+		adjustment, err := s.CreateReconciliationAdjustment(ctx, balance.ID, "reconciliation test")
+		if err != nil {
+			t.Fatalf("drift %d: failed to adjust: %v", drift, err)
+		}
+		if adjustment == nil || adjustment.Amount != drift || adjustment.Type != domain.BalanceOperationTypeAdjustment {
+			t.Fatalf("drift %d: unexpected adjustment %+v", drift, adjustment)
+		}
+		if got := storedBalance(t, s, balance.ID); got != 100+drift {
+			t.Errorf("drift %d: adjustment changed the balance to %d", drift, got)
+		}
+
+		row = reconcileTestBalance(t, s, balance.ID)
+		if row.Actual != row.Expected {
+			t.Errorf("drift %d: still off after the adjustment: actual %d, expected %d", drift, row.Actual, row.Expected)
+		}
+		remaining := 0
+		for _, lot := range lotsOf(t, s, balance.ID) {
+			remaining += lot
+		}
+		if remaining != 100+drift {
+			t.Errorf("drift %d: lots hold %d after the adjustment, want %d", drift, remaining, 100+drift)
+		}
+
+		// A second run finds nothing left to adjust.
+		adjustment, err = s.CreateReconciliationAdjustment(ctx, balance.ID, "reconciliation test")
+		if err != nil || adjustment != nil {
+			t.Errorf("drift %d: second adjustment %+v, err %v", drift, adjustment, err)
+		}
+
+		trialBalance, err := s.GetTrialBalance(ctx)
+		if err != nil {
+			t.Fatalf("failed to get trial balance: %v", err)
+		}
+		if !trialBalance.Balanced() {
+			t.Errorf("drift %d: trial balance is off after the adjustment", drift)
+		}
+	}
+}
@@ -0,0 +1,534 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+func (s *SqlStorage) AddReputationEvent(ctx context.Context, event *domain.ReputationEvent) (*domain.ReputationEvent, error) {
+	if event == nil || event.MaxID == "" || event.Amount == 0 {
+		return nil, ErrReputationInvalid
+	}
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		return s.addReputationEvent(txCtx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// The operation is the event's reference.
+func reputationEventFor(balance *domain.Balance, operation *domain.BalanceOperation) *domain.ReputationEvent {
+	switch {
+	case operation.Type == domain.BalanceOperationTypeAdjustment && operation.CountsTowardsReputation:
+		return &domain.ReputationEvent{
+			MaxID:       balance.UserID,
+			Amount:      operation.Amount,
+			Reason:      domain.ReputationEventReasonAdjustment,
+			Description: operation.Justification,
+			ReferenceID: operation.ID,
+		}
+	case operation.Type == domain.BalanceOperationTypeDeposit && balance.WalletType.FeedsReputationScore():
+		reason := domain.ReputationEventReasonOther
+		switch operation.ReasonCode {
+		case domain.BalanceOperationReasonTaskReward:
+			reason = domain.ReputationEventReasonTaskCompleted
+		case domain.BalanceOperationReasonEventBonus:
+			reason = domain.ReputationEventReasonEventParticipation
+		}
+		return &domain.ReputationEvent{
+			MaxID:       balance.UserID,
+			Amount:      operation.Amount,
+			Reason:      reason,
+			Description: operation.Description,
+			ReferenceID: operation.ID,
+		}
+	default:
+		return nil
+	}
+}
+
+// reverseReputationEvent does nothing if originalID recorded no event.
+func (s *SqlStorage) reverseReputationEvent(ctx context.Context, maxID string, originalID string, reversal *domain.BalanceOperation) error {
+	events := make([]*domain.ReputationEvent, 0, 1)
+	err := s.trf.Transaction(ctx).SelectContext(ctx, &events,
+		"SELECT amount, reason FROM reputation_events WHERE user_id = $1 AND reference_id = $2",
+		maxID,
+		originalID,
+	)
+	if err != nil {
+		s.logger.Error("failed to get reputation event of reversed operation", zap.Error(err), zap.String("operation_id", originalID))
+		return ErrReputationInternal
+	}
+
+	for _, event := range events {
+		err := s.addReputationEvent(ctx, &domain.ReputationEvent{
+			MaxID:       maxID,
+			Amount:      -event.Amount,
+			Reason:      event.Reason,
+			Description: reversal.Description,
+			ReferenceID: reversal.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addReputationEvent runs inside the caller's transaction. The user row is
+// locked first, so concurrent events for one user apply one after the other.
+func (s *SqlStorage) addReputationEvent(ctx context.Context, event *domain.ReputationEvent) error {
+	db := s.trf.Transaction(ctx)
+
+	var current struct {
+		Score   int `db:"reputation"`
+		GroupID int `db:"reputation_group_id"`
+	}
+	if err := db.GetContext(ctx, &current, "SELECT reputation, reputation_group_id FROM users WHERE max_id = $1 FOR UPDATE", event.MaxID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		s.logger.Error("failed to lock user reputation", zap.Error(err), zap.String("max_id", event.MaxID))
+		return ErrReputationInternal
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Reason == "" {
+		event.Reason = domain.ReputationEventReasonOther
+	}
+	event.CreatedAt = time.Now().UTC()
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO reputation_events (id, user_id, amount, reason, description, reference_id, created_at)
+		 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)`,
+		event.ID,
+		event.MaxID,
+		event.Amount,
+		event.Reason,
+		event.Description,
+		event.ReferenceID,
+		event.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgErrUniqueViolation:
+				return ErrReputationEventAlreadyExists
+			case pgErrCheckViolation:
+				return ErrReputationInvalid
+			}
+		}
+		s.logger.Error("failed to insert reputation event", zap.Error(err), zap.String("max_id", event.MaxID))
+		return ErrReputationInternal
+	}
+
+	// Gaining reputation is what counts as activity for decay.
+	if event.Amount > 0 {
+		_, err := db.ExecContext(ctx, "UPDATE users SET reputation_active_at = $1 WHERE max_id = $2", event.CreatedAt, event.MaxID)
+		if err != nil {
+			s.logger.Error("failed to update reputation activity", zap.Error(err), zap.String("max_id", event.MaxID))
+			return ErrReputationInternal
+		}
+	}
+
+	return s.setReputation(ctx, &domain.ReputationGroupChange{
+		MaxID:             event.MaxID,
+		FromGroupID:       current.GroupID,
+		Score:             current.Score + event.Amount,
+		Cause:             reputationChangeCause(event.Reason),
+		ReputationEventID: event.ID,
+		ReferenceID:       event.ReferenceID,
+		CreatedAt:         event.CreatedAt,
+	})
+}
+
+func reputationChangeCause(reason domain.ReputationEventReason) domain.ReputationChangeCause {
+	switch reason {
+	case domain.ReputationEventReasonDecay:
+		return domain.ReputationChangeCauseDecay
+	case domain.ReputationEventReasonAdjustment:
+		return domain.ReputationChangeCauseAdmin
+	default:
+		return domain.ReputationChangeCauseReputationEvent
+	}
+}
+
+// Scores below every threshold fall back to the default group. The move is
+// recorded in the history if the group changes.
+func (s *SqlStorage) setReputation(ctx context.Context, change *domain.ReputationGroupChange) error {
+	err := s.trf.Transaction(ctx).GetContext(ctx, &change.ToGroupID,
+		`UPDATE users
+		 SET reputation = $1,
+			reputation_group_id = COALESCE((
+				SELECT id FROM reputation_groups
+				WHERE reputation_need <= $1
+				ORDER BY reputation_need DESC
+				LIMIT 1
+			), $2),
+			updated_at = $3
+		 WHERE max_id = $4
+		 RETURNING reputation_group_id`,
+		change.Score,
+		domain.DefaultReputationGroupID,
+		change.CreatedAt,
+		change.MaxID,
+	)
+	if err != nil {
+		s.logger.Error("failed to update user reputation", zap.Error(err), zap.String("max_id", change.MaxID))
+		return ErrReputationInternal
+	}
+
+	if change.ToGroupID == change.FromGroupID {
+		return nil
+	}
+	return s.recordReputationGroupChange(ctx, change)
+}
+
+const reputationGroupChangeReturningSQL = `RETURNING id, user_id, from_group_id, from_group_name, to_group_id, to_group_name, promoted, score, cause,
+	COALESCE(reputation_event_id, '') AS reputation_event_id, COALESCE(reference_id, '') AS reference_id, created_at`
+
+// recordReputationGroupChange also queues a notification for change.
+func (s *SqlStorage) recordReputationGroupChange(ctx context.Context, change *domain.ReputationGroupChange) error {
+	if change.ID == "" {
+		change.ID = uuid.NewString()
+	}
+
+	err := s.trf.Transaction(ctx).GetContext(ctx, change,
+		`INSERT INTO user_reputation_history (id, user_id, from_group_id, from_group_name, to_group_id, to_group_name, promoted, score, cause, reputation_event_id, reference_id, created_at)
+		 SELECT $1, $2, f.id, f.name, t.id, t.name, t.reputation_need > f.reputation_need, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9
+		 FROM reputation_groups f, reputation_groups t
+		 WHERE f.id = $3 AND t.id = $4
+		 `+reputationGroupChangeReturningSQL,
+		change.ID,
+		change.MaxID,
+		change.FromGroupID,
+		change.ToGroupID,
+		change.Score,
+		change.Cause,
+		change.ReputationEventID,
+		change.ReferenceID,
+		change.CreatedAt,
+	)
+	if err != nil {
+		s.logger.Error("failed to record reputation group change", zap.Error(err), zap.String("max_id", change.MaxID))
+		return ErrReputationInternal
+	}
+
+	return s.notifyReputationGroupChanges(ctx, []*domain.ReputationGroupChange{change})
+}
+
+// Every decayed user loses at least one point.
+func (s *SqlStorage) DecayReputation(ctx context.Context, now time.Time, inactiveSince time.Time, rate float64, limit int) (int, error) {
+	decayed := 0
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		users := make([]*struct {
+			MaxID string `db:"max_id"`
+			Score int    `db:"reputation"`
+		}, 0, limit)
+		err := db.SelectContext(txCtx, &users,
+			`SELECT max_id, reputation
+			 FROM users
+			 WHERE reputation > 0
+			   AND reputation_active_at <= $1
+			   AND (reputation_decayed_at IS NULL OR reputation_decayed_at <= $1)
+			 ORDER BY max_id
+			 LIMIT $2
+			 FOR UPDATE SKIP LOCKED`,
+			inactiveSince,
+			limit,
+		)
+		if err != nil {
+			s.logger.Error("failed to select users to decay", zap.Error(err))
+			return ErrReputationInternal
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		maxIDs := make([]string, 0, len(users))
+		for _, user := range users {
+			amount := min(max(int(math.Ceil(float64(user.Score)*rate)), 1), user.Score)
+			event := &domain.ReputationEvent{
+				MaxID:       user.MaxID,
+				Amount:      -amount,
+				Reason:      domain.ReputationEventReasonDecay,
+				Description: "reputation decayed after inactivity",
+			}
+			if err := s.addReputationEvent(txCtx, event); err != nil {
+				return err
+			}
+			maxIDs = append(maxIDs, user.MaxID)
+		}
+
+		if _, err := db.ExecContext(txCtx, "UPDATE users SET reputation_decayed_at = $1 WHERE max_id = ANY($2)", now, maxIDs); err != nil {
+			s.logger.Error("failed to mark users decayed", zap.Error(err), zap.Int("count", len(maxIDs)))
+			return ErrReputationInternal
+		}
+
+		decayed = len(users)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return decayed, nil
+}
+
+type RecalculateReputationGroupsResponse struct {
+	Changes   []*domain.ReputationGroupChange `json:"changes"`
+	Scanned   int                             `json:"scanned"`
+	LastMaxID string                          `json:"last_max_id"`
+}
+
+// Users are locked in max_id order.
+func (s *SqlStorage) RecalculateReputationGroups(ctx context.Context, afterMaxID string, limit int, dryRun bool) (*RecalculateReputationGroupsResponse, error) {
+	response := &RecalculateReputationGroupsResponse{}
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		lock := ""
+		if !dryRun {
+			lock = "FOR UPDATE"
+		}
+
+		rows := make([]*struct {
+			domain.ReputationGroupChange
+			Moved bool `db:"moved"`
+		}, 0, limit)
+		err := db.SelectContext(txCtx, &rows,
+			`WITH batch AS (
+				SELECT max_id, reputation, reputation_group_id
+				FROM users
+				WHERE max_id > $1
+				ORDER BY max_id
+				LIMIT $2
+				`+lock+`
+			), target AS (
+				SELECT
+					b.*,
+					COALESCE((
+						SELECT id FROM reputation_groups
+						WHERE reputation_need <= b.reputation
+						ORDER BY reputation_need DESC
+						LIMIT 1
+					), $3) AS target_group_id
+				FROM batch b
+			)
+			SELECT
+				t.max_id AS user_id,
+				t.reputation AS score,
+				f.id AS from_group_id,
+				f.name AS from_group_name,
+				g.id AS to_group_id,
+				g.name AS to_group_name,
+				g.reputation_need > f.reputation_need AS promoted,
+				g.id <> f.id AS moved
+			FROM target t
+			JOIN reputation_groups f ON f.id = t.reputation_group_id
+			JOIN reputation_groups g ON g.id = t.target_group_id
+			ORDER BY t.max_id`,
+			afterMaxID,
+			limit,
+			domain.DefaultReputationGroupID,
+		)
+		if err != nil {
+			s.logger.Error("failed to get reputation recalculation batch", zap.Error(err), zap.String("after_max_id", afterMaxID))
+			return ErrReputationInternal
+		}
+
+		response.Scanned = len(rows)
+		if len(rows) > 0 {
+			response.LastMaxID = rows[len(rows)-1].MaxID
+		}
+
+		now := time.Now().UTC()
+		for _, row := range rows {
+			if !row.Moved {
+				continue
+			}
+			change := &row.ReputationGroupChange
+			change.Cause = domain.ReputationChangeCauseRecalculation
+			change.CreatedAt = now
+			if !dryRun {
+				if err := s.setReputation(txCtx, change); err != nil {
+					return err
+				}
+			}
+			response.Changes = append(response.Changes, change)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *SqlStorage) GetReputation(ctx context.Context, maxID string) (*domain.Reputation, error) {
+	db := s.trf.Transaction(ctx)
+
+	var row struct {
+		Score   int `db:"reputation"`
+		GroupID int `db:"reputation_group_id"`
+	}
+	if err := db.GetContext(ctx, &row, "SELECT reputation, reputation_group_id FROM users WHERE max_id = $1", maxID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		s.logger.Error("failed to get user reputation", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+
+	groups := make([]*domain.ReputationGroup, 0, 2)
+	err := db.SelectContext(ctx, &groups,
+		`(SELECT id, name, description, coefficient, reputation_need FROM reputation_groups WHERE id = $1)
+		 UNION ALL
+		 (SELECT id, name, description, coefficient, reputation_need FROM reputation_groups
+		  WHERE reputation_need > GREATEST($2, (SELECT reputation_need FROM reputation_groups WHERE id = $1))
+		  ORDER BY reputation_need
+		  LIMIT 1)`,
+		row.GroupID,
+		row.Score,
+	)
+	if err != nil {
+		s.logger.Error("failed to get reputation groups for user", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+
+	reputation := &domain.Reputation{MaxID: maxID, Score: row.Score}
+	for _, group := range groups {
+		if group.ID == row.GroupID {
+			reputation.Group = group
+		} else {
+			reputation.NextGroup = group
+		}
+	}
+
+	return reputation, nil
+}
+
+type GetReputationHistoryResponse struct {
+	Changes []*domain.ReputationGroupChange `json:"changes"`
+	Total   int32                           `json:"total"`
+}
+
+// Unknown users are reported as not found rather than as an empty history.
+func (s *SqlStorage) GetReputationHistory(ctx context.Context, maxID string, limit int, offset int) (*GetReputationHistoryResponse, error) {
+	db := s.trf.Transaction(ctx)
+
+	var exists bool
+	if err := db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM users WHERE max_id = $1)", maxID); err != nil {
+		s.logger.Error("failed to check user", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	sb := sq.Select(
+		"id",
+		"user_id",
+		"from_group_id",
+		"from_group_name",
+		"to_group_id",
+		"to_group_name",
+		"promoted",
+		"score",
+		"cause",
+		"COALESCE(reputation_event_id, '') AS reputation_event_id",
+		"COALESCE(reference_id, '') AS reference_id",
+		"created_at",
+	).
+		From("user_reputation_history").
+		Where(sq.Eq{"user_id": maxID}).
+		OrderBy("created_at DESC", "id DESC").
+		PlaceholderFormat(sq.Dollar)
+	if limit > 0 {
+		sb = sb.Limit(uint64(limit))
+	}
+	if offset > 0 {
+		sb = sb.Offset(uint64(offset))
+	}
+
+	query, args := sb.MustSql()
+
+	response := &GetReputationHistoryResponse{Changes: make([]*domain.ReputationGroupChange, 0, limit)}
+	if err := db.SelectContext(ctx, &response.Changes, query, args...); err != nil {
+		s.logger.Error("failed to get reputation history", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+
+	if err := db.GetContext(ctx, &response.Total, "SELECT COUNT(*) FROM user_reputation_history WHERE user_id = $1", maxID); err != nil {
+		s.logger.Error("failed to count reputation history", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+
+	return response, nil
+}
+
+type GetReputationEventsResponse struct {
+	Events []*domain.ReputationEvent `json:"events"`
+	Total  int32                     `json:"total"`
+}
+
+func (s *SqlStorage) GetReputationEvents(ctx context.Context, maxID string, limit int, offset int) (*GetReputationEventsResponse, error) {
+	sb := sq.Select(
+		"id",
+		"user_id",
+		"amount",
+		"reason",
+		"description",
+		"COALESCE(reference_id, '') AS reference_id",
+		"created_at",
+	).
+		From("reputation_events").
+		Where(sq.Eq{"user_id": maxID}).
+		OrderBy("created_at DESC", "id DESC").
+		PlaceholderFormat(sq.Dollar)
+	if limit > 0 {
+		sb = sb.Limit(uint64(limit))
+	}
+	if offset > 0 {
+		sb = sb.Offset(uint64(offset))
+	}
+
+	query, args := sb.MustSql()
+
+	db := s.trf.Transaction(ctx)
+	response := &GetReputationEventsResponse{Events: make([]*domain.ReputationEvent, 0, limit)}
+	if err := db.SelectContext(ctx, &response.Events, query, args...); err != nil {
+		s.logger.Error("failed to get reputation events", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+
+	if err := db.GetContext(ctx, &response.Total, "SELECT COUNT(*) FROM reputation_events WHERE user_id = $1", maxID); err != nil {
+		s.logger.Error("failed to count reputation events", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrReputationInternal
+	}
+
+	return response, nil
+}
@@ -4,8 +4,11 @@ import (
 	"DobrikaDev/user-service/internal/domain"
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 )
 
@@ -47,3 +50,212 @@ func (s *SqlStorage) GetReputationGroupByID(ctx context.Context, id int) (*domai
 
 	return &group, nil
 }
+
+func (s *SqlStorage) GetReputationGroupLimits(ctx context.Context, groupID int) ([]*domain.ReputationGroupLimit, error) {
+	sb := sq.Select("reputation_group_id", "direction", "window_seconds", "max_amount").
+		From("reputation_group_limits").
+		Where(sq.Eq{"reputation_group_id": groupID}).
+		OrderBy("direction", "window_seconds").
+		PlaceholderFormat(sq.Dollar)
+
+	query, args := sb.MustSql()
+
+	limits := make([]*domain.ReputationGroupLimit, 0, 4)
+	if err := s.trf.Transaction(ctx).SelectContext(ctx, &limits, query, args...); err != nil {
+		s.logger.Error("failed to get reputation group limits", zap.Error(err), zap.Int("id", groupID))
+		return nil, ErrReputationGroupInternal
+	}
+
+	return limits, nil
+}
+
+func (s *SqlStorage) SetReputationGroupLimits(ctx context.Context, groupID int, limits []*domain.ReputationGroupLimit) ([]*domain.ReputationGroupLimit, error) {
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		var lockedID int
+		if err := db.GetContext(txCtx, &lockedID, "SELECT id FROM reputation_groups WHERE id = $1 FOR UPDATE", groupID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrReputationGroupNotFound
+			}
+			s.logger.Error("failed to lock reputation group", zap.Error(err), zap.Int("id", groupID))
+			return ErrReputationGroupInternal
+		}
+
+		if _, err := db.ExecContext(txCtx, "DELETE FROM reputation_group_limits WHERE reputation_group_id = $1", groupID); err != nil {
+			s.logger.Error("failed to delete reputation group limits", zap.Error(err), zap.Int("id", groupID))
+			return ErrReputationGroupInternal
+		}
+
+		if len(limits) == 0 {
+			return nil
+		}
+
+		ib := sq.Insert("reputation_group_limits").
+			Columns("reputation_group_id", "direction", "window_seconds", "max_amount").
+			PlaceholderFormat(sq.Dollar)
+		for _, limit := range limits {
+			limit.ReputationGroupID = groupID
+			ib = ib.Values(groupID, limit.Direction, limit.WindowSeconds, limit.MaxAmount)
+		}
+
+		query, args := ib.MustSql()
+		if _, err := db.ExecContext(txCtx, query, args...); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && (pgErr.Code == pgErrUniqueViolation || pgErr.Code == pgErrCheckViolation) {
+				return ErrReputationGroupInvalid
+			}
+			s.logger.Error("failed to insert reputation group limits", zap.Error(err), zap.Int("id", groupID))
+			return ErrReputationGroupInternal
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+func (s *SqlStorage) CreateReputationGroup(ctx context.Context, group *domain.ReputationGroup) (*domain.ReputationGroup, error) {
+	err := s.trf.Transaction(ctx).GetContext(ctx, &group.ID,
+		`INSERT INTO reputation_groups (name, description, coefficient, reputation_need)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id`,
+		group.Name,
+		group.Description,
+		group.Coefficient,
+		group.ReputationNeed,
+	)
+	if err != nil {
+		if err := convertReputationGroupWriteError(err); err != nil {
+			return nil, err
+		}
+		s.logger.Error("failed to create reputation group", zap.Error(err), zap.String("name", group.Name))
+		return nil, ErrReputationGroupInternal
+	}
+
+	return group, nil
+}
+
+func (s *SqlStorage) UpdateReputationGroup(ctx context.Context, group *domain.ReputationGroup) (*domain.ReputationGroup, error) {
+	var id int
+	err := s.trf.Transaction(ctx).GetContext(ctx, &id,
+		`UPDATE reputation_groups
+		 SET name = $1, description = $2, coefficient = $3, reputation_need = $4, updated_at = $5
+		 WHERE id = $6
+		 RETURNING id`,
+		group.Name,
+		group.Description,
+		group.Coefficient,
+		group.ReputationNeed,
+		time.Now().UTC(),
+		group.ID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReputationGroupNotFound
+		}
+		if err := convertReputationGroupWriteError(err); err != nil {
+			return nil, err
+		}
+		s.logger.Error("failed to update reputation group", zap.Error(err), zap.Int("id", group.ID))
+		return nil, ErrReputationGroupInternal
+	}
+
+	return group, nil
+}
+
+// Without moveMembersToID a group that still has members is not deleted.
+// Moves are recorded in the members' reputation history as admin changes.
+func (s *SqlStorage) DeleteReputationGroup(ctx context.Context, id int, moveMembersToID int) (int, error) {
+	var moved int
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		var lockedID int
+		if err := db.GetContext(txCtx, &lockedID, "SELECT id FROM reputation_groups WHERE id = $1 FOR UPDATE", id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrReputationGroupNotFound
+			}
+			s.logger.Error("failed to lock reputation group", zap.Error(err), zap.Int("id", id))
+			return ErrReputationGroupInternal
+		}
+
+		if moveMembersToID != 0 {
+			if err := db.GetContext(txCtx, &lockedID, "SELECT id FROM reputation_groups WHERE id = $1 FOR SHARE", moveMembersToID); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return ErrReputationGroupNotFound
+				}
+				s.logger.Error("failed to lock target reputation group", zap.Error(err), zap.Int("id", moveMembersToID))
+				return ErrReputationGroupInternal
+			}
+
+			now := time.Now().UTC()
+			changes := make([]*domain.ReputationGroupChange, 0)
+			err := db.SelectContext(txCtx, &changes,
+				`WITH moved AS (
+					UPDATE users SET reputation_group_id = $1, updated_at = $2
+					WHERE reputation_group_id = $3
+					RETURNING max_id, reputation
+				)
+				INSERT INTO user_reputation_history (id, user_id, from_group_id, from_group_name, to_group_id, to_group_name, promoted, score, cause, created_at)
+				SELECT gen_random_uuid()::text, moved.max_id, f.id, f.name, t.id, t.name, t.reputation_need > f.reputation_need, moved.reputation, $4, $2
+				FROM moved, reputation_groups f, reputation_groups t
+				WHERE f.id = $3 AND t.id = $1
+				`+reputationGroupChangeReturningSQL,
+				moveMembersToID,
+				now,
+				id,
+				domain.ReputationChangeCauseAdmin,
+			)
+			if err != nil {
+				s.logger.Error("failed to move reputation group members", zap.Error(err), zap.Int("id", id), zap.Int("move_members_to_id", moveMembersToID))
+				return ErrReputationGroupInternal
+			}
+			if err := s.notifyReputationGroupChanges(txCtx, changes); err != nil {
+				return err
+			}
+			moved = len(changes)
+		}
+
+		if _, err := db.ExecContext(txCtx, "DELETE FROM reputation_groups WHERE id = $1", id); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgErrForeignKeyViolation {
+				return ErrReputationGroupInUse
+			}
+			s.logger.Error("failed to delete reputation group", zap.Error(err), zap.Int("id", id))
+			return ErrReputationGroupInternal
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+// convertReputationGroupWriteError returns nil for anything but a constraint
+// violation.
+func convertReputationGroupWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	switch pgErr.Code {
+	case pgErrUniqueViolation:
+		if pgErr.ConstraintName == "reputation_groups_name_key" {
+			return ErrReputationGroupAlreadyExists
+		}
+		return ErrReputationGroupInvalid
+	case pgErrCheckViolation:
+		return ErrReputationGroupInvalid
+	default:
+		return nil
+	}
+}
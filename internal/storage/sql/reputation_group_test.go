@@ -0,0 +1,108 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestReputationGroupWrites(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+
+	group, err := s.GetReputationGroupByID(ctx, newTestReputationGroup(t, s))
+	if err != nil {
+		t.Fatalf("failed to get reputation group: %v", err)
+	}
+
+	for name, invalid := range map[string]domain.ReputationGroup{
+		"duplicate threshold": {Name: group.Name + "-other", Coefficient: 1, ReputationNeed: group.ReputationNeed},
+		"zero coefficient":    {Name: group.Name + "-other", Coefficient: 0, ReputationNeed: group.ReputationNeed + 1},
+		"negative threshold":  {Name: group.Name + "-other", Coefficient: 1, ReputationNeed: -1},
+	} {
+		if _, err := s.CreateReputationGroup(ctx, &invalid); !errors.Is(err, ErrReputationGroupInvalid) {
+			t.Errorf("%s: expected ErrReputationGroupInvalid, got %v", name, err)
+		}
+	}
+	duplicate := *group
+	duplicate.ReputationNeed++
+	if _, err := s.CreateReputationGroup(ctx, &duplicate); !errors.Is(err, ErrReputationGroupAlreadyExists) {
+		t.Errorf("duplicate name: expected ErrReputationGroupAlreadyExists, got %v", err)
+	}
+
+	group.Coefficient = 1.5
+	if _, err := s.UpdateReputationGroup(ctx, group); err != nil {
+		t.Fatalf("failed to update reputation group: %v", err)
+	}
+	updated, err := s.GetReputationGroupByID(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("failed to get reputation group: %v", err)
+	}
+	if updated.Coefficient != 1.5 {
+		t.Errorf("coefficient is %v after update, want 1.5", updated.Coefficient)
+	}
+
+	missing := *group
+	missing.ID = -1
+	if _, err := s.UpdateReputationGroup(ctx, &missing); !errors.Is(err, ErrReputationGroupNotFound) {
+		t.Errorf("updating a missing group: expected ErrReputationGroupNotFound, got %v", err)
+	}
+}
+
+func TestDeleteReputationGroup(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+
+	groupID := newTestReputationGroup(t, s)
+	targetID := newTestReputationGroup(t, s)
+	members := []*domain.Balance{newTestBalance(t, s), newTestBalance(t, s)}
+	for _, member := range members {
+		moveToReputationGroup(t, s, member.UserID, groupID)
+	}
+	_, err := s.SetReputationGroupLimits(ctx, groupID, []*domain.ReputationGroupLimit{
+		{Direction: domain.LimitDirectionEarn, WindowSeconds: 3600, MaxAmount: 10},
+	})
+	if err != nil {
+		t.Fatalf("failed to set limits: %v", err)
+	}
+
+	if _, err := s.DeleteReputationGroup(ctx, groupID, 0); !errors.Is(err, ErrReputationGroupInUse) {
+		t.Errorf("deleting a group with members: expected ErrReputationGroupInUse, got %v", err)
+	}
+	if _, err := s.DeleteReputationGroup(ctx, groupID, -1); !errors.Is(err, ErrReputationGroupNotFound) {
+		t.Errorf("moving members to a missing group: expected ErrReputationGroupNotFound, got %v", err)
+	}
+	if _, err := s.GetReputationGroupByID(ctx, groupID); err != nil {
+		t.Fatalf("group is gone after failed deletes: %v", err)
+	}
+
+	moved, err := s.DeleteReputationGroup(ctx, groupID, targetID)
+	if err != nil {
+		t.Fatalf("failed to delete reputation group: %v", err)
+	}
+	if moved != len(members) {
+		t.Errorf("moved %d members, want %d", moved, len(members))
+	}
+	for _, member := range members {
+		var memberGroupID int
+		if err := s.trf.Transaction(ctx).GetContext(ctx, &memberGroupID, "SELECT reputation_group_id FROM users WHERE max_id = $1", member.UserID); err != nil {
+			t.Fatalf("failed to get member group: %v", err)
+		}
+		if memberGroupID != targetID {
+			t.Errorf("member is in group %d, want %d", memberGroupID, targetID)
+		}
+	}
+
+	var limits int
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &limits, "SELECT COUNT(*) FROM reputation_group_limits WHERE reputation_group_id = $1", groupID); err != nil {
+		t.Fatalf("failed to count limits: %v", err)
+	}
+	if limits != 0 {
+		t.Errorf("%d limits survived the group", limits)
+	}
+
+	if _, err := s.DeleteReputationGroup(ctx, groupID, targetID); !errors.Is(err, ErrReputationGroupNotFound) {
+		t.Errorf("deleting twice: expected ErrReputationGroupNotFound, got %v", err)
+	}
+}
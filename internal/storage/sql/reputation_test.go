@@ -0,0 +1,387 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func reputationScore(t *testing.T, s *SqlStorage, maxID string) int {
+	t.Helper()
+
+	reputation, err := s.GetReputation(context.Background(), maxID)
+	if err != nil {
+		t.Fatalf("failed to get reputation: %v", err)
+	}
+
+	return reputation.Score
+}
+
+func TestAddReputationEvent(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	groupID := newTestReputationGroup(t, s)
+	group, err := s.GetReputationGroupByID(ctx, groupID)
+	if err != nil {
+		t.Fatalf("failed to get reputation group: %v", err)
+	}
+
+	event := &domain.ReputationEvent{
+		MaxID:       balance.UserID,
+		Amount:      group.ReputationNeed,
+		Reason:      domain.ReputationEventReasonTaskCompleted,
+		ReferenceID: "task-1",
+	}
+	if _, err := s.AddReputationEvent(ctx, event); err != nil {
+		t.Fatalf("failed to add reputation event: %v", err)
+	}
+	reputation, err := s.GetReputation(ctx, balance.UserID)
+	if err != nil {
+		t.Fatalf("failed to get reputation: %v", err)
+	}
+	if reputation.Score != group.ReputationNeed || reputation.Group == nil || reputation.Group.ID != groupID {
+		t.Errorf("reputation is %+v after reaching group %d", reputation, groupID)
+	}
+
+	// Rewards are idempotent per reason and reference.
+	again := *event
+	again.ID = ""
+	if _, err := s.AddReputationEvent(ctx, &again); !errors.Is(err, ErrReputationEventAlreadyExists) {
+		t.Errorf("repeated reference: expected ErrReputationEventAlreadyExists, got %v", err)
+	}
+
+	_, err = s.AddReputationEvent(ctx, &domain.ReputationEvent{
+		MaxID:  balance.UserID,
+		Amount: -1,
+		Reason: domain.ReputationEventReasonModerationPenalty,
+	})
+	if err != nil {
+		t.Fatalf("failed to add penalty: %v", err)
+	}
+	reputation, err = s.GetReputation(ctx, balance.UserID)
+	if err != nil {
+		t.Fatalf("failed to get reputation: %v", err)
+	}
+	if reputation.Score != group.ReputationNeed-1 || reputation.Group == nil || reputation.Group.ID == groupID {
+		t.Errorf("reputation is %+v after falling below group %d", reputation, groupID)
+	}
+	if reputation.NextGroup == nil || reputation.NextGroup.ID != groupID {
+		t.Errorf("next group is %+v, want %d", reputation.NextGroup, groupID)
+	}
+
+	for _, invalid := range []*domain.ReputationEvent{
+		{MaxID: balance.UserID},
+		{Amount: 1},
+	} {
+		if _, err := s.AddReputationEvent(ctx, invalid); !errors.Is(err, ErrReputationInvalid) {
+			t.Errorf("event %+v: expected ErrReputationInvalid, got %v", invalid, err)
+		}
+	}
+	if _, err := s.AddReputationEvent(ctx, &domain.ReputationEvent{MaxID: "test-missing", Amount: 1}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing user: expected ErrUserNotFound, got %v", err)
+	}
+
+	response, err := s.GetReputationEvents(ctx, balance.UserID, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get reputation events: %v", err)
+	}
+	if response.Total != 2 || response.Events[0].Amount != -1 || response.Events[1].ReferenceID != "task-1" {
+		t.Errorf("events are %+v", response.Events)
+	}
+}
+
+func TestBalanceOperationReputationEvents(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	points := newTestBalance(t, s)
+	karma, err := s.GetBalance(ctx, points.UserID, domain.WalletTypeKarma)
+	if err != nil {
+		t.Fatalf("failed to get karma wallet: %v", err)
+	}
+	admin := newTestBalance(t, s)
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE users SET role = $1 WHERE max_id = $2", domain.UserRoleAdmin, admin.UserID); err != nil {
+		t.Fatalf("failed to make user an admin: %v", err)
+	}
+
+	// Points are spendable currency and leave the score alone.
+	deposit(t, s, points, 100)
+	if got := reputationScore(t, s, points.UserID); got != 0 {
+		t.Errorf("score is %d after a points deposit, want 0", got)
+	}
+
+	earned := deposit(t, s, karma, 40)
+	adjust := func(countsTowardsReputation bool) *domain.BalanceOperation {
+		t.Helper()
+		operation, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+			BalanceID:               points.ID,
+			Amount:                  15,
+			Type:                    domain.BalanceOperationTypeAdjustment,
+			Description:             "reputation test",
+			ActorMaxID:              admin.UserID,
+			Justification:           "support ticket",
+			CountsTowardsReputation: countsTowardsReputation,
+		})
+		if err != nil {
+			t.Fatalf("failed to adjust: %v", err)
+		}
+		return operation
+	}
+	adjust(false)
+	flagged := adjust(true)
+	if got := reputationScore(t, s, points.UserID); got != 55 {
+		t.Errorf("score is %d after karma and a flagged adjustment, want 55", got)
+	}
+
+	if _, err := s.ReverseBalanceOperation(ctx, earned.ID, "reputation test"); err != nil {
+		t.Fatalf("failed to reverse karma deposit: %v", err)
+	}
+	if got := reputationScore(t, s, points.UserID); got != 15 {
+		t.Errorf("score is %d after reversing the karma deposit, want 15", got)
+	}
+
+	response, err := s.GetReputationEvents(ctx, points.UserID, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get reputation events: %v", err)
+	}
+	if response.Total != 3 {
+		t.Fatalf("got %d reputation events, want 3", response.Total)
+	}
+	for i, want := range []struct {
+		amount    int
+		reference string
+	}{{-40, ""}, {15, flagged.ID}, {40, earned.ID}} {
+		event := response.Events[i]
+		if event.Amount != want.amount || (want.reference != "" && event.ReferenceID != want.reference) {
+			t.Errorf("event %d is %+v, want amount %d", i, event, want.amount)
+		}
+	}
+}
+
+func TestDecayReputation(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+
+	groupID := newTestReputationGroup(t, s)
+	group, err := s.GetReputationGroupByID(ctx, groupID)
+	if err != nil {
+		t.Fatalf("failed to get reputation group: %v", err)
+	}
+
+	now := time.Now().UTC()
+	inactiveSince := now.Add(-30 * 24 * time.Hour)
+	users := map[string]struct {
+		score    int
+		inactive bool
+		want     int
+	}{
+		newTestBalance(t, s).UserID: {group.ReputationNeed, true, group.ReputationNeed - int(math.Ceil(float64(group.ReputationNeed)*0.1))},
+		newTestBalance(t, s).UserID: {3, true, 2},
+		newTestBalance(t, s).UserID: {100, false, 100},
+	}
+	for maxID, user := range users {
+		_, err := s.AddReputationEvent(ctx, &domain.ReputationEvent{MaxID: maxID, Amount: user.score})
+		if err != nil {
+			t.Fatalf("failed to add reputation event: %v", err)
+		}
+		if user.inactive {
+			_, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE users SET reputation_active_at = $1 WHERE max_id = $2", inactiveSince.Add(-time.Hour), maxID)
+			if err != nil {
+				t.Fatalf("failed to backdate activity: %v", err)
+			}
+		}
+	}
+
+	// A second run in the same inactive period leaves everyone alone.
+	for range 2 {
+		for {
+			decayed, err := s.DecayReputation(ctx, now, inactiveSince, 0.1, 100)
+			if err != nil {
+				t.Fatalf("failed to decay reputation: %v", err)
+			}
+			if decayed == 0 {
+				break
+			}
+		}
+	}
+
+	for maxID, user := range users {
+		if got := reputationScore(t, s, maxID); got != user.want {
+			t.Errorf("score of %d decayed to %d, want %d", user.score, got, user.want)
+		}
+	}
+
+	for maxID, user := range users {
+		if user.score != group.ReputationNeed {
+			continue
+		}
+
+		history, err := s.GetReputationHistory(ctx, maxID, 1, 0)
+		if err != nil {
+			t.Fatalf("failed to get reputation history: %v", err)
+		}
+		if len(history.Changes) != 1 {
+			t.Fatalf("got %d group changes, want 1", len(history.Changes))
+		}
+		if change := history.Changes[0]; change.FromGroupID != groupID || change.Promoted || change.Cause != domain.ReputationChangeCauseDecay {
+			t.Errorf("demotion is %+v", change)
+		}
+	}
+}
+
+func TestGetReputationHistory(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	groupID := newTestReputationGroup(t, s)
+	group, err := s.GetReputationGroupByID(ctx, groupID)
+	if err != nil {
+		t.Fatalf("failed to get reputation group: %v", err)
+	}
+
+	promotion := &domain.ReputationEvent{
+		MaxID:       balance.UserID,
+		Amount:      group.ReputationNeed,
+		Reason:      domain.ReputationEventReasonTaskCompleted,
+		ReferenceID: "task-1",
+	}
+	if _, err := s.AddReputationEvent(ctx, promotion); err != nil {
+		t.Fatalf("failed to add reputation event: %v", err)
+	}
+	// Events that keep the user in their group leave no trace in the history.
+	if _, err := s.AddReputationEvent(ctx, &domain.ReputationEvent{MaxID: balance.UserID, Amount: 1}); err != nil {
+		t.Fatalf("failed to add reputation event: %v", err)
+	}
+	if _, err := s.DeleteReputationGroup(ctx, groupID, domain.DefaultReputationGroupID); err != nil {
+		t.Fatalf("failed to delete reputation group: %v", err)
+	}
+
+	history, err := s.GetReputationHistory(ctx, balance.UserID, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get reputation history: %v", err)
+	}
+	if history.Total != 2 || len(history.Changes) != 2 {
+		t.Fatalf("got %d group changes, want 2", history.Total)
+	}
+
+	moved, promoted := history.Changes[0], history.Changes[1]
+	if !promoted.Promoted || promoted.ToGroupID != groupID || promoted.ToGroupName != group.Name ||
+		promoted.Cause != domain.ReputationChangeCauseReputationEvent || promoted.ReputationEventID != promotion.ID || promoted.ReferenceID != "task-1" {
+		t.Errorf("promotion is %+v", promoted)
+	}
+	// The deleted group's name survives in the history.
+	if moved.Promoted || moved.FromGroupID != groupID || moved.FromGroupName != group.Name ||
+		moved.ToGroupID != domain.DefaultReputationGroupID || moved.Cause != domain.ReputationChangeCauseAdmin || moved.ReputationEventID != "" {
+		t.Errorf("move out of the deleted group is %+v", moved)
+	}
+
+	if _, err := s.GetReputationHistory(ctx, "test-missing", 0, 0); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("missing user: expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestRecalculateReputationGroups(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	db := s.trf.Transaction(ctx)
+
+	groupID := newTestReputationGroup(t, s)
+	group, err := s.GetReputationGroupByID(ctx, groupID)
+	if err != nil {
+		t.Fatalf("failed to get reputation group: %v", err)
+	}
+
+	// Users a and c sit in the wrong group, as if thresholds had changed.
+	prefix := "test-" + uuid.NewString()
+	placement := []struct {
+		score   int
+		groupID int
+	}{
+		{group.ReputationNeed, domain.DefaultReputationGroupID},
+		{0, domain.DefaultReputationGroupID},
+		{0, groupID},
+	}
+	maxIDs := make([]string, 0, len(placement))
+	for i, p := range placement {
+		maxID := prefix + "-" + string(rune('a'+i))
+		_, err := s.CreateUser(ctx, &domain.User{
+			MaxID:  maxID,
+			Name:   t.Name(),
+			Sex:    domain.SexUnknown,
+			Role:   domain.UserRoleUser,
+			Status: domain.UserStatusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		if _, err := db.ExecContext(ctx, "UPDATE users SET reputation = $1, reputation_group_id = $2 WHERE max_id = $3", p.score, p.groupID, maxID); err != nil {
+			t.Fatalf("failed to place user: %v", err)
+		}
+		maxIDs = append(maxIDs, maxID)
+	}
+	groupOf := func(maxID string) int {
+		t.Helper()
+		var id int
+		if err := db.GetContext(ctx, &id, "SELECT reputation_group_id FROM users WHERE max_id = $1", maxID); err != nil {
+			t.Fatalf("failed to get group: %v", err)
+		}
+		return id
+	}
+
+	dryRun, err := s.RecalculateReputationGroups(ctx, prefix, 2, true)
+	if err != nil {
+		t.Fatalf("failed to recalculate: %v", err)
+	}
+	if dryRun.Scanned != 2 || dryRun.LastMaxID != maxIDs[1] || len(dryRun.Changes) != 1 {
+		t.Fatalf("dry run scanned %d up to %q with %d changes", dryRun.Scanned, dryRun.LastMaxID, len(dryRun.Changes))
+	}
+	if change := dryRun.Changes[0]; change.MaxID != maxIDs[0] || change.ToGroupID != groupID || !change.Promoted {
+		t.Errorf("dry run change is %+v", change)
+	}
+	if got := groupOf(maxIDs[0]); got != domain.DefaultReputationGroupID {
+		t.Errorf("dry run moved user to group %d", got)
+	}
+
+	// A run stopped after the first batch picks up where it left off.
+	first, err := s.RecalculateReputationGroups(ctx, prefix, 2, false)
+	if err != nil {
+		t.Fatalf("failed to recalculate: %v", err)
+	}
+	second, err := s.RecalculateReputationGroups(ctx, first.LastMaxID, 1, false)
+	if err != nil {
+		t.Fatalf("failed to resume recalculation: %v", err)
+	}
+	if len(first.Changes) != 1 || second.LastMaxID != maxIDs[2] || len(second.Changes) != 1 {
+		t.Fatalf("batches made %d and %d changes up to %q", len(first.Changes), len(second.Changes), second.LastMaxID)
+	}
+	for i, want := range []int{groupID, domain.DefaultReputationGroupID, domain.DefaultReputationGroupID} {
+		if got := groupOf(maxIDs[i]); got != want {
+			t.Errorf("user %d is in group %d, want %d", i, got, want)
+		}
+	}
+
+	for _, maxID := range []string{maxIDs[0], maxIDs[2]} {
+		history, err := s.GetReputationHistory(ctx, maxID, 0, 0)
+		if err != nil {
+			t.Fatalf("failed to get reputation history: %v", err)
+		}
+		if history.Total != 1 || history.Changes[0].Cause != domain.ReputationChangeCauseRecalculation {
+			t.Errorf("history is %+v", history.Changes)
+		}
+	}
+
+	again, err := s.RecalculateReputationGroups(ctx, prefix, len(maxIDs), false)
+	if err != nil {
+		t.Fatalf("failed to recalculate: %v", err)
+	}
+	if len(again.Changes) != 0 {
+		t.Errorf("second pass made %d changes", len(again.Changes))
+	}
+}
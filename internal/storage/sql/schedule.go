@@ -0,0 +1,321 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// selectScheduledOperations selects scheduled operations aliased as so.
+func selectScheduledOperations() sq.SelectBuilder {
+	return sq.Select(
+		"so.id",
+		"so.user_id",
+		"so.wallet_type",
+		"so.amount",
+		"so.type",
+		"so.description",
+		"so.reason_code",
+		"so.metadata",
+		"COALESCE(so.cron_expression, '') AS cron_expression",
+		"so.status",
+		"so.next_run_at",
+		"so.last_run_at",
+		"so.created_at",
+		"so.updated_at",
+		"COALESCE(r.operation_id, '') AS last_operation_id",
+		"COALESCE(r.error, '') AS last_error",
+	).
+		From("scheduled_operations so").
+		JoinClause(`LEFT JOIN LATERAL (
+			SELECT operation_id, error FROM scheduled_operation_runs
+			WHERE scheduled_operation_id = so.id
+			ORDER BY scheduled_for DESC
+			LIMIT 1
+		) r ON true`).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *SqlStorage) CreateScheduledOperation(ctx context.Context, operation *domain.ScheduledOperation) (*domain.ScheduledOperation, error) {
+	if operation == nil || operation.NextRunAt == nil {
+		return nil, ErrBalanceInvalid
+	}
+
+	if operation.ID == "" {
+		operation.ID = uuid.NewString()
+	}
+	if operation.ReasonCode == "" {
+		operation.ReasonCode = domain.BalanceOperationReasonOther
+	}
+	now := time.Now().UTC()
+	operation.Status = domain.ScheduledOperationStatusActive
+	operation.CreatedAt = now
+	operation.UpdatedAt = now
+
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		`INSERT INTO scheduled_operations (id, user_id, wallet_type, amount, type, description, reason_code, metadata, cron_expression, status, next_run_at, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $12)`,
+		operation.ID,
+		operation.MaxID,
+		operation.WalletType,
+		operation.Amount,
+		operation.Type,
+		operation.Description,
+		operation.ReasonCode,
+		operation.Metadata,
+		operation.CronExpression,
+		operation.Status,
+		operation.NextRunAt,
+		now,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgErrForeignKeyViolation:
+				return nil, ErrBalanceNotFound
+			case pgErrCheckViolation:
+				return nil, ErrBalanceInvalid
+			}
+		}
+		s.logger.Error("failed to insert scheduled operation", zap.Error(err), zap.String("max_id", operation.MaxID))
+		return nil, ErrBalanceInternal
+	}
+
+	return operation, nil
+}
+
+type GetScheduledOperationsResponse struct {
+	Operations []*domain.ScheduledOperation `json:"operations"`
+	Total      int32                        `json:"total"`
+}
+
+// An empty maxID lists the operations of every user.
+func (s *SqlStorage) GetScheduledOperations(ctx context.Context, maxID string, statuses []domain.ScheduledOperationStatus, limit int, offset int) (*GetScheduledOperationsResponse, error) {
+	filter := sq.And{}
+	if maxID != "" {
+		filter = append(filter, sq.Eq{"so.user_id": maxID})
+	}
+	if len(statuses) > 0 {
+		values := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, status.String())
+		}
+		filter = append(filter, sq.Eq{"so.status": values})
+	}
+
+	sb := selectScheduledOperations().
+		Where(filter).
+		OrderBy("so.created_at DESC", "so.id DESC")
+	if limit > 0 {
+		sb = sb.Limit(uint64(limit))
+	}
+	if offset > 0 {
+		sb = sb.Offset(uint64(offset))
+	}
+
+	query, args := sb.MustSql()
+
+	db := s.trf.Transaction(ctx)
+	response := &GetScheduledOperationsResponse{Operations: make([]*domain.ScheduledOperation, 0, limit)}
+	if err := db.SelectContext(ctx, &response.Operations, query, args...); err != nil {
+		s.logger.Error("failed to get scheduled operations", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrBalanceInternal
+	}
+
+	countQuery, countArgs := sq.Select("COUNT(*)").
+		From("scheduled_operations so").
+		Where(filter).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+	if err := db.GetContext(ctx, &response.Total, countQuery, countArgs...); err != nil {
+		s.logger.Error("failed to count scheduled operations", zap.Error(err), zap.String("max_id", maxID))
+		return nil, ErrBalanceInternal
+	}
+
+	return response, nil
+}
+
+// An error from update is returned as is and leaves the operation untouched.
+func (s *SqlStorage) UpdateScheduledOperation(ctx context.Context, id string, update func(operation *domain.ScheduledOperation) error) (*domain.ScheduledOperation, error) {
+	var operation *domain.ScheduledOperation
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		var err error
+		operation, err = s.lockScheduledOperation(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := update(operation); err != nil {
+			return err
+		}
+
+		return s.saveScheduledOperation(txCtx, operation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// Other replicas skip the locked row and the run is recorded together with
+// its balance operation, so every occurrence runs once. Missed runs collapse
+// into one.
+func (s *SqlStorage) ExecuteDueScheduledOperation(ctx context.Context, now time.Time, next func(operation *domain.ScheduledOperation, after time.Time) *time.Time) (bool, error) {
+	found := false
+
+	err := s.TransactionManager.Do(ctx, func(txCtx context.Context) error {
+		db := s.trf.Transaction(txCtx)
+
+		query, args := selectScheduledOperations().
+			Where(sq.Eq{"so.status": domain.ScheduledOperationStatusActive}).
+			Where(sq.LtOrEq{"so.next_run_at": now}).
+			OrderBy("so.next_run_at").
+			Limit(1).
+			Suffix("FOR UPDATE OF so SKIP LOCKED").
+			MustSql()
+
+		var scheduled domain.ScheduledOperation
+		if err := db.GetContext(txCtx, &scheduled, query, args...); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			s.logger.Error("failed to select due scheduled operation", zap.Error(err))
+			return ErrBalanceInternal
+		}
+		found = true
+
+		scheduledFor := *scheduled.NextRunAt
+
+		var ran bool
+		err := db.GetContext(txCtx, &ran,
+			"SELECT EXISTS (SELECT 1 FROM scheduled_operation_runs WHERE scheduled_operation_id = $1 AND scheduled_for = $2)",
+			scheduled.ID,
+			scheduledFor,
+		)
+		if err != nil {
+			s.logger.Error("failed to check scheduled operation run", zap.Error(err), zap.String("scheduled_operation_id", scheduled.ID))
+			return ErrBalanceInternal
+		}
+
+		if !ran {
+			operationID, runErr := s.runScheduledOperation(txCtx, &scheduled)
+			if errors.Is(runErr, ErrBalanceInternal) {
+				return runErr
+			}
+
+			runError := ""
+			if runErr != nil {
+				s.logger.Warn("scheduled operation was rejected", zap.Error(runErr), zap.String("scheduled_operation_id", scheduled.ID))
+				runError = runErr.Error()
+			}
+
+			_, err = db.ExecContext(txCtx,
+				`INSERT INTO scheduled_operation_runs (scheduled_operation_id, scheduled_for, operation_id, error, created_at)
+				 VALUES ($1, $2, NULLIF($3, ''), $4, $5)`,
+				scheduled.ID,
+				scheduledFor,
+				operationID,
+				runError,
+				now,
+			)
+			if err != nil {
+				s.logger.Error("failed to record scheduled operation run", zap.Error(err), zap.String("scheduled_operation_id", scheduled.ID))
+				return ErrBalanceInternal
+			}
+		}
+
+		scheduled.LastRunAt = &now
+		scheduled.NextRunAt = next(&scheduled, now)
+		if scheduled.NextRunAt == nil {
+			scheduled.Status = domain.ScheduledOperationStatusCompleted
+		}
+
+		return s.saveScheduledOperation(txCtx, &scheduled)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+// Any error but ErrBalanceInternal means the operation was rejected.
+func (s *SqlStorage) runScheduledOperation(ctx context.Context, scheduled *domain.ScheduledOperation) (string, error) {
+	var balanceID string
+	err := s.trf.Transaction(ctx).GetContext(ctx, &balanceID,
+		"SELECT id FROM balances WHERE user_id = $1 AND wallet_type = $2",
+		scheduled.MaxID,
+		scheduled.WalletType,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrBalanceNotFound
+		}
+		s.logger.Error("failed to get balance for scheduled operation", zap.Error(err), zap.String("scheduled_operation_id", scheduled.ID))
+		return "", ErrBalanceInternal
+	}
+
+	balance, err := s.lockBalance(ctx, balanceID)
+	if err != nil {
+		return "", err
+	}
+
+	operation := &domain.BalanceOperation{
+		Amount:      scheduled.Amount,
+		Type:        scheduled.Type,
+		Description: scheduled.Description,
+		ReasonCode:  scheduled.ReasonCode,
+		Metadata:    scheduled.Metadata,
+	}
+	if err := s.createBalanceOperation(ctx, balance, operation); err != nil {
+		return "", err
+	}
+
+	return operation.ID, nil
+}
+
+func (s *SqlStorage) lockScheduledOperation(ctx context.Context, id string) (*domain.ScheduledOperation, error) {
+	query, args := selectScheduledOperations().
+		Where(sq.Eq{"so.id": id}).
+		Suffix("FOR UPDATE OF so").
+		MustSql()
+
+	var operation domain.ScheduledOperation
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &operation, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrScheduledOperationNotFound
+		}
+		s.logger.Error("failed to lock scheduled operation", zap.Error(err), zap.String("scheduled_operation_id", id))
+		return nil, ErrBalanceInternal
+	}
+
+	return &operation, nil
+}
+
+func (s *SqlStorage) saveScheduledOperation(ctx context.Context, operation *domain.ScheduledOperation) error {
+	operation.UpdatedAt = time.Now().UTC()
+	_, err := s.trf.Transaction(ctx).ExecContext(ctx,
+		"UPDATE scheduled_operations SET status = $1, next_run_at = $2, last_run_at = $3, updated_at = $4 WHERE id = $5",
+		operation.Status,
+		operation.NextRunAt,
+		operation.LastRunAt,
+		operation.UpdatedAt,
+		operation.ID,
+	)
+	if err != nil {
+		s.logger.Error("failed to update scheduled operation", zap.Error(err), zap.String("scheduled_operation_id", operation.ID))
+		return ErrBalanceInternal
+	}
+
+	return nil
+}
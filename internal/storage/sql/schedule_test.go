@@ -0,0 +1,165 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteDueScheduledOperation(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	// Nothing else is due this long ago, so other scheduled operations in the
+	// database stay out of the way.
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	hourly := func(_ *domain.ScheduledOperation, after time.Time) *time.Time {
+		next := after.Add(time.Hour)
+		return &next
+	}
+	once := func(*domain.ScheduledOperation, time.Time) *time.Time {
+		return nil
+	}
+	execute := func(now time.Time, next func(*domain.ScheduledOperation, time.Time) *time.Time) bool {
+		t.Helper()
+		found, err := s.ExecuteDueScheduledOperation(ctx, now, next)
+		if err != nil {
+			t.Fatalf("failed to execute scheduled operation: %v", err)
+		}
+		return found
+	}
+
+	scheduled, err := s.CreateScheduledOperation(ctx, &domain.ScheduledOperation{
+		MaxID:          balance.UserID,
+		WalletType:     domain.WalletTypePoints,
+		Amount:         10,
+		Type:           domain.BalanceOperationTypeDeposit,
+		Description:    "schedule test",
+		CronExpression: "0 * * * *",
+		NextRunAt:      &now,
+	})
+	if err != nil {
+		t.Fatalf("failed to create scheduled operation: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = s.UpdateScheduledOperation(ctx, scheduled.ID, func(operation *domain.ScheduledOperation) error {
+			operation.Status = domain.ScheduledOperationStatusCancelled
+			return nil
+		})
+	})
+
+	// Concurrent schedulers skip the locked row instead of running it again.
+	var (
+		wg    sync.WaitGroup
+		found atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := s.ExecuteDueScheduledOperation(ctx, now, hourly)
+			if err != nil {
+				t.Errorf("failed to execute scheduled operation: %v", err)
+			}
+			if ok {
+				found.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if found.Load() != 1 {
+		t.Errorf("%d schedulers ran the operation, want 1", found.Load())
+	}
+	if got := storedBalance(t, s, balance.ID); got != 10 {
+		t.Errorf("balance is %d after one run, want 10", got)
+	}
+
+	// A scheduler that crashed after the run was recorded but before the
+	// next run was saved must not create the operation a second time.
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE scheduled_operations SET next_run_at = $1 WHERE id = $2", now, scheduled.ID); err != nil {
+		t.Fatalf("failed to rewind scheduled operation: %v", err)
+	}
+	if !execute(now, hourly) {
+		t.Fatal("rewound operation was not found")
+	}
+	if got := storedBalance(t, s, balance.ID); got != 10 {
+		t.Errorf("balance is %d after a replayed run, want 10", got)
+	}
+
+	if execute(now.Add(30*time.Minute), hourly) {
+		t.Error("operation ran before it was due")
+	}
+	if !execute(now.Add(time.Hour), once) {
+		t.Fatal("operation did not run when due")
+	}
+	if got := storedBalance(t, s, balance.ID); got != 20 {
+		t.Errorf("balance is %d after two runs, want 20", got)
+	}
+
+	response, err := s.GetScheduledOperations(ctx, balance.UserID, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get scheduled operations: %v", err)
+	}
+	if len(response.Operations) != 1 {
+		t.Fatalf("got %d scheduled operations, want 1", len(response.Operations))
+	}
+	got := response.Operations[0]
+	if got.Status != domain.ScheduledOperationStatusCompleted || got.NextRunAt != nil || got.LastOperationID == "" || got.LastError != "" {
+		t.Errorf("finished operation is %+v", got)
+	}
+
+	var runs int
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &runs, "SELECT COUNT(*) FROM scheduled_operation_runs WHERE scheduled_operation_id = $1", scheduled.ID); err != nil {
+		t.Fatalf("failed to count runs: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("recorded %d runs, want 2", runs)
+	}
+}
+
+func TestExecuteDueScheduledOperationRejected(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+	deposit(t, s, balance, 5)
+
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	scheduled, err := s.CreateScheduledOperation(ctx, &domain.ScheduledOperation{
+		MaxID:       balance.UserID,
+		WalletType:  domain.WalletTypePoints,
+		Amount:      10,
+		Type:        domain.BalanceOperationTypeWithdraw,
+		Description: "schedule test",
+		NextRunAt:   &now,
+	})
+	if err != nil {
+		t.Fatalf("failed to create scheduled operation: %v", err)
+	}
+
+	found, err := s.ExecuteDueScheduledOperation(ctx, now, func(*domain.ScheduledOperation, time.Time) *time.Time {
+		return nil
+	})
+	if err != nil || !found {
+		t.Fatalf("failed to execute scheduled operation: found %t, %v", found, err)
+	}
+
+	// The rejected run is recorded and the operation still completes.
+	response, err := s.GetScheduledOperations(ctx, balance.UserID, []domain.ScheduledOperationStatus{domain.ScheduledOperationStatusCompleted}, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get scheduled operations: %v", err)
+	}
+	if response.Total != 1 || response.Operations[0].ID != scheduled.ID {
+		t.Fatalf("completed operations are %+v", response.Operations)
+	}
+	if got := response.Operations[0]; got.LastOperationID != "" || got.LastError == "" {
+		t.Errorf("rejected run reports operation %q and error %q", got.LastOperationID, got.LastError)
+	}
+	if got := storedBalance(t, s, balance.ID); got != 5 {
+		t.Errorf("balance is %d after a rejected run, want 5", got)
+	}
+}
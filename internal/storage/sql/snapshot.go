@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Operations are never backdated, so a snapshot stays valid forever.
+func (s *SqlStorage) GetBalanceAt(ctx context.Context, balanceID string, at time.Time) (int, error) {
+	var balance int
+	err := s.trf.Transaction(ctx).GetContext(ctx, &balance,
+		`SELECT COALESCE(snap.balance, 0) + COALESCE((
+			SELECT SUM(`+signedAmountSQL+`)
+			FROM balance_operations bo
+			WHERE bo.balance_id = $1
+			  AND bo.created_at <= $2
+			  AND bo.created_at >= COALESCE(snap.taken_at, '-infinity')
+		 ), 0)
+		 FROM (SELECT 1) one
+		 LEFT JOIN LATERAL (
+			SELECT taken_at, balance FROM balance_snapshots
+			WHERE balance_id = $1 AND taken_at <= $2
+			ORDER BY taken_at DESC
+			LIMIT 1
+		 ) snap ON true`,
+		balanceID,
+		at,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance at", zap.Error(err), zap.String("balance_id", balanceID), zap.Time("at", at))
+		return 0, ErrBalanceInternal
+	}
+
+	return balance, nil
+}
+
+func (s *SqlStorage) GetBalanceHistory(ctx context.Context, balanceID string, from time.Time, to time.Time, granularity domain.BalanceHistoryGranularity) ([]*domain.BalanceHistoryPoint, error) {
+	points := make([]*domain.BalanceHistoryPoint, 0, 32)
+	err := s.trf.Transaction(ctx).SelectContext(ctx, &points,
+		`WITH periods AS (
+			SELECT
+				p AT TIME ZONE 'UTC' AS period_start,
+				LEAST((p + ('1 ' || $4::text)::interval) AT TIME ZONE 'UTC', $3) AS period_end
+			FROM generate_series(
+				date_trunc($4::text, $2::timestamptz AT TIME ZONE 'UTC'),
+				$3::timestamptz AT TIME ZONE 'UTC',
+				('1 ' || $4::text)::interval
+			) p
+			WHERE p < $3::timestamptz AT TIME ZONE 'UTC'
+		)
+		SELECT
+			periods.period_start,
+			periods.period_end,
+			COALESCE(snap.balance, 0) + COALESCE((
+				SELECT SUM(`+signedAmountSQL+`)
+				FROM balance_operations bo
+				WHERE bo.balance_id = $1
+				  AND bo.created_at < periods.period_end
+				  AND bo.created_at >= COALESCE(snap.taken_at, '-infinity')
+			), 0) AS balance
+		FROM periods
+		LEFT JOIN LATERAL (
+			SELECT taken_at, balance FROM balance_snapshots
+			WHERE balance_id = $1 AND taken_at <= periods.period_end
+			ORDER BY taken_at DESC
+			LIMIT 1
+		) snap ON true
+		ORDER BY periods.period_start`,
+		balanceID,
+		from,
+		to,
+		granularity,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance history", zap.Error(err), zap.String("balance_id", balanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	return points, nil
+}
+
+// It returns an empty id once every balance has been visited.
+func (s *SqlStorage) SnapshotBalances(ctx context.Context, takenAt time.Time, afterBalanceID string, limit int) (string, error) {
+	db := s.trf.Transaction(ctx)
+
+	ids := make([]string, 0, limit)
+	err := db.SelectContext(ctx, &ids, "SELECT id FROM balances WHERE id > $1 ORDER BY id LIMIT $2", afterBalanceID, limit)
+	if err != nil {
+		s.logger.Error("failed to select balances to snapshot", zap.Error(err), zap.String("after_balance_id", afterBalanceID))
+		return "", ErrBalanceInternal
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO balance_snapshots (balance_id, taken_at, balance)
+		 SELECT b.id, $1, COALESCE(prev.balance, 0) + COALESCE((
+			SELECT SUM(`+signedAmountSQL+`)
+			FROM balance_operations bo
+			WHERE bo.balance_id = b.id
+			  AND bo.created_at < $1
+			  AND bo.created_at >= COALESCE(prev.taken_at, '-infinity')
+		 ), 0)
+		 FROM balances b
+		 LEFT JOIN LATERAL (
+			SELECT taken_at, balance FROM balance_snapshots
+			WHERE balance_id = b.id AND taken_at < $1
+			ORDER BY taken_at DESC
+			LIMIT 1
+		 ) prev ON true
+		 WHERE b.id > $2 AND b.id <= $3
+		 ON CONFLICT (balance_id, taken_at) DO NOTHING`,
+		takenAt,
+		afterBalanceID,
+		ids[len(ids)-1],
+	)
+	if err != nil {
+		s.logger.Error("failed to snapshot balances", zap.Error(err), zap.Time("taken_at", takenAt))
+		return "", ErrBalanceInternal
+	}
+
+	if len(ids) < limit {
+		return "", nil
+	}
+	return ids[len(ids)-1], nil
+}
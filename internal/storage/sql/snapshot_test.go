@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"testing"
+	"time"
+)
+
+func backdateOperation(t *testing.T, s *SqlStorage, operationID string, at time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := s.trf.Transaction(ctx).ExecContext(ctx, "UPDATE balance_operations SET created_at = $1 WHERE id = $2", at, operationID); err != nil {
+		t.Fatalf("failed to backdate operation: %v", err)
+	}
+}
+
+func TestGetBalanceAt(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	day := 24 * time.Hour
+	start := time.Now().UTC().Add(-10 * day).Truncate(day)
+
+	backdateOperation(t, s, deposit(t, s, balance, 100).ID, start.Add(time.Hour))
+	withdrawal, err := withdraw(ctx, s, balance, 30)
+	if err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+	backdateOperation(t, s, withdrawal.ID, start.Add(day+2*time.Hour))
+	backdateOperation(t, s, deposit(t, s, balance, 5).ID, start.Add(2*day))
+	backdateOperation(t, s, deposit(t, s, balance, 50).ID, start.Add(3*day+5*time.Hour))
+
+	check := func(stage string) {
+		t.Helper()
+		for _, tt := range []struct {
+			at   time.Time
+			want int
+		}{
+			{start, 0},
+			{start.Add(time.Hour), 100},
+			{start.Add(day + 3*time.Hour), 70},
+			{start.Add(2 * day), 75},
+			{start.Add(5 * day), 125},
+		} {
+			got, err := s.GetBalanceAt(ctx, balance.ID, tt.at)
+			if err != nil {
+				t.Fatalf("failed to get balance at %s: %v", tt.at, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s: balance at %s is %d, want %d", stage, tt.at, got, tt.want)
+			}
+		}
+
+		history, err := s.GetBalanceHistory(ctx, balance.ID, start, start.Add(4*day), domain.BalanceHistoryGranularityDay)
+		if err != nil {
+			t.Fatalf("failed to get balance history: %v", err)
+		}
+		want := []int{100, 70, 75, 125}
+		if len(history) != len(want) {
+			t.Fatalf("%s: history has %d points, want %d", stage, len(history), len(want))
+		}
+		for i, point := range history {
+			if !point.PeriodStart.Equal(start.Add(time.Duration(i)*day)) || point.Balance != want[i] {
+				t.Errorf("%s: day %d closes at %d from %s, want %d", stage, i, point.Balance, point.PeriodStart, want[i])
+			}
+		}
+	}
+
+	check("without snapshots")
+
+	// The snapshot is taken at the exact time of the 5 point deposit, which
+	// therefore belongs after it.
+	var after string
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &after, "SELECT COALESCE(MAX(id), '') FROM balances WHERE id < $1", balance.ID); err != nil {
+		t.Fatalf("failed to get preceding balance id: %v", err)
+	}
+	if _, err := s.SnapshotBalances(ctx, start.Add(2*day), after, 1); err != nil {
+		t.Fatalf("failed to snapshot balances: %v", err)
+	}
+	var snapshot int
+	if err := s.trf.Transaction(ctx).GetContext(ctx, &snapshot, "SELECT balance FROM balance_snapshots WHERE balance_id = $1", balance.ID); err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if snapshot != 70 {
+		t.Errorf("snapshot holds %d, want 70", snapshot)
+	}
+
+	check("with a snapshot")
+}
@@ -0,0 +1,108 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// statsOperationsSQL leaves out reversals and the operations they reversed.
+const statsOperationsSQL = `bo.balance_id = $1
+	AND bo.reverses_operation_id IS NULL
+	AND NOT EXISTS (SELECT 1 FROM balance_operations r WHERE r.reverses_operation_id = bo.id)`
+
+// At most groupLimit groups are returned, the busiest first.
+func (s *SqlStorage) GetBalanceStats(ctx context.Context, balanceID string, from time.Time, to time.Time, groupBy domain.BalanceStatsGroupBy, groupLimit int) (*domain.BalanceStats, error) {
+	db := s.trf.Transaction(ctx)
+
+	var stats domain.BalanceStats
+	err := db.GetContext(ctx, &stats,
+		`SELECT
+			COALESCE(SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` > 0 AND bo.created_at >= $2 AND bo.created_at < $3), 0) AS earned,
+			COALESCE(-SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` < 0 AND bo.created_at >= $2 AND bo.created_at < $3), 0) AS spent,
+			COALESCE(SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` > 0), 0) AS lifetime_earned,
+			COALESCE(-SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` < 0), 0) AS lifetime_spent
+		 FROM balance_operations bo
+		 WHERE `+statsOperationsSQL,
+		balanceID,
+		from,
+		to,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance stats", zap.Error(err), zap.String("balance_id", balanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	stats.Months = make([]*domain.BalanceStatsMonth, 0, 12)
+	err = db.SelectContext(ctx, &stats.Months,
+		`WITH months AS (
+			SELECT
+				GREATEST(m AT TIME ZONE 'UTC', $2) AS month_start,
+				LEAST((m + interval '1 month') AT TIME ZONE 'UTC', $3) AS month_end
+			FROM generate_series(
+				date_trunc('month', $2::timestamptz AT TIME ZONE 'UTC'),
+				$3::timestamptz AT TIME ZONE 'UTC',
+				interval '1 month'
+			) m
+			WHERE m < $3::timestamptz AT TIME ZONE 'UTC'
+		)
+		SELECT
+			months.month_start,
+			COALESCE(SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` > 0), 0) AS earned,
+			COALESCE(-SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` < 0), 0) AS spent
+		FROM months
+		LEFT JOIN balance_operations bo
+			ON bo.created_at >= months.month_start
+			AND bo.created_at < months.month_end
+			AND `+statsOperationsSQL+`
+		GROUP BY months.month_start
+		ORDER BY months.month_start`,
+		balanceID,
+		from,
+		to,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance stats by month", zap.Error(err), zap.String("balance_id", balanceID))
+		return nil, ErrBalanceInternal
+	}
+
+	var key string
+	switch groupBy {
+	case "":
+		return &stats, nil
+	case domain.BalanceStatsGroupByReason:
+		key = "bo.reason_code"
+	case domain.BalanceStatsGroupByDescription:
+		key = "bo.description"
+	default:
+		return nil, ErrBalanceInvalid
+	}
+
+	stats.Groups = make([]*domain.BalanceStatsGroup, 0, 8)
+	err = db.SelectContext(ctx, &stats.Groups,
+		`SELECT
+			`+key+` AS key,
+			COUNT(*) AS count,
+			COALESCE(SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` > 0), 0) AS earned,
+			COALESCE(-SUM(`+signedAmountSQL+`) FILTER (WHERE `+signedAmountSQL+` < 0), 0) AS spent
+		 FROM balance_operations bo
+		 WHERE `+statsOperationsSQL+`
+		   AND bo.created_at >= $2
+		   AND bo.created_at < $3
+		 GROUP BY 1
+		 ORDER BY count DESC, key
+		 LIMIT $4`,
+		balanceID,
+		from,
+		to,
+		groupLimit,
+	)
+	if err != nil {
+		s.logger.Error("failed to get balance stats by group", zap.Error(err), zap.String("balance_id", balanceID), zap.String("group_by", string(groupBy)))
+		return nil, ErrBalanceInternal
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"DobrikaDev/user-service/internal/domain"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetBalanceStats(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	balance := newTestBalance(t, s)
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month()-3, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	from := month.AddDate(0, 0, 15)
+	to := month.AddDate(0, 2, 10)
+
+	create := func(operationType domain.BalanceOperationType, amount int, description string, at time.Time) *domain.BalanceOperation {
+		t.Helper()
+		operation, err := s.CreateBalanceOperation(ctx, &domain.BalanceOperation{
+			BalanceID:   balance.ID,
+			Amount:      amount,
+			Type:        operationType,
+			Description: description,
+		})
+		if err != nil {
+			t.Fatalf("failed to create operation: %v", err)
+		}
+		backdateOperation(t, s, operation.ID, at)
+		return operation
+	}
+
+	create(domain.BalanceOperationTypeDeposit, 100, "old", month.Add(day))
+	create(domain.BalanceOperationTypeDeposit, 40, "quest", month.AddDate(0, 0, 20))
+	create(domain.BalanceOperationTypeWithdraw, 15, "shop", month.AddDate(0, 1, 2))
+	reversed := create(domain.BalanceOperationTypeDeposit, 25, "quest", month.AddDate(0, 1, 5))
+	create(domain.BalanceOperationTypeDeposit, 10, "quest", month.AddDate(0, 2, 3))
+	create(domain.BalanceOperationTypeDeposit, 7, "late", month.AddDate(0, 2, 20))
+
+	// Neither the reversed deposit nor its reversal shows up anywhere.
+	if _, err := s.ReverseBalanceOperation(ctx, reversed.ID, "stats test"); err != nil {
+		t.Fatalf("failed to reverse deposit: %v", err)
+	}
+
+	stats, err := s.GetBalanceStats(ctx, balance.ID, from, to, domain.BalanceStatsGroupByDescription, 1)
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if stats.Earned != 50 || stats.Spent != 15 || stats.LifetimeEarned != 157 || stats.LifetimeSpent != 15 {
+		t.Errorf("totals are %+v", stats)
+	}
+
+	wantMonths := []domain.BalanceStatsMonth{
+		{MonthStart: from, Earned: 40},
+		{MonthStart: month.AddDate(0, 1, 0), Spent: 15},
+		{MonthStart: month.AddDate(0, 2, 0), Earned: 10},
+	}
+	if len(stats.Months) != len(wantMonths) {
+		t.Fatalf("histogram has %d months, want %d", len(stats.Months), len(wantMonths))
+	}
+	for i, got := range stats.Months {
+		want := wantMonths[i]
+		if !got.MonthStart.Equal(want.MonthStart) || got.Earned != want.Earned || got.Spent != want.Spent {
+			t.Errorf("month %d is %+v, want %+v", i, got, want)
+		}
+	}
+
+	if len(stats.Groups) != 1 {
+		t.Fatalf("got %d groups, want 1", len(stats.Groups))
+	}
+	if got := stats.Groups[0]; got.Key != "quest" || got.Count != 2 || got.Earned != 50 || got.Spent != 0 {
+		t.Errorf("busiest group is %+v", got)
+	}
+
+	stats, err = s.GetBalanceStats(ctx, balance.ID, from, to, "", 1)
+	if err != nil {
+		t.Fatalf("failed to get stats without groups: %v", err)
+	}
+	if len(stats.Groups) != 0 {
+		t.Errorf("got %d groups without grouping", len(stats.Groups))
+	}
+}
@@ -15,13 +15,15 @@ import (
 type SqlStorage struct {
 	trf deps.TransactionFactory
 	deps.TransactionManager
+	cfg    *config.Config
 	logger *zap.Logger
 }
 
-func NewStorage(trf deps.TransactionFactory, trm deps.TransactionManager, logger *zap.Logger) *SqlStorage {
+func NewStorage(trf deps.TransactionFactory, trm deps.TransactionManager, cfg *config.Config, logger *zap.Logger) *SqlStorage {
 	return &SqlStorage{
 		trf:                trf,
 		TransactionManager: trm,
+		cfg:                cfg,
 		logger:             logger,
 	}
 }
@@ -16,6 +16,7 @@ import (
 const (
 	pgErrUniqueViolation     = "23505"
 	pgErrForeignKeyViolation = "23503"
+	pgErrCheckViolation      = "23514"
 )
 
 type GetUsersResponse struct {
@@ -169,40 +170,9 @@ func (s *SqlStorage) CreateUser(ctx context.Context, user *domain.User) (*domain
 			return ErrUserInternal
 		}
 
-		var balanceID string
-		balanceQueryErr := tx.QueryRowContext(
-			txCtx,
-			"SELECT id FROM balances WHERE user_id = $1",
-			created.MaxID,
-		).Scan(&balanceID)
-		if balanceQueryErr != nil {
-			if errors.Is(balanceQueryErr, sql.ErrNoRows) {
-				balanceID = uuid.NewString()
-				if _, err := tx.ExecContext(
-					txCtx,
-					"INSERT INTO balances (id, user_id, balance, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)",
-					balanceID,
-					created.MaxID,
-					0,
-					now,
-					now,
-				); err != nil {
-					var pgErr *pgconn.PgError
-					if errors.As(err, &pgErr) {
-						switch pgErr.Code {
-						case pgErrUniqueViolation:
-							return ErrBalanceAlreadyExists
-						case pgErrForeignKeyViolation:
-							return ErrUserInvalid
-						}
-					}
-
-					s.logger.Error("failed to create balance for user", zap.Error(err), zap.String("max_id", created.MaxID))
-					return ErrUserInternal
-				}
-			} else {
-				s.logger.Error("failed to get balance for user", zap.Error(balanceQueryErr), zap.String("max_id", created.MaxID))
-				return ErrUserInternal
+		for _, walletType := range walletTypes {
+			if err := s.createWallet(txCtx, created.MaxID, walletType, now); err != nil {
+				return err
 			}
 		}
 
@@ -429,3 +399,60 @@ func (s *SqlStorage) CountUsers(ctx context.Context) (int, error) {
 
 	return total, nil
 }
+
+var walletTypes = []domain.WalletType{
+	domain.WalletTypePoints,
+	domain.WalletTypeKarma,
+	domain.WalletTypeEventTokens,
+}
+
+// createWallet does nothing if the wallet already exists.
+func (s *SqlStorage) createWallet(ctx context.Context, maxID string, walletType domain.WalletType, now time.Time) error {
+	tx := s.trf.Transaction(ctx)
+
+	var balanceID string
+	err := tx.QueryRowContext(
+		ctx,
+		"SELECT id FROM balances WHERE user_id = $1 AND wallet_type = $2",
+		maxID,
+		walletType,
+	).Scan(&balanceID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Error("failed to get balance for user", zap.Error(err), zap.String("max_id", maxID), zap.String("wallet_type", walletType.String()))
+		return ErrUserInternal
+	}
+
+	balanceID = uuid.NewString()
+	if _, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO balances (id, user_id, wallet_type, balance, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		balanceID,
+		maxID,
+		walletType,
+		0,
+		now,
+		now,
+	); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgErrUniqueViolation:
+				return ErrBalanceAlreadyExists
+			case pgErrForeignKeyViolation:
+				return ErrUserInvalid
+			}
+		}
+
+		s.logger.Error("failed to create balance for user", zap.Error(err), zap.String("max_id", maxID), zap.String("wallet_type", walletType.String()))
+		return ErrUserInternal
+	}
+
+	if err := s.createWalletLedgerAccount(ctx, balanceID); err != nil {
+		return ErrUserInternal
+	}
+
+	return nil
+}
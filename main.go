@@ -24,6 +24,15 @@ func main() {
 		container.GetRpcServer(),
 	)
 
+	go container.GetIdempotencyService().RunCleanup(ctx)
+	go container.GetBalanceService().RunHoldExpiry(ctx)
+	go container.GetBalanceService().RunPointsExpiry(ctx)
+	go container.GetBalanceService().RunBalanceSnapshots(ctx)
+	go container.GetBalanceService().RunBalanceListener(ctx)
+	go container.GetBalanceService().RunLeaderboardRefresh(ctx)
+	go container.GetBalanceService().RunScheduledOperations(ctx)
+	go container.GetReputationService().RunReputationDecay(ctx)
+
 	logger.Info("Starting application with port", zap.String("port", cfg.Port))
 
 	err := container.GetGRPCServer().Serve(*container.GetNetListener())
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NOT VALID: balances that already drifted below zero must not stop the
+-- migration. New writes are checked right away; the reconciler validates the
+-- constraint once no negative balance is left.
+ALTER TABLE balances
+    ADD CONSTRAINT balances_balance_non_negative
+    CHECK (balance >= 0) NOT VALID;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS balances_balance_non_negative;
+-- +goose StatementEnd
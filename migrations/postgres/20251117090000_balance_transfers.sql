@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE balance_operations ADD COLUMN transfer_id VARCHAR(255);
+
+CREATE INDEX balance_operations_transfer_id_idx ON balance_operations (transfer_id) WHERE transfer_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS balance_operations_transfer_id_idx;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS transfer_id;
+-- +goose StatementEnd
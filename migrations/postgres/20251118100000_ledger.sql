@@ -0,0 +1,73 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ledger_accounts (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    kind VARCHAR(255) NOT NULL,
+    balance_id VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE ledger_accounts
+    ADD CONSTRAINT ledger_accounts_balance_id_fkey
+    FOREIGN KEY (balance_id) REFERENCES balances(id);
+
+INSERT INTO ledger_accounts (id, kind)
+VALUES
+    ('system:rewards_issued', 'system'),
+    ('system:redemptions', 'system'),
+    ('system:adjustments', 'system'),
+    ('system:transfers', 'system');
+
+INSERT INTO ledger_accounts (id, kind, balance_id)
+SELECT 'wallet:' || id, 'wallet', id FROM balances;
+
+CREATE TABLE ledger_entries (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    operation_id VARCHAR(255) NOT NULL,
+    account_id VARCHAR(255) NOT NULL,
+    direction VARCHAR(16) NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount INT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE ledger_entries
+    ADD CONSTRAINT ledger_entries_operation_id_fkey
+    FOREIGN KEY (operation_id) REFERENCES balance_operations(id);
+
+ALTER TABLE ledger_entries
+    ADD CONSTRAINT ledger_entries_account_id_fkey
+    FOREIGN KEY (account_id) REFERENCES ledger_accounts(id);
+
+CREATE INDEX ledger_entries_operation_id_idx ON ledger_entries (operation_id);
+CREATE INDEX ledger_entries_account_id_idx ON ledger_entries (account_id);
+
+INSERT INTO ledger_entries (id, operation_id, account_id, direction, amount, created_at)
+SELECT gen_random_uuid()::text, bo.id, e.account_id, e.direction, bo.amount, bo.created_at
+FROM balance_operations bo
+CROSS JOIN LATERAL (
+    VALUES
+        (
+            CASE
+                WHEN bo.type = 'withdraw' THEN 'wallet:' || bo.balance_id
+                WHEN bo.transfer_id IS NOT NULL THEN 'system:transfers'
+                ELSE 'system:rewards_issued'
+            END,
+            'debit'
+        ),
+        (
+            CASE
+                WHEN bo.type = 'deposit' THEN 'wallet:' || bo.balance_id
+                WHEN bo.transfer_id IS NOT NULL THEN 'system:transfers'
+                ELSE 'system:redemptions'
+            END,
+            'credit'
+        )
+) AS e(account_id, direction)
+WHERE bo.amount > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE ledger_entries;
+DROP TABLE ledger_accounts;
+-- +goose StatementEnd
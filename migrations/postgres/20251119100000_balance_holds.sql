@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE balances ADD COLUMN held INT NOT NULL DEFAULT 0;
+
+-- NOT VALID for the same reason as balances_balance_non_negative: a balance
+-- already below zero fails it. The reconciler validates both together.
+ALTER TABLE balances
+    ADD CONSTRAINT balances_held_within_balance
+    CHECK (held >= 0 AND held <= balance) NOT VALID;
+
+CREATE TABLE balance_holds (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    balance_id VARCHAR(255) NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    captured_amount INT NOT NULL DEFAULT 0,
+    status VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    operation_id VARCHAR(255),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE balance_holds
+    ADD CONSTRAINT balance_holds_balance_id_fkey
+    FOREIGN KEY (balance_id) REFERENCES balances(id);
+
+ALTER TABLE balance_holds
+    ADD CONSTRAINT balance_holds_operation_id_fkey
+    FOREIGN KEY (operation_id) REFERENCES balance_operations(id);
+
+CREATE INDEX balance_holds_active_expires_at_idx ON balance_holds (expires_at) WHERE status = 'active';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE balance_holds;
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS balances_held_within_balance;
+ALTER TABLE balances DROP COLUMN IF EXISTS held;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE balance_operations ADD COLUMN reverses_operation_id VARCHAR(255) UNIQUE;
+
+ALTER TABLE balance_operations
+    ADD CONSTRAINT balance_operations_reverses_operation_id_fkey
+    FOREIGN KEY (reverses_operation_id) REFERENCES balance_operations(id);
+
+ALTER TABLE balance_operations ADD COLUMN reputation_amount INT NOT NULL DEFAULT 0;
+
+UPDATE balance_operations
+SET reputation_amount = amount
+WHERE type = 'deposit' AND transfer_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS reputation_amount;
+ALTER TABLE balance_operations DROP CONSTRAINT IF EXISTS balance_operations_reverses_operation_id_fkey;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS reverses_operation_id;
+-- +goose StatementEnd
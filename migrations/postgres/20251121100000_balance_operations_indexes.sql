@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX balances_user_id_idx ON balances (user_id);
+
+CREATE INDEX balance_operations_balance_id_created_at_id_idx
+    ON balance_operations (balance_id, created_at DESC, id DESC);
+
+CREATE INDEX balance_operations_balance_id_type_created_at_idx
+    ON balance_operations (balance_id, type, created_at DESC);
+
+CREATE INDEX balance_operations_balance_id_amount_idx
+    ON balance_operations (balance_id, amount);
+
+CREATE INDEX balance_operations_description_trgm_idx
+    ON balance_operations USING gin (description gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS balance_operations_description_trgm_idx;
+DROP INDEX IF EXISTS balance_operations_balance_id_amount_idx;
+DROP INDEX IF EXISTS balance_operations_balance_id_type_created_at_idx;
+DROP INDEX IF EXISTS balance_operations_balance_id_created_at_id_idx;
+DROP INDEX IF EXISTS balances_user_id_idx;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE balance_operations ADD COLUMN reason_code VARCHAR(64) NOT NULL DEFAULT 'other';
+ALTER TABLE balance_operations ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+UPDATE balance_operations SET reason_code = 'transfer' WHERE transfer_id IS NOT NULL;
+UPDATE balance_operations SET reason_code = 'reversal' WHERE reverses_operation_id IS NOT NULL;
+UPDATE balance_operations SET reason_code = 'adjustment' WHERE type = 'adjustment';
+
+CREATE INDEX balance_operations_balance_id_reason_code_created_at_idx
+    ON balance_operations (balance_id, reason_code, created_at DESC);
+
+CREATE INDEX balance_operations_reason_code_created_at_idx
+    ON balance_operations (reason_code, created_at);
+
+CREATE INDEX balance_operations_metadata_idx
+    ON balance_operations USING gin (metadata jsonb_path_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS balance_operations_metadata_idx;
+DROP INDEX IF EXISTS balance_operations_reason_code_created_at_idx;
+DROP INDEX IF EXISTS balance_operations_balance_id_reason_code_created_at_idx;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS metadata;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS reason_code;
+-- +goose StatementEnd
@@ -0,0 +1,85 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE balance_lots (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    balance_id VARCHAR(255) NOT NULL,
+    operation_id VARCHAR(255),
+    amount INT NOT NULL CHECK (amount > 0),
+    remaining INT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT balance_lots_remaining_within_amount CHECK (remaining >= 0 AND remaining <= amount)
+);
+
+ALTER TABLE balance_lots
+    ADD CONSTRAINT balance_lots_balance_id_fkey
+    FOREIGN KEY (balance_id) REFERENCES balances(id);
+
+ALTER TABLE balance_lots
+    ADD CONSTRAINT balance_lots_operation_id_fkey
+    FOREIGN KEY (operation_id) REFERENCES balance_operations(id);
+
+CREATE INDEX balance_lots_open_balance_id_expires_at_idx
+    ON balance_lots (balance_id, expires_at, created_at) WHERE remaining > 0;
+
+CREATE INDEX balance_lots_open_expires_at_idx
+    ON balance_lots (expires_at) WHERE remaining > 0;
+
+INSERT INTO ledger_accounts (id, kind) VALUES ('system:expired', 'system');
+
+-- Rebuild open lots from the newest credits backwards until they cover the
+-- current balance. Points that would already be past their expiry get a
+-- 30-day grace period so the first expiry run does not wipe them silently.
+WITH credits AS (
+    SELECT
+        bo.id,
+        bo.balance_id,
+        bo.amount,
+        bo.created_at,
+        SUM(bo.amount) OVER (
+            PARTITION BY bo.balance_id
+            ORDER BY bo.created_at DESC, bo.id DESC
+        ) AS running
+    FROM balance_operations bo
+    WHERE bo.type = 'deposit' AND bo.amount > 0
+)
+INSERT INTO balance_lots (id, balance_id, operation_id, amount, remaining, expires_at, created_at)
+SELECT
+    gen_random_uuid()::text,
+    c.balance_id,
+    c.id,
+    c.amount,
+    LEAST(c.amount, b.balance - (c.running - c.amount)),
+    GREATEST(c.created_at + INTERVAL '12 months', now() + INTERVAL '30 days'),
+    c.created_at
+FROM credits c
+JOIN balances b ON b.id = c.balance_id
+WHERE b.balance - (c.running - c.amount) > 0;
+
+-- Whatever the deposits do not explain (positive adjustments, manual fixes)
+-- becomes a single lot starting today.
+INSERT INTO balance_lots (id, balance_id, amount, remaining, expires_at)
+SELECT gen_random_uuid()::text, b.id, b.balance - COALESCE(l.total, 0), b.balance - COALESCE(l.total, 0), now() + INTERVAL '12 months'
+FROM balances b
+LEFT JOIN (
+    SELECT balance_id, SUM(remaining) AS total FROM balance_lots GROUP BY balance_id
+) l ON l.balance_id = b.id
+WHERE b.balance > COALESCE(l.total, 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Expired points are gone from the balances, so their operations cannot be
+-- dropped without breaking reconciliation, nor kept without the account
+-- their ledger entries post to.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM balance_operations WHERE type = 'expire') THEN
+        RAISE EXCEPTION 'cannot roll back balance_lots: points have already expired';
+    END IF;
+END
+$$;
+
+DROP TABLE balance_lots;
+DELETE FROM ledger_accounts WHERE id = 'system:expired';
+-- +goose StatementEnd
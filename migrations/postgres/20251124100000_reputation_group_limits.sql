@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reputation_group_limits (
+    reputation_group_id INT NOT NULL,
+    direction VARCHAR(16) NOT NULL CHECK (direction IN ('earn', 'spend')),
+    window_seconds INT NOT NULL CHECK (window_seconds > 0),
+    max_amount INT NOT NULL CHECK (max_amount >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (reputation_group_id, direction, window_seconds)
+);
+
+ALTER TABLE reputation_group_limits
+    ADD CONSTRAINT reputation_group_limits_reputation_group_id_fkey
+    FOREIGN KEY (reputation_group_id) REFERENCES reputation_groups(id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reputation_group_limits;
+-- +goose StatementEnd
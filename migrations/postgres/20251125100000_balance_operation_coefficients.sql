@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE balance_operations ADD COLUMN base_amount INT;
+ALTER TABLE balance_operations ADD COLUMN coefficient NUMERIC(4,2);
+
+ALTER TABLE balance_operations
+    ADD CONSTRAINT balance_operations_coefficient_pair
+    CHECK ((base_amount IS NULL) = (coefficient IS NULL));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE balance_operations DROP CONSTRAINT IF EXISTS balance_operations_coefficient_pair;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS coefficient;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS base_amount;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE balance_snapshots (
+    balance_id VARCHAR(255) NOT NULL,
+    taken_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    balance INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (balance_id, taken_at)
+);
+
+ALTER TABLE balance_snapshots
+    ADD CONSTRAINT balance_snapshots_balance_id_fkey
+    FOREIGN KEY (balance_id) REFERENCES balances(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE balance_snapshots;
+-- +goose StatementEnd
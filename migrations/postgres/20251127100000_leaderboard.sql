@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Earned points are what counts towards reputation: deposits in full, net of
+-- reversals, transfers excluded. Months and weeks are UTC calendar periods as
+-- of the last refresh.
+CREATE MATERIALIZED VIEW leaderboard_stats AS
+SELECT
+    u.max_id,
+    u.geolocation,
+    u.reputation_group_id,
+    COALESCE(b.balance, 0) AS balance,
+    COALESCE(SUM(bo.reputation_amount), 0)::INT AS earned_all_time,
+    COALESCE(SUM(bo.reputation_amount) FILTER (
+        WHERE bo.created_at >= date_trunc('month', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+    ), 0)::INT AS earned_month,
+    COALESCE(SUM(bo.reputation_amount) FILTER (
+        WHERE bo.created_at >= date_trunc('week', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+    ), 0)::INT AS earned_week,
+    now() AS refreshed_at
+FROM users u
+LEFT JOIN balances b ON b.user_id = u.max_id
+LEFT JOIN balance_operations bo ON bo.balance_id = b.id
+WHERE u.status = 'active'
+GROUP BY u.max_id, u.geolocation, u.reputation_group_id, b.balance;
+
+CREATE UNIQUE INDEX leaderboard_stats_max_id_idx ON leaderboard_stats (max_id);
+CREATE INDEX leaderboard_stats_balance_idx ON leaderboard_stats (balance DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_all_time_idx ON leaderboard_stats (earned_all_time DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_month_idx ON leaderboard_stats (earned_month DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_week_idx ON leaderboard_stats (earned_week DESC, max_id);
+CREATE INDEX leaderboard_stats_geolocation_idx ON leaderboard_stats (geolocation);
+CREATE INDEX leaderboard_stats_reputation_group_id_idx ON leaderboard_stats (reputation_group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP MATERIALIZED VIEW IF EXISTS leaderboard_stats;
+-- +goose StatementEnd
@@ -0,0 +1,104 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every user gets one balance per wallet type. The existing balances become
+-- the points wallets.
+ALTER TABLE balances ADD COLUMN wallet_type VARCHAR(32) NOT NULL DEFAULT 'points'
+    CHECK (wallet_type IN ('points', 'karma', 'event_tokens'));
+
+ALTER TABLE balances
+    ADD CONSTRAINT balances_user_id_wallet_type_key UNIQUE (user_id, wallet_type);
+
+ALTER TABLE balance_operations ADD COLUMN wallet_type VARCHAR(32) NOT NULL DEFAULT 'points';
+
+CREATE INDEX balance_operations_wallet_type_created_at_idx
+    ON balance_operations (wallet_type, created_at);
+
+INSERT INTO balances (id, user_id, balance, held, wallet_type)
+SELECT gen_random_uuid()::text, u.max_id, 0, 0, w.wallet_type
+FROM users u
+CROSS JOIN (VALUES ('karma'), ('event_tokens')) AS w(wallet_type)
+WHERE EXISTS (SELECT 1 FROM balances b WHERE b.user_id = u.max_id);
+
+INSERT INTO ledger_accounts (id, kind, balance_id)
+SELECT 'wallet:' || id, 'wallet', id FROM balances WHERE wallet_type <> 'points';
+
+-- The leaderboard ranks by the points balance; earned amounts keep summing
+-- reputation over every wallet.
+DROP MATERIALIZED VIEW leaderboard_stats;
+
+CREATE MATERIALIZED VIEW leaderboard_stats AS
+SELECT
+    u.max_id,
+    u.geolocation,
+    u.reputation_group_id,
+    COALESCE(p.balance, 0) AS balance,
+    COALESCE(SUM(bo.reputation_amount), 0)::INT AS earned_all_time,
+    COALESCE(SUM(bo.reputation_amount) FILTER (
+        WHERE bo.created_at >= date_trunc('month', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+    ), 0)::INT AS earned_month,
+    COALESCE(SUM(bo.reputation_amount) FILTER (
+        WHERE bo.created_at >= date_trunc('week', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+    ), 0)::INT AS earned_week,
+    now() AS refreshed_at
+FROM users u
+LEFT JOIN balances p ON p.user_id = u.max_id AND p.wallet_type = 'points'
+LEFT JOIN balances b ON b.user_id = u.max_id
+LEFT JOIN balance_operations bo ON bo.balance_id = b.id
+WHERE u.status = 'active'
+GROUP BY u.max_id, u.geolocation, u.reputation_group_id, p.balance;
+
+CREATE UNIQUE INDEX leaderboard_stats_max_id_idx ON leaderboard_stats (max_id);
+CREATE INDEX leaderboard_stats_balance_idx ON leaderboard_stats (balance DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_all_time_idx ON leaderboard_stats (earned_all_time DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_month_idx ON leaderboard_stats (earned_month DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_week_idx ON leaderboard_stats (earned_week DESC, max_id);
+CREATE INDEX leaderboard_stats_geolocation_idx ON leaderboard_stats (geolocation);
+CREATE INDEX leaderboard_stats_reputation_group_id_idx ON leaderboard_stats (reputation_group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP MATERIALIZED VIEW IF EXISTS leaderboard_stats;
+
+DELETE FROM ledger_entries WHERE account_id IN (SELECT 'wallet:' || id FROM balances WHERE wallet_type <> 'points')
+    OR operation_id IN (SELECT id FROM balance_operations WHERE wallet_type <> 'points');
+DELETE FROM ledger_accounts WHERE balance_id IN (SELECT id FROM balances WHERE wallet_type <> 'points');
+DELETE FROM balance_lots WHERE balance_id IN (SELECT id FROM balances WHERE wallet_type <> 'points');
+DELETE FROM balance_snapshots WHERE balance_id IN (SELECT id FROM balances WHERE wallet_type <> 'points');
+DELETE FROM balance_holds WHERE balance_id IN (SELECT id FROM balances WHERE wallet_type <> 'points');
+DELETE FROM balance_operations WHERE wallet_type <> 'points';
+DELETE FROM balances WHERE wallet_type <> 'points';
+
+DROP INDEX IF EXISTS balance_operations_wallet_type_created_at_idx;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS wallet_type;
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS balances_user_id_wallet_type_key;
+ALTER TABLE balances DROP COLUMN IF EXISTS wallet_type;
+
+CREATE MATERIALIZED VIEW leaderboard_stats AS
+SELECT
+    u.max_id,
+    u.geolocation,
+    u.reputation_group_id,
+    COALESCE(b.balance, 0) AS balance,
+    COALESCE(SUM(bo.reputation_amount), 0)::INT AS earned_all_time,
+    COALESCE(SUM(bo.reputation_amount) FILTER (
+        WHERE bo.created_at >= date_trunc('month', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+    ), 0)::INT AS earned_month,
+    COALESCE(SUM(bo.reputation_amount) FILTER (
+        WHERE bo.created_at >= date_trunc('week', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+    ), 0)::INT AS earned_week,
+    now() AS refreshed_at
+FROM users u
+LEFT JOIN balances b ON b.user_id = u.max_id
+LEFT JOIN balance_operations bo ON bo.balance_id = b.id
+WHERE u.status = 'active'
+GROUP BY u.max_id, u.geolocation, u.reputation_group_id, b.balance;
+
+CREATE UNIQUE INDEX leaderboard_stats_max_id_idx ON leaderboard_stats (max_id);
+CREATE INDEX leaderboard_stats_balance_idx ON leaderboard_stats (balance DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_all_time_idx ON leaderboard_stats (earned_all_time DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_month_idx ON leaderboard_stats (earned_month DESC, max_id);
+CREATE INDEX leaderboard_stats_earned_week_idx ON leaderboard_stats (earned_week DESC, max_id);
+CREATE INDEX leaderboard_stats_geolocation_idx ON leaderboard_stats (geolocation);
+CREATE INDEX leaderboard_stats_reputation_group_id_idx ON leaderboard_stats (reputation_group_id);
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A scheduled operation either runs once at run_at or repeats on a cron
+-- schedule (UTC). next_run_at is NULL once nothing is left to run.
+CREATE TABLE scheduled_operations (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_type VARCHAR(32) NOT NULL DEFAULT 'points',
+    amount INT NOT NULL CHECK (amount > 0),
+    type VARCHAR(255) NOT NULL CHECK (type IN ('deposit', 'withdraw')),
+    description TEXT NOT NULL,
+    reason_code VARCHAR(64) NOT NULL DEFAULT 'other',
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    cron_expression VARCHAR(255),
+    status VARCHAR(16) NOT NULL CHECK (status IN ('active', 'paused', 'cancelled', 'completed')),
+    next_run_at TIMESTAMP WITH TIME ZONE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE scheduled_operations
+    ADD CONSTRAINT scheduled_operations_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(max_id);
+
+CREATE INDEX scheduled_operations_due_idx
+    ON scheduled_operations (next_run_at)
+    WHERE status = 'active';
+
+CREATE INDEX scheduled_operations_user_id_created_at_idx
+    ON scheduled_operations (user_id, created_at DESC);
+
+-- One row per occurrence. The primary key is what makes an occurrence run at
+-- most once; it is written in the same transaction as the operation itself.
+CREATE TABLE scheduled_operation_runs (
+    scheduled_operation_id VARCHAR(255) NOT NULL,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    operation_id VARCHAR(255),
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (scheduled_operation_id, scheduled_for)
+);
+
+ALTER TABLE scheduled_operation_runs
+    ADD CONSTRAINT scheduled_operation_runs_scheduled_operation_id_fkey
+    FOREIGN KEY (scheduled_operation_id) REFERENCES scheduled_operations(id);
+
+ALTER TABLE scheduled_operation_runs
+    ADD CONSTRAINT scheduled_operation_runs_operation_id_fkey
+    FOREIGN KEY (operation_id) REFERENCES balance_operations(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE scheduled_operation_runs;
+DROP TABLE scheduled_operations;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Manual adjustments record the admin who made them and why. Adjustments
+-- written by the reconciler have no actor.
+ALTER TABLE balance_operations ADD COLUMN actor_max_id VARCHAR(255);
+ALTER TABLE balance_operations ADD COLUMN justification TEXT;
+
+ALTER TABLE balance_operations
+    ADD CONSTRAINT balance_operations_actor_max_id_fkey
+    FOREIGN KEY (actor_max_id) REFERENCES users(max_id);
+
+ALTER TABLE balance_operations
+    ADD CONSTRAINT balance_operations_actor_justified
+    CHECK (actor_max_id IS NULL OR (type = 'adjustment' AND COALESCE(justification, '') <> ''));
+
+CREATE INDEX balance_operations_actor_max_id_idx
+    ON balance_operations (actor_max_id, created_at)
+    WHERE actor_max_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS balance_operations_actor_max_id_idx;
+ALTER TABLE balance_operations DROP CONSTRAINT IF EXISTS balance_operations_actor_justified;
+ALTER TABLE balance_operations DROP CONSTRAINT IF EXISTS balance_operations_actor_max_id_fkey;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS justification;
+ALTER TABLE balance_operations DROP COLUMN IF EXISTS actor_max_id;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Groups used to be seeded with fixed ids; new ones take theirs from a
+-- sequence that continues after the seed.
+CREATE SEQUENCE reputation_groups_id_seq OWNED BY reputation_groups.id;
+SELECT setval('reputation_groups_id_seq', (SELECT COALESCE(MAX(id), 1) FROM reputation_groups));
+ALTER TABLE reputation_groups ALTER COLUMN id SET DEFAULT nextval('reputation_groups_id_seq');
+
+ALTER TABLE reputation_groups
+    ADD CONSTRAINT reputation_groups_coefficient_positive
+    CHECK (coefficient > 0);
+
+ALTER TABLE reputation_groups
+    ADD CONSTRAINT reputation_groups_reputation_need_non_negative
+    CHECK (reputation_need >= 0);
+
+-- Distinct thresholds keep the groups strictly ordered.
+ALTER TABLE reputation_groups
+    ADD CONSTRAINT reputation_groups_reputation_need_key
+    UNIQUE (reputation_need);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reputation_groups DROP CONSTRAINT IF EXISTS reputation_groups_reputation_need_key;
+ALTER TABLE reputation_groups DROP CONSTRAINT IF EXISTS reputation_groups_reputation_need_non_negative;
+ALTER TABLE reputation_groups DROP CONSTRAINT IF EXISTS reputation_groups_coefficient_positive;
+ALTER TABLE reputation_groups ALTER COLUMN id DROP DEFAULT;
+DROP SEQUENCE IF EXISTS reputation_groups_id_seq;
+-- +goose StatementEnd
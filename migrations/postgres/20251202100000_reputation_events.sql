@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reputation is its own score now, kept on the user and explained by the
+-- event log. Karma deposits and flagged adjustments feed it through events,
+-- and reversing them takes those events back.
+ALTER TABLE users ADD COLUMN reputation INT NOT NULL DEFAULT 0;
+
+CREATE TABLE reputation_events (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    amount INT NOT NULL CHECK (amount <> 0),
+    reason VARCHAR(64) NOT NULL CHECK (reason IN ('task_completed', 'event_participation', 'moderation_penalty', 'adjustment', 'migration', 'other')),
+    description TEXT NOT NULL DEFAULT '',
+    reference_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE reputation_events
+    ADD CONSTRAINT reputation_events_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(max_id);
+
+CREATE INDEX reputation_events_user_id_created_at_idx
+    ON reputation_events (user_id, created_at DESC, id DESC);
+
+-- A task or moderation case moves a user's reputation once.
+CREATE UNIQUE INDEX reputation_events_reference_idx
+    ON reputation_events (user_id, reason, reference_id)
+    WHERE reference_id IS NOT NULL;
+
+-- Seed every score with what used to count as reputation: deposits net of
+-- reversals, transfers excluded.
+INSERT INTO reputation_events (id, user_id, amount, reason, description)
+SELECT gen_random_uuid()::text, b.user_id, SUM(bo.reputation_amount), 'migration', 'deposit total before reputation events'
+FROM balance_operations bo
+JOIN balances b ON b.id = bo.balance_id
+GROUP BY b.user_id
+HAVING SUM(bo.reputation_amount) <> 0;
+
+UPDATE users u
+SET reputation = e.amount
+FROM reputation_events e
+WHERE e.user_id = u.max_id AND e.reason = 'migration';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reputation_events;
+ALTER TABLE users DROP COLUMN IF EXISTS reputation;
+-- +goose StatementEnd
@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+-- reputation_active_at is the last time the user gained reputation, and
+-- reputation_decayed_at the last time inactivity cost them some. A user is
+-- decayed at most once per inactive period.
+ALTER TABLE users ADD COLUMN reputation_active_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE users ADD COLUMN reputation_decayed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users u
+SET reputation_active_at = COALESCE((
+    SELECT MAX(bo.created_at)
+    FROM balance_operations bo
+    JOIN balances b ON b.id = bo.balance_id
+    WHERE b.user_id = u.max_id AND bo.reputation_amount > 0
+), u.created_at);
+
+CREATE INDEX users_reputation_active_at_idx
+    ON users (reputation_active_at)
+    WHERE reputation > 0;
+
+ALTER TABLE reputation_events DROP CONSTRAINT reputation_events_reason_check;
+ALTER TABLE reputation_events
+    ADD CONSTRAINT reputation_events_reason_check
+    CHECK (reason IN ('task_completed', 'event_participation', 'moderation_penalty', 'adjustment', 'migration', 'decay', 'other'));
+
+-- Group names are copied so a demotion can still be told after a group is
+-- renamed or deleted.
+CREATE TABLE reputation_demotions (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    from_group_id INT NOT NULL,
+    from_group_name VARCHAR(255) NOT NULL,
+    to_group_id INT NOT NULL,
+    to_group_name VARCHAR(255) NOT NULL,
+    score INT NOT NULL,
+    reputation_event_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE reputation_demotions
+    ADD CONSTRAINT reputation_demotions_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(max_id);
+
+ALTER TABLE reputation_demotions
+    ADD CONSTRAINT reputation_demotions_reputation_event_id_fkey
+    FOREIGN KEY (reputation_event_id) REFERENCES reputation_events(id);
+
+CREATE INDEX reputation_demotions_user_id_created_at_idx
+    ON reputation_demotions (user_id, created_at DESC);
+
+CREATE INDEX reputation_demotions_created_at_idx
+    ON reputation_demotions (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reputation_demotions;
+-- Scores keep whatever decay took from them.
+DELETE FROM reputation_events WHERE reason = 'decay';
+ALTER TABLE reputation_events DROP CONSTRAINT reputation_events_reason_check;
+ALTER TABLE reputation_events
+    ADD CONSTRAINT reputation_events_reason_check
+    CHECK (reason IN ('task_completed', 'event_participation', 'moderation_penalty', 'adjustment', 'migration', 'other'));
+DROP INDEX IF EXISTS users_reputation_active_at_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS reputation_decayed_at;
+ALTER TABLE users DROP COLUMN IF EXISTS reputation_active_at;
+-- +goose StatementEnd
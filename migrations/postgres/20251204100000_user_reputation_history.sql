@@ -0,0 +1,92 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every move between groups, up or down. Group names are copied so an entry
+-- still reads right after a group is renamed or deleted. A change caused by a
+-- reputation event points at it; reference_id is the event's reference, such
+-- as the balance operation behind an adjustment.
+CREATE TABLE user_reputation_history (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    from_group_id INT NOT NULL,
+    from_group_name VARCHAR(255) NOT NULL,
+    to_group_id INT NOT NULL,
+    to_group_name VARCHAR(255) NOT NULL,
+    promoted BOOLEAN NOT NULL,
+    score INT NOT NULL,
+    cause VARCHAR(64) NOT NULL CHECK (cause IN ('reputation_event', 'admin', 'decay')),
+    reputation_event_id VARCHAR(255),
+    reference_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE user_reputation_history
+    ADD CONSTRAINT user_reputation_history_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(max_id);
+
+ALTER TABLE user_reputation_history
+    ADD CONSTRAINT user_reputation_history_reputation_event_id_fkey
+    FOREIGN KEY (reputation_event_id) REFERENCES reputation_events(id);
+
+CREATE INDEX user_reputation_history_user_id_created_at_idx
+    ON user_reputation_history (user_id, created_at DESC, id DESC);
+
+-- Demotions were the only group changes recorded so far.
+INSERT INTO user_reputation_history (id, user_id, from_group_id, from_group_name, to_group_id, to_group_name, promoted, score, cause, reputation_event_id, reference_id, created_at)
+SELECT
+    d.id,
+    d.user_id,
+    d.from_group_id,
+    d.from_group_name,
+    d.to_group_id,
+    d.to_group_name,
+    false,
+    d.score,
+    CASE e.reason
+        WHEN 'decay' THEN 'decay'
+        WHEN 'adjustment' THEN 'admin'
+        ELSE 'reputation_event'
+    END,
+    d.reputation_event_id,
+    e.reference_id,
+    d.created_at
+FROM reputation_demotions d
+LEFT JOIN reputation_events e ON e.id = d.reputation_event_id;
+
+DROP TABLE reputation_demotions;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE reputation_demotions (
+    id VARCHAR(255) PRIMARY KEY NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    from_group_id INT NOT NULL,
+    from_group_name VARCHAR(255) NOT NULL,
+    to_group_id INT NOT NULL,
+    to_group_name VARCHAR(255) NOT NULL,
+    score INT NOT NULL,
+    reputation_event_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE reputation_demotions
+    ADD CONSTRAINT reputation_demotions_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(max_id);
+
+ALTER TABLE reputation_demotions
+    ADD CONSTRAINT reputation_demotions_reputation_event_id_fkey
+    FOREIGN KEY (reputation_event_id) REFERENCES reputation_events(id);
+
+CREATE INDEX reputation_demotions_user_id_created_at_idx
+    ON reputation_demotions (user_id, created_at DESC);
+
+CREATE INDEX reputation_demotions_created_at_idx
+    ON reputation_demotions (created_at);
+
+INSERT INTO reputation_demotions (id, user_id, from_group_id, from_group_name, to_group_id, to_group_name, score, reputation_event_id, created_at)
+SELECT id, user_id, from_group_id, from_group_name, to_group_id, to_group_name, score, reputation_event_id, created_at
+FROM user_reputation_history
+WHERE NOT promoted;
+
+DROP TABLE user_reputation_history;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reputation_history DROP CONSTRAINT user_reputation_history_cause_check;
+ALTER TABLE user_reputation_history
+    ADD CONSTRAINT user_reputation_history_cause_check
+    CHECK (cause IN ('reputation_event', 'admin', 'decay', 'recalculation'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM user_reputation_history WHERE cause = 'recalculation';
+ALTER TABLE user_reputation_history DROP CONSTRAINT user_reputation_history_cause_check;
+ALTER TABLE user_reputation_history
+    ADD CONSTRAINT user_reputation_history_cause_check
+    CHECK (cause IN ('reputation_event', 'admin', 'decay'));
+-- +goose StatementEnd
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"DobrikaDev/user-service/di"
+	"DobrikaDev/user-service/internal/domain"
+	"DobrikaDev/user-service/utils/config"
+	"DobrikaDev/user-service/utils/logger"
+	"context"
+	"flag"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+const batchSize = 500
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report which users would move between groups")
+	after := flag.String("after", "", "resume after this max_id, as logged by an interrupted run")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := config.MustLoadConfigFromFile("deployments/config.yaml")
+	logger, _ := logger.NewLogger()
+	defer logger.Sync()
+	container := di.NewContainer(ctx, cfg, logger)
+
+	total := &domain.ReputationRecalculationReport{DryRun: *dryRun}
+	moves := make(map[[2]int]*domain.ReputationGroupMove)
+
+	// Each call covers one batch, committed on its own, so the logged cursor
+	// is always safe to resume from with -after.
+	next := *after
+	for {
+		report, err := container.GetReputationService().RecalculateReputationGroups(ctx, *dryRun, next, batchSize)
+		if err != nil {
+			logger.Error("Error recalculating reputation groups:", zap.Error(err), zap.String("after", next))
+			os.Exit(1)
+		}
+
+		total.Scanned += report.Scanned
+		total.Moved += report.Moved
+		for _, move := range report.Moves {
+			key := [2]int{move.FromGroupID, move.ToGroupID}
+			if existing, ok := moves[key]; ok {
+				existing.Count += move.Count
+				continue
+			}
+			moves[key] = move
+			total.Moves = append(total.Moves, move)
+		}
+
+		if report.NextMaxID == "" {
+			break
+		}
+		next = report.NextMaxID
+
+		logger.Info("Recalculation batch committed",
+			zap.Bool("dry_run", total.DryRun),
+			zap.Int("scanned", total.Scanned),
+			zap.Int("moved", total.Moved),
+			zap.String("resume_after", next),
+		)
+	}
+
+	for _, move := range total.Moves {
+		logger.Info("Reputation group move",
+			zap.Int("from_group_id", move.FromGroupID),
+			zap.String("from_group_name", move.FromGroupName),
+			zap.Int("to_group_id", move.ToGroupID),
+			zap.String("to_group_name", move.ToGroupName),
+			zap.Bool("promoted", move.Promoted),
+			zap.Int("count", move.Count),
+		)
+	}
+
+	logger.Info("Recalculation completed",
+		zap.Bool("dry_run", total.DryRun),
+		zap.Int("scanned", total.Scanned),
+		zap.Int("moved", total.Moved),
+	)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"DobrikaDev/user-service/di"
+	"DobrikaDev/user-service/utils/config"
+	"DobrikaDev/user-service/utils/logger"
+	"context"
+	"flag"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "write an adjustment operation for every mismatch and validate the non-negative balance constraint")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := config.MustLoadConfigFromFile("deployments/config.yaml")
+	logger, _ := logger.NewLogger()
+	defer logger.Sync()
+	container := di.NewContainer(ctx, cfg, logger)
+
+	report, err := container.GetBalanceService().Reconcile(ctx, *fix)
+	if err != nil {
+		logger.Error("Error reconciling balances:", zap.Error(err))
+		os.Exit(1)
+	}
+
+	logger.Info("Reconciliation completed",
+		zap.Int("scanned", report.Scanned),
+		zap.Int("mismatches", len(report.Mismatches)),
+		zap.Int("adjusted", report.Adjusted),
+		zap.Int("negative", len(report.NegativeBalances)),
+	)
+
+	if len(report.Mismatches) > report.Adjusted || len(report.NegativeBalances) > 0 {
+		os.Exit(2)
+	}
+}
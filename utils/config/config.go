@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/spf13/viper"
 )
@@ -9,6 +11,14 @@ type Config struct {
 	Port string `mapstructure:"port" env:"PORT"`
 
 	SQL DB `mapstructure:"sql" env-prefix:"POSTGRES_"`
+
+	Idempotency Idempotency `mapstructure:"idempotency" env-prefix:"IDEMPOTENCY_"`
+	Holds       Holds       `mapstructure:"holds" env-prefix:"HOLDS_"`
+	Points      Points      `mapstructure:"points" env-prefix:"POINTS_"`
+	Snapshots   Snapshots   `mapstructure:"snapshots" env-prefix:"SNAPSHOTS_"`
+	Leaderboard Leaderboard `mapstructure:"leaderboard" env-prefix:"LEADERBOARD_"`
+	Scheduler   Scheduler   `mapstructure:"scheduler" env-prefix:"SCHEDULER_"`
+	Reputation  Reputation  `mapstructure:"reputation" env-prefix:"REPUTATION_"`
 }
 
 type DB struct {
@@ -19,6 +29,42 @@ type DB struct {
 	Name     string `mapstructure:"name" env:"NAME"`
 }
 
+type Idempotency struct {
+	TTL             time.Duration `mapstructure:"ttl" env:"TTL"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval" env:"CLEANUP_INTERVAL"`
+}
+
+type Holds struct {
+	DefaultTTL     time.Duration `mapstructure:"default_ttl" env:"DEFAULT_TTL"`
+	ExpiryInterval time.Duration `mapstructure:"expiry_interval" env:"EXPIRY_INTERVAL"`
+}
+
+type Points struct {
+	Lifetime            time.Duration `mapstructure:"lifetime" env:"LIFETIME"`
+	EventTokensLifetime time.Duration `mapstructure:"event_tokens_lifetime" env:"EVENT_TOKENS_LIFETIME"`
+	ExpiringWindow      time.Duration `mapstructure:"expiring_window" env:"EXPIRING_WINDOW"`
+	ExpiryInterval      time.Duration `mapstructure:"expiry_interval" env:"EXPIRY_INTERVAL"`
+}
+
+type Snapshots struct {
+	Interval time.Duration `mapstructure:"interval" env:"INTERVAL"`
+}
+
+type Leaderboard struct {
+	RefreshInterval time.Duration `mapstructure:"refresh_interval" env:"REFRESH_INTERVAL"`
+}
+
+type Scheduler struct {
+	Interval time.Duration `mapstructure:"interval" env:"INTERVAL"`
+}
+
+// A zero DecayRate turns decay off.
+type Reputation struct {
+	DecayRate     float64       `mapstructure:"decay_rate" env:"DECAY_RATE"`
+	DecayPeriod   time.Duration `mapstructure:"decay_period" env:"DECAY_PERIOD"`
+	DecayInterval time.Duration `mapstructure:"decay_interval" env:"DECAY_INTERVAL"`
+}
+
 func LoadConfigFromFile(path string) (*Config, error) {
 	config := new(Config)
 	viper.SetConfigFile(path)